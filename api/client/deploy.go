@@ -30,6 +30,78 @@ func (c *Client) GetReleaseWebhook(
 	return resp, err
 }
 
+// CreateReleaseWebhook creates the deploy webhook for a given release, or updates the
+// signing settings of its existing webhook
+func (c *Client) CreateReleaseWebhook(
+	ctx context.Context,
+	projID, clusterID uint,
+	name, namespace string,
+	req *types.CreateWebhookRequest,
+) (*types.PorterRelease, error) {
+	resp := &types.PorterRelease{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/webhook",
+			projID,
+			clusterID,
+			namespace,
+			name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetAutoRollback retrieves the auto rollback setting for a given release
+func (c *Client) GetAutoRollback(
+	ctx context.Context,
+	projID, clusterID uint,
+	name, namespace string,
+) (*types.GetAutoRollbackResponse, error) {
+	resp := &types.GetAutoRollbackResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/auto_rollback",
+			projID,
+			clusterID,
+			namespace,
+			name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateAutoRollback updates the auto rollback setting for a given release
+func (c *Client) UpdateAutoRollback(
+	ctx context.Context,
+	projID, clusterID uint,
+	name, namespace string,
+	req *types.UpdateAutoRollbackRequest,
+) (*types.GetAutoRollbackResponse, error) {
+	resp := &types.GetAutoRollbackResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/auto_rollback",
+			projID,
+			clusterID,
+			namespace,
+			name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // DeployWithWebhook deploys an application with an image tag using a unique webhook URI
 func (c *Client) DeployWithWebhook(
 	ctx context.Context,
@@ -119,3 +119,60 @@ func (c *Client) DeleteDeployment(
 		nil, nil,
 	)
 }
+
+func (c *Client) CreateGitlabDeployment(
+	ctx context.Context,
+	projID, clusterID, environmentID uint,
+	req *types.CreateGitlabDeploymentRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environments/%d/gitlab/deployment",
+			projID, clusterID, environmentID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+func (c *Client) FinalizeGitlabDeployment(
+	ctx context.Context,
+	projID, clusterID, environmentID uint,
+	req *types.FinalizeDeploymentRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environments/%d/gitlab/deployment/finalize",
+			projID, clusterID, environmentID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+func (c *Client) UpdateGitlabDeploymentStatus(
+	ctx context.Context,
+	projID, clusterID, environmentID uint,
+	req *types.UpdateGitlabDeploymentStatusRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environments/%d/gitlab/deployment/status",
+			projID, clusterID, environmentID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
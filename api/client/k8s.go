@@ -99,6 +99,27 @@ func (c *Client) GetKubeconfig(
 	return resp, err
 }
 
+// ListEnvGroups lists the latest versions of the env groups in a namespace
+func (c *Client) ListEnvGroups(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+) (types.ListEnvGroupsResponse, error) {
+	resp := types.ListEnvGroupsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroups/list",
+			projectID, clusterID,
+			namespace,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
 func (c *Client) GetEnvGroup(
 	ctx context.Context,
 	projectID, clusterID uint,
@@ -162,6 +183,118 @@ func (c *Client) CloneEnvGroup(
 	return resp, err
 }
 
+// GetEnvGroupDiff gets the variables that were added, removed or changed between two versions
+// of an env group
+func (c *Client) GetEnvGroupDiff(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetEnvGroupDiffRequest,
+) (*types.GetEnvGroupDiffResponse, error) {
+	resp := &types.GetEnvGroupDiffResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/diff",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RollbackEnvGroup creates a new version of an env group with the variables of an older version
+func (c *Client) RollbackEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.RollbackEnvGroupRequest,
+) (*types.EnvGroup, error) {
+	resp := &types.EnvGroup{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/rollback",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListEnvGroupSources lists the external secret manager sources of an env group
+func (c *Client) ListEnvGroupSources(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.ListEnvGroupSourcesRequest,
+) (types.ListEnvGroupSourcesResponse, error) {
+	resp := types.ListEnvGroupSourcesResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/sources",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// UpdateEnvGroupSources replaces the external secret manager sources of an env group and
+// syncs them
+func (c *Client) UpdateEnvGroupSources(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.UpdateEnvGroupSourcesRequest,
+) (*types.SyncEnvGroupResponse, error) {
+	resp := &types.SyncEnvGroupResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/sources",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// SyncEnvGroup syncs an env group with its external secret manager sources
+func (c *Client) SyncEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.SyncEnvGroupRequest,
+) (*types.SyncEnvGroupResponse, error) {
+	resp := &types.SyncEnvGroupResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/sync",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 func (c *Client) GetRelease(
 	ctx context.Context,
 	projectID, clusterID uint,
@@ -204,6 +337,27 @@ func (c *Client) GetJobs(
 	return *resp, err
 }
 
+// GetJobsStatus gets the status of the most recent run of a job release
+func (c *Client) GetJobsStatus(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) (*types.GetJobsStatusResponse, error) {
+	resp := &types.GetJobsStatusResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/jobs/status",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
 // GetK8sAllPods gets all pods for a given release
 func (c *Client) GetK8sAllPods(
 	ctx context.Context,
@@ -224,3 +378,45 @@ func (c *Client) GetK8sAllPods(
 
 	return resp, err
 }
+
+// GetPreviousPodLogs gets the logs of the previous instance of a container in a pod
+func (c *Client) GetPreviousPodLogs(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.GetPreviousPodLogsRequest,
+) (*types.GetPreviousPodLogsResponse, error) {
+	resp := &types.GetPreviousPodLogsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/pod/%s/previous_logs",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// SearchLogs searches the historical logs of a release that were captured by the porter agent
+func (c *Client) SearchLogs(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *types.SearchLogsRequest,
+) (*types.SearchLogsResponse, error) {
+	resp := &types.SearchLogsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/logs/search",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
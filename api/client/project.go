@@ -177,3 +177,187 @@ func (c *Client) DeleteProject(
 		nil,
 	)
 }
+
+// ListProjectCollaborators lists the collaborators of a project, along with their
+// roles and any custom policies bound to them
+func (c *Client) ListProjectCollaborators(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListCollaboratorsResponse, error) {
+	resp := &types.ListCollaboratorsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/collaborators",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateProjectMFA sets whether multi-factor authentication is required to access a project
+func (c *Client) UpdateProjectMFA(
+	ctx context.Context,
+	projectID uint,
+	req *types.UpdateProjectMFARequest,
+) (*types.Project, error) {
+	resp := &types.Project{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/mfa",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateProjectEnvGroupRetention sets the number of versions of each env group kept in the
+// clusters of a project
+func (c *Client) UpdateProjectEnvGroupRetention(
+	ctx context.Context,
+	projectID uint,
+	req *types.UpdateEnvGroupRetentionRequest,
+) (*types.Project, error) {
+	resp := &types.Project{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/env_group_retention",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateClusterEnvGroupRetention sets the number of versions of each env group kept in a
+// cluster, overriding the retention of the project
+func (c *Client) UpdateClusterEnvGroupRetention(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *types.UpdateEnvGroupRetentionRequest,
+) (*types.Cluster, error) {
+	resp := &types.Cluster{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/env_group_retention",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetEnvGroupRetentionReport lists the env group versions that the retention policy of a
+// cluster would delete
+func (c *Client) GetEnvGroupRetentionReport(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *types.GetEnvGroupRetentionReportRequest,
+) (*types.EnvGroupRetentionReport, error) {
+	resp := &types.EnvGroupRetentionReport{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/env_group_retention/report",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ResetCollaboratorMFA disables multi-factor authentication for a collaborator of a project.
+// Only the admin user of the instance can reset multi-factor authentication.
+func (c *Client) ResetCollaboratorMFA(
+	ctx context.Context,
+	projectID uint,
+	req *types.ResetCollaboratorMFARequest,
+) (*types.User, error) {
+	resp := &types.User{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/collaborators/mfa/reset",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateProjectRole updates the role of a project collaborator. When the role kind
+// is "custom", the request must also reference a policy in the project.
+func (c *Client) UpdateProjectRole(
+	ctx context.Context,
+	projectID uint,
+	req *types.UpdateRoleRequest,
+) (*types.UpdateRoleResponse, error) {
+	resp := &types.UpdateRoleResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/roles",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListProjectPolicies lists the custom policies in a project
+func (c *Client) ListProjectPolicies(
+	ctx context.Context,
+	projectID uint,
+) ([]*types.APIPolicyMeta, error) {
+	resp := make([]*types.APIPolicyMeta, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/policies",
+			projectID,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// ListAuditLogs lists the audit events of a project, most recent first
+func (c *Client) ListAuditLogs(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListAuditEventsRequest,
+) (*types.ListAuditEventsResponse, error) {
+	resp := &types.ListAuditEventsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/audit_logs",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -47,6 +47,47 @@ func (c *Client) CreateHelmRepo(
 	return resp, err
 }
 
+// ListHelmRepos returns a list of helm repos for a project
+func (c *Client) ListHelmRepos(
+	ctx context.Context,
+	projectID uint,
+) ([]*types.HelmRepo, error) {
+	resp := make([]*types.HelmRepo, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/helmrepos",
+			projectID,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// GetHelmRepoChart gets a chart from a helm repo in the project, authenticating
+// with the helm repo's integration
+func (c *Client) GetHelmRepoChart(
+	ctx context.Context,
+	projectID, helmRepoID uint,
+	name, version string,
+) (*types.GetTemplateResponse, error) {
+	resp := &types.GetTemplateResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/helmrepos/%d/charts/%s/%s",
+			projectID, helmRepoID,
+			name, version,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
 // ListRegistries returns a list of registries for a project
 func (c *Client) ListRegistries(
 	ctx context.Context,
@@ -240,3 +281,47 @@ func (c *Client) CreateRepository(
 		nil,
 	)
 }
+
+// UpdateRegistryImageRetention sets the rules that decide which images are deleted from the
+// repositories of a registry
+func (c *Client) UpdateRegistryImageRetention(
+	ctx context.Context,
+	projectID, regID uint,
+	req *types.UpdateImageRetentionRequest,
+) (*types.Registry, error) {
+	resp := &types.Registry{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/image_retention",
+			projectID,
+			regID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetImageRetentionPreview lists the images that the retention rules of a registry would
+// delete
+func (c *Client) GetImageRetentionPreview(
+	ctx context.Context,
+	projectID, regID uint,
+	req *types.GetImageRetentionPreviewRequest,
+) (*types.ImageRetentionReport, error) {
+	resp := &types.ImageRetentionReport{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/image_retention/preview",
+			projectID,
+			regID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
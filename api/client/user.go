@@ -37,6 +37,64 @@ func (c *Client) Login(ctx context.Context, req *types.LoginUserRequest) (*types
 	return resp, err
 }
 
+// LoginMFA verifies a one-time password or recovery code for a user whose login
+// response required a second factor, and authenticates the cookie-based session
+func (c *Client) LoginMFA(ctx context.Context, req *types.LoginMFARequest) (*types.GetAuthenticatedUserResponse, error) {
+	resp := &types.GetAuthenticatedUserResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/login/mfa",
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// EnrollTOTP generates a new TOTP secret for the current user
+func (c *Client) EnrollTOTP(ctx context.Context) (*types.EnrollTOTPResponse, error) {
+	resp := &types.EnrollTOTPResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/users/current/mfa/totp",
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// VerifyTOTP enables TOTP for the current user by verifying a one-time password, and
+// returns the user's recovery codes
+func (c *Client) VerifyTOTP(ctx context.Context, req *types.VerifyTOTPRequest) (*types.VerifyTOTPResponse, error) {
+	resp := &types.VerifyTOTPResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/users/current/mfa/totp/verify",
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DisableTOTP disables TOTP for the current user
+func (c *Client) DisableTOTP(ctx context.Context, req *types.DisableTOTPRequest) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/users/current/mfa/totp",
+		),
+		req,
+		nil,
+	)
+}
+
 // Logout logs the user out and deauthorizes the cookie-based session
 func (c *Client) Logout(ctx context.Context) error {
 	err := c.postRequest(
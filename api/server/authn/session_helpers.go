@@ -2,6 +2,7 @@ package authn
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/internal/models"
@@ -29,6 +30,11 @@ func SaveUserAuthenticated(
 	session.Values["user_id"] = user.ID
 	session.Values["email"] = user.Email
 
+	// clear any pending second factor verification
+	session.Values["mfa_user_id"] = nil
+	session.Values["mfa_started_at"] = nil
+	session.Values["mfa_attempts"] = nil
+
 	// we unset the redirect uri after login
 	session.Values["redirect_uri"] = ""
 
@@ -51,3 +57,28 @@ func SaveUserUnauthenticated(
 	session.Values["email"] = nil
 	return session.Save(r, w)
 }
+
+// SaveUserMFAPending stores a user who has verified their password, but has not yet
+// verified a one-time password, in the session. The session remains unauthenticated
+// until the second factor is verified.
+func SaveUserMFAPending(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+	user *models.User,
+) error {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+
+	if err != nil {
+		return err
+	}
+
+	session.Values["authenticated"] = false
+	session.Values["user_id"] = nil
+	session.Values["email"] = nil
+	session.Values["mfa_user_id"] = user.ID
+	session.Values["mfa_started_at"] = time.Now().Unix()
+	session.Values["mfa_attempts"] = 0
+
+	return session.Save(r, w)
+}
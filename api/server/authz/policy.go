@@ -40,7 +40,7 @@ type PolicyHandler struct {
 
 func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// get the full map of scopes to resource actions
-	reqScopes, reqErr := getRequestActionForEndpoint(r, h.endpointMeta)
+	reqScopes, reqErr := GetRequestActionForEndpoint(r, h.endpointMeta)
 
 	if reqErr != nil {
 		apierrors.HandleAPIError(h.config.Logger, h.config.Alerter, w, r, reqErr, true)
@@ -100,7 +100,9 @@ func NewRequestScopeCtx(ctx context.Context, reqScopes map[types.PermissionScope
 	return context.WithValue(ctx, types.RequestScopeCtxKey, reqScopes)
 }
 
-func getRequestActionForEndpoint(
+// GetRequestActionForEndpoint resolves the resource of every scope of an endpoint from
+// the URL parameters of the request
+func GetRequestActionForEndpoint(
 	r *http.Request,
 	endpointMeta types.APIRequestMetadata,
 ) (res map[types.PermissionScope]*types.RequestAction, reqErr apierrors.RequestError) {
@@ -70,6 +70,20 @@ func (b *RepoPolicyDocumentLoader) LoadPolicyDocuments(
 			return types.DeveloperPolicy, nil
 		case types.RoleViewer:
 			return types.ViewerPolicy, nil
+		case types.RoleCustom:
+			if role.PolicyUID == "" || b.policyRepo == nil {
+				return nil, apierrors.NewErrForbidden(
+					fmt.Errorf("custom role for user %d, project %d is not bound to a policy", userID, projectID),
+				)
+			}
+
+			apiPolicy, reqErr := GetAPIPolicyFromUID(b.policyRepo, projectID, role.PolicyUID)
+
+			if reqErr != nil {
+				return nil, reqErr
+			}
+
+			return apiPolicy.Policy, nil
 		default:
 			return nil, apierrors.NewErrForbidden(
 				fmt.Errorf("%s role not supported for user %d, project %d", string(role.Kind), userID, projectID),
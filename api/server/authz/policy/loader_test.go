@@ -1,6 +1,7 @@
 package policy_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -16,6 +17,7 @@ import (
 type basicLoaderTest struct {
 	description      string
 	roleKind         types.RoleKind
+	policyUID        string
 	expErr           bool
 	expErrString     string
 	expErrStatusCode int
@@ -39,11 +41,54 @@ var basicLoaderTests = []basicLoaderTest{
 		expPolicy:   types.ViewerPolicy,
 	},
 	{
-		description:      "should not load custom policy for basic loader",
+		description: "should load custom policy bound to role",
+		roleKind:    types.RoleCustom,
+		policyUID:   "custom-policy",
+		expPolicy:   customPolicy,
+	},
+	{
+		description: "should load preset policy bound to custom role",
+		roleKind:    types.RoleCustom,
+		policyUID:   "viewer",
+		expPolicy:   types.ViewerPolicy,
+	},
+	{
+		description:      "should not load custom role without policy",
 		roleKind:         types.RoleCustom,
 		expErr:           true,
 		expErrStatusCode: http.StatusForbidden,
-		expErrString:     "custom role not supported for user 1, project 1",
+		expErrString:     "custom role for user 1, project 1 is not bound to a policy",
+	},
+	{
+		description:      "should not load unsupported role kind",
+		roleKind:         types.RoleKind("unknown"),
+		expErr:           true,
+		expErrStatusCode: http.StatusForbidden,
+		expErrString:     "unknown role not supported for user 1, project 1",
+	},
+}
+
+var customPolicy = []*types.PolicyDocument{
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadWriteVerbGroup(),
+						Resources: []types.NameOrUInt{
+							{
+								Name: "staging",
+							},
+						},
+					},
+				},
+			},
+		},
 	},
 }
 
@@ -53,7 +98,8 @@ func TestBasicPolicyDocumentLoader(t *testing.T) {
 	for _, basicTest := range basicLoaderTests {
 		// use the in-memory project repo
 		projRepo := test.NewProjectRepository(true)
-		loader := policy.NewBasicPolicyDocumentLoader(projRepo, nil)
+		policyRepo := test.NewPolicyRepository(true)
+		loader := policy.NewBasicPolicyDocumentLoader(projRepo, policyRepo)
 
 		project := &models.Project{
 			Name: "test-project",
@@ -67,11 +113,29 @@ func TestBasicPolicyDocumentLoader(t *testing.T) {
 			t.Fatalf("%v", err)
 		}
 
+		policyBytes, err := json.Marshal(customPolicy)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		_, err = policyRepo.CreatePolicy(&models.Policy{
+			UniqueID:    "custom-policy",
+			ProjectID:   1,
+			Name:        "staging-deployer",
+			PolicyBytes: policyBytes,
+		})
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
 		_, err = projRepo.CreateProjectRole(project, &models.Role{
 			Role: types.Role{
 				UserID:    1,
 				ProjectID: 1,
 				Kind:      basicTest.roleKind,
+				PolicyUID: basicTest.policyUID,
 			},
 		})
 
@@ -52,6 +52,19 @@ func (p *ProjectScopedMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// users without multi-factor authentication are denied access to projects that require
+	// it. API tokens are issued to the project, and are not affected.
+	if project.MFARequired {
+		if user, ok := r.Context().Value(types.UserScope).(*models.User); ok && user.ID != 0 && !user.TOTPEnabled {
+			apierrors.HandleAPIError(p.config.Logger, p.config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("project %d requires multi-factor authentication, which is not enabled for your account", projID),
+				http.StatusForbidden,
+			), true)
+
+			return
+		}
+	}
+
 	ctx := NewProjectContext(r.Context(), project)
 	r = r.Clone(ctx)
 	p.next.ServeHTTP(w, r)
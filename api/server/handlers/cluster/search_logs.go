@@ -0,0 +1,115 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// maxSearchLogsLimit is the largest page of log lines that is returned, which is also
+// used when no limit is passed
+const maxSearchLogsLimit = 1000
+
+type SearchLogsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewSearchLogsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SearchLogsHandler {
+	return &SearchLogsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *SearchLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.SearchLogsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Namespace == "" {
+		request.Namespace = "default"
+	}
+
+	if request.Limit <= 0 || request.Limit > maxSearchLogsLimit {
+		request.Limit = maxSearchLogsLimit
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// get agent service
+	agentSvc, err := porter_agent.GetAgentService(agent.Clientset)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	opts := &porter_agent.SearchLogsOpts{
+		ReleaseName: request.ReleaseName,
+		Namespace:   request.Namespace,
+		Search:      request.Search,
+		Since:       request.Since,
+		Until:       request.Until,
+		Limit:       request.Limit,
+	}
+
+	if request.AfterLogID != "" {
+		opts.After = &porter_agent.SearchLogsCursor{
+			Timestamp: request.AfterTimestamp,
+			LogID:     request.AfterLogID,
+			Line:      request.AfterLine,
+		}
+	}
+
+	lines, next, err := porter_agent.SearchLogs(agent.Clientset, agentSvc, opts)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.SearchLogsResponse{
+		Lines: make([]*types.LogLine, 0),
+	}
+
+	if next != nil {
+		res.HasMore = true
+		res.Next = &types.SearchLogsCursor{
+			AfterTimestamp: next.Timestamp,
+			AfterLogID:     next.LogID,
+			AfterLine:      next.Line,
+		}
+	}
+
+	for _, line := range lines {
+		res.Lines = append(res.Lines, &types.LogLine{
+			Timestamp:     line.Timestamp,
+			PodName:       line.PodName,
+			ContainerName: line.ContainerName,
+			Line:          line.Line,
+		})
+	}
+
+	c.WriteResult(w, r, res)
+}
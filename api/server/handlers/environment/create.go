@@ -62,7 +62,7 @@ func (c *CreateEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	env, err := c.Repo().Environment().CreateEnvironment(&models.Environment{
+	env := &models.Environment{
 		ProjectID:         project.ID,
 		ClusterID:         cluster.ID,
 		GitInstallationID: uint(ga.InstallationID),
@@ -70,8 +70,14 @@ func (c *CreateEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		GitRepoOwner:      owner,
 		GitRepoName:       name,
 		Mode:              request.Mode,
+		TTLHours:          request.TTLHours,
+		IdleTTLHours:      request.IdleTTLHours,
 		WebhookID:         string(webhookUID),
-	})
+	}
+
+	env.SetTriggerRules(request.TriggerLabels, request.TriggerPaths, request.TriggerBaseBranches)
+
+	env, err = c.Repo().Environment().CreateEnvironment(env)
 
 	if err != nil {
 		c.deleteEnvAndReportError(w, r, env, err)
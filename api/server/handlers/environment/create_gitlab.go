@@ -0,0 +1,144 @@
+package environment
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type CreateGitlabEnvironmentHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateGitlabEnvironmentHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateGitlabEnvironmentHandler {
+	return &CreateGitlabEnvironmentHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *CreateGitlabEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	gi, _ := r.Context().Value(types.GitlabIntegrationScope).(*integrations.GitlabIntegration)
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	owner, name, ok := commonutils.GetOwnerAndNameParams(c, w, r)
+
+	if !ok {
+		return
+	}
+
+	request := &types.CreateEnvironmentRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// create a random webhook id
+	webhookUID, err := encryption.GenerateRandomBytes(32)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	env, err := c.Repo().Environment().CreateEnvironment(&models.Environment{
+		ProjectID:           project.ID,
+		ClusterID:           cluster.ID,
+		GitlabIntegrationID: gi.ID,
+		UserID:              user.ID,
+		Name:                request.Name,
+		GitRepoOwner:        owner,
+		GitRepoName:         name,
+		Mode:                request.Mode,
+		TTLHours:            request.TTLHours,
+		IdleTTLHours:        request.IdleTTLHours,
+		WebhookID:           string(webhookUID),
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	client, _, err := gitlab.GetClient(c.Config(), c.Repo(), user.ID, project.ID, gi.ID)
+
+	if err != nil {
+		c.deleteEnvAndReportError(w, r, env, err)
+		return
+	}
+
+	// generate porter jwt token
+	jwt, err := token.GetTokenForAPI(user.ID, project.ID)
+
+	if err != nil {
+		c.deleteEnvAndReportError(w, r, env, err)
+		return
+	}
+
+	encoded, err := jwt.EncodeToken(c.Config().TokenConf)
+
+	if err != nil {
+		c.deleteEnvAndReportError(w, r, env, err)
+		return
+	}
+
+	previewOpts := &gitlab.PreviewEnvOpts{
+		Client:          client,
+		InstanceURL:     gi.InstanceURL,
+		ServerURL:       c.Config().ServerConf.ServerURL,
+		PorterToken:     encoded,
+		WebhookURL:      getGitlabWebhookURL(c.Config(), env),
+		WebhookSecret:   c.Config().ServerConf.GitlabIncomingWebhookSecret,
+		GitRepoOwner:    owner,
+		GitRepoName:     name,
+		EnvironmentName: request.Name,
+		Mode:            request.Mode,
+		ProjectID:       project.ID,
+		ClusterID:       cluster.ID,
+		EnvironmentID:   env.ID,
+	}
+
+	err = gitlab.SetupPreviewEnv(previewOpts)
+
+	if err != nil {
+		// the webhook may have been created before the setup failed, and it would keep
+		// calling Porter for an environment that no longer exists
+		if cleanupErr := gitlab.DeletePreviewEnv(previewOpts); cleanupErr != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(
+				fmt.Errorf("error cleaning up gitlab preview environment %s: %w", request.Name, cleanupErr),
+			))
+		}
+
+		c.deleteEnvAndReportError(w, r, env, err)
+		return
+	}
+
+	c.WriteResult(w, r, env.ToEnvironmentType())
+}
+
+func (c *CreateGitlabEnvironmentHandler) deleteEnvAndReportError(
+	w http.ResponseWriter, r *http.Request, env *models.Environment, err error,
+) {
+	c.Repo().Environment().DeleteEnvironment(env)
+	c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+}
+
+func getGitlabWebhookURL(config *config.Config, env *models.Environment) string {
+	return fmt.Sprintf("%s/api/gitlab/incoming_webhook/%s", config.ServerConf.ServerURL, env.WebhookID)
+}
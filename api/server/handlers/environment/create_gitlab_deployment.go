@@ -0,0 +1,166 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	gogitlab "github.com/xanzy/go-gitlab"
+	"gorm.io/gorm"
+)
+
+// CreateGitlabDeploymentHandler is called by the preview job of a merge request before
+// it deploys. It creates the deployment and its namespace if needed, or re-enables an
+// inactive deployment, and reports the deployment as running on the commit.
+type CreateGitlabDeploymentHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateGitlabDeploymentHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateGitlabDeploymentHandler {
+	return &CreateGitlabDeploymentHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *CreateGitlabDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	envID, reqErr := requestutils.GetURLParamUint(r, "environment_id")
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.CreateGitlabDeploymentRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	env, err := c.Repo().Environment().ReadEnvironmentByID(project.ID, cluster.ID, envID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !env.IsGitlab() {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("environment %d is not backed by gitlab", env.ID), http.StatusBadRequest,
+		))
+		return
+	}
+
+	now := time.Now()
+
+	depl, err := c.Repo().Environment().ReadDeployment(env.ID, request.Namespace)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	createNamespace := false
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		depl, err = c.Repo().Environment().CreateDeployment(&models.Deployment{
+			EnvironmentID:  env.ID,
+			Namespace:      request.Namespace,
+			Status:         types.DeploymentStatusCreating,
+			PullRequestID:  request.MergeRequestIID,
+			PRName:         request.MergeRequestTitle,
+			RepoOwner:      env.GitRepoOwner,
+			RepoName:       env.GitRepoName,
+			CommitSHA:      request.CommitSHA,
+			PRBranchFrom:   request.BranchFrom,
+			PRBranchInto:   request.BranchInto,
+			ActiveSince:    &now,
+			LastActivityAt: &now,
+		})
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		createNamespace = true
+	} else {
+		if depl.Status == types.DeploymentStatusInactive {
+			// the deployment was deleted when the merge request was closed or the
+			// deployment expired, so it is rebuilt from scratch
+			depl.Status = types.DeploymentStatusCreating
+			depl.ActiveSince = &now
+			depl.WarnedExpiry = nil
+			depl.ExpiredAt = nil
+
+			createNamespace = true
+		} else {
+			depl.Status = types.DeploymentStatusUpdating
+		}
+
+		depl.PRName = request.MergeRequestTitle
+		depl.CommitSHA = request.CommitSHA
+		depl.PRBranchFrom = request.BranchFrom
+		depl.LastActivityAt = &now
+
+		depl, err = c.Repo().Environment().UpdateDeployment(depl)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	if createNamespace {
+		agent, err := c.GetAgent(r, cluster, "")
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		_, err = agent.CreateNamespace(depl.Namespace)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	client, err := gitlab.GetClientForEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	err = gitlab.SetPreviewCommitStatus(
+		client, env.GitRepoOwner, env.GitRepoName, depl.CommitSHA,
+		gogitlab.Running, "", "Deploying preview environment",
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
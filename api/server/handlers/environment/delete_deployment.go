@@ -15,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
 	"github.com/porter-dev/porter/internal/models"
 	"gorm.io/gorm"
 )
@@ -85,31 +86,44 @@ func (c *DeleteDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	if env.IsGitlab() {
+		client, err := gitlab.GetClientForEnvironment(c.Config(), env)
 
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
 
-	// Create new deployment status to indicate deployment is ready
-	state := "inactive"
+		// stopping the GitLab environment is best-effort, since it is only created once the
+		// preview job has run
+		gitlab.StopPreviewEnvironment(client, env.GitRepoOwner, env.GitRepoName, env.Name, depl.PullRequestID)
+	} else {
+		client, err := getGithubClientFromEnvironment(c.Config(), env)
 
-	deploymentStatusRequest := github.DeploymentStatusRequest{
-		State: &state,
-	}
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
 
-	_, _, err = client.Repositories.CreateDeploymentStatus(
-		context.Background(),
-		env.GitRepoOwner,
-		env.GitRepoName,
-		depl.GHDeploymentID,
-		&deploymentStatusRequest,
-	)
+		// Create new deployment status to indicate deployment is ready
+		state := "inactive"
 
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		deploymentStatusRequest := github.DeploymentStatusRequest{
+			State: &state,
+		}
+
+		_, _, err = client.Repositories.CreateDeploymentStatus(
+			context.Background(),
+			env.GitRepoOwner,
+			env.GitRepoName,
+			depl.GHDeploymentID,
+			&deploymentStatusRequest,
+		)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
 	}
 
 	depl.Status = types.DeploymentStatusInactive
@@ -2,10 +2,10 @@ package environment
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -18,6 +18,7 @@ import (
 
 type FinalizeDeploymentHandler struct {
 	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
 }
 
 func NewFinalizeDeploymentHandler(
@@ -27,6 +28,7 @@ func NewFinalizeDeploymentHandler(
 ) *FinalizeDeploymentHandler {
 	return &FinalizeDeploymentHandler{
 		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
 	}
 }
 
@@ -104,24 +106,16 @@ func (c *FinalizeDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// write comment in PR
-	commentBody := fmt.Sprintf("Porter has deployed this pull request to the following URL:\n%s", depl.Subdomain)
-	prComment := github.IssueComment{
-		Body: &commentBody,
-		User: &github.User{},
-	}
+	// update the deployment comment in the PR. The deployment has already been updated, so
+	// a failure to update the comment is only logged.
+	helmAgent, err := c.GetHelmAgent(r, cluster, depl.Namespace)
 
-	_, _, err = client.Issues.CreateComment(
-		context.Background(),
-		env.GitRepoOwner,
-		env.GitRepoName,
-		int(depl.PullRequestID),
-		&prComment,
-	)
+	if err == nil {
+		err = upsertPreviewComment(c.Config(), client, helmAgent, cluster, env, depl)
+	}
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
 	}
 
 	c.WriteResult(w, r, depl.ToDeploymentType())
@@ -0,0 +1,129 @@
+package environment
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+type FinalizeGitlabDeploymentHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewFinalizeGitlabDeploymentHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *FinalizeGitlabDeploymentHandler {
+	return &FinalizeGitlabDeploymentHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *FinalizeGitlabDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	envID, reqErr := requestutils.GetURLParamUint(r, "environment_id")
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.FinalizeDeploymentRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	env, depl, ok := readGitlabDeployment(c, w, r, project.ID, cluster.ID, envID, request.Namespace)
+
+	if !ok {
+		return
+	}
+
+	depl.Subdomain = request.Subdomain
+	depl.Status = types.DeploymentStatusCreated
+
+	// update the deployment
+	depl, err := c.Repo().Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	client, err := gitlab.GetClientForEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// report the first URL as the target of the commit status
+	err = gitlab.SetPreviewCommitStatus(
+		client, env.GitRepoOwner, env.GitRepoName, depl.CommitSHA,
+		gogitlab.Success, strings.Split(depl.Subdomain, ",")[0], "Preview environment is ready",
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	err = gitlab.CommentOnMergeRequest(
+		client, env.GitRepoOwner, env.GitRepoName, depl.PullRequestID,
+		fmt.Sprintf("Porter has deployed this merge request to the following URL:\n%s", depl.Subdomain),
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
+
+// readGitlabDeployment reads a deployment of a preview environment backed by GitLab,
+// writing an error to the response if it cannot be read
+func readGitlabDeployment(
+	c handlers.PorterHandlerReadWriter,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID, clusterID, envID uint,
+	namespace string,
+) (*models.Environment, *models.Deployment, bool) {
+	env, err := c.Repo().Environment().ReadEnvironmentByID(projectID, clusterID, envID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, nil, false
+	}
+
+	if !env.IsGitlab() {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("environment %d is not backed by gitlab", env.ID), http.StatusBadRequest,
+		))
+		return nil, nil, false
+	}
+
+	depl, err := c.Repo().Environment().ReadDeployment(env.ID, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, nil, false
+	}
+
+	return env, depl, true
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -177,7 +178,8 @@ func updateDeploymentWithGithubWorkflowRunStatus(
 	env *models.Environment,
 	deployment *types.Deployment,
 ) {
-	if deployment.Status == types.DeploymentStatusInactive {
+	// GitLab previews report their status from the merge request pipeline directly
+	if deployment.Status == types.DeploymentStatusInactive || env.IsGitlab() {
 		return
 	}
 
@@ -211,6 +213,10 @@ func fetchOpenPullRequests(
 	env *models.Environment,
 	deplInfoMap map[string]bool,
 ) ([]*types.PullRequest, error) {
+	if env.IsGitlab() {
+		return fetchOpenMergeRequests(config, env, deplInfoMap)
+	}
+
 	client, err := getGithubClientFromEnvironment(config, env)
 
 	if err != nil {
@@ -265,3 +271,38 @@ func fetchOpenPullRequests(
 
 	return prs, nil
 }
+
+func fetchOpenMergeRequests(
+	config *config.Config,
+	env *models.Environment,
+	deplInfoMap map[string]bool,
+) ([]*types.PullRequest, error) {
+	client, err := gitlab.GetClientForEnvironment(config, env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	openMRs, err := gitlab.ListOpenMergeRequests(client, env.GitRepoOwner, env.GitRepoName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []*types.PullRequest
+
+	for _, mr := range openMRs {
+		if _, ok := deplInfoMap[fmt.Sprintf("%s-%s-%d", env.GitRepoOwner, env.GitRepoName, mr.IID)]; !ok {
+			prs = append(prs, &types.PullRequest{
+				Title:      mr.Title,
+				Number:     uint(mr.IID),
+				RepoOwner:  env.GitRepoOwner,
+				RepoName:   env.GitRepoName,
+				BranchFrom: mr.SourceBranch,
+				BranchInto: mr.TargetBranch,
+			})
+		}
+	}
+
+	return prs, nil
+}
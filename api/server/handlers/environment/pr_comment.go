@@ -0,0 +1,312 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// previewCommentMarker identifies the comments that Porter keeps updated on pull requests
+const previewCommentMarker = "<!-- porter-preview-deployment -->"
+
+type previewRelease struct {
+	Name   string
+	URL    string
+	Status string
+}
+
+// upsertPreviewComment creates or updates the single pull request comment that lists
+// the releases in the namespace of a preview deployment along with their status
+func upsertPreviewComment(
+	config *config.Config,
+	client *github.Client,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	env *models.Environment,
+	depl *models.Deployment,
+) error {
+	releases, err := getPreviewReleases(config, helmAgent, cluster, depl.Namespace)
+
+	if err != nil {
+		return err
+	}
+
+	body := getPreviewCommentBody(config, cluster, depl, releases)
+
+	if depl.GHPRCommentID != 0 {
+		_, resp, err := client.Issues.EditComment(
+			context.Background(),
+			env.GitRepoOwner,
+			env.GitRepoName,
+			depl.GHPRCommentID,
+			&github.IssueComment{
+				Body: github.String(body),
+			},
+		)
+
+		// if the comment was deleted, a new one is created below
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return err
+		}
+	}
+
+	// the comment ID is only stored once the comment has been created, so a comment
+	// created by an earlier update which failed to store its ID, or by a concurrent
+	// update, is reused rather than duplicated
+	existing, err := findPreviewComment(client, env, depl)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		_, _, err := client.Issues.EditComment(
+			context.Background(),
+			env.GitRepoOwner,
+			env.GitRepoName,
+			existing.GetID(),
+			&github.IssueComment{
+				Body: github.String(body),
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		depl.GHPRCommentID = existing.GetID()
+
+		_, err = config.Repo.Environment().UpdateDeployment(depl)
+
+		return err
+	}
+
+	comment, _, err := client.Issues.CreateComment(
+		context.Background(),
+		env.GitRepoOwner,
+		env.GitRepoName,
+		int(depl.PullRequestID),
+		&github.IssueComment{
+			Body: github.String(body),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	depl.GHPRCommentID = comment.GetID()
+
+	_, err = config.Repo.Environment().UpdateDeployment(depl)
+
+	return err
+}
+
+// findPreviewComment returns the comment on the pull request of a deployment which
+// starts with previewCommentMarker, or nil if there is none
+func findPreviewComment(
+	client *github.Client,
+	env *models.Environment,
+	depl *models.Deployment,
+) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	for {
+		comments, resp, err := client.Issues.ListComments(
+			context.Background(),
+			env.GitRepoOwner,
+			env.GitRepoName,
+			int(depl.PullRequestID),
+			opts,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, comment := range comments {
+			if strings.HasPrefix(comment.GetBody(), previewCommentMarker) {
+				return comment, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
+func getPreviewReleases(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	namespace string,
+) ([]*previewRelease, error) {
+	records, err := config.Repo.DNSRecord().ListDNSRecordsByNamespace(cluster.ID, namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the subdomains that Porter generated for each release, where later records replace
+	// earlier ones
+	subdomains := make(map[string]string)
+
+	for _, record := range records {
+		subdomains[record.ReleaseName] = record.Hostname
+	}
+
+	releases, err := helmAgent.ListReleases(namespace, &types.ReleaseListFilter{
+		StatusFilter: []string{
+			"deployed",
+			"pending-install",
+			"pending-upgrade",
+			"pending-rollback",
+			"failed",
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*previewRelease, 0)
+
+	for _, rel := range releases {
+		status := ""
+
+		if rel.Info != nil {
+			status = rel.Info.Status.String()
+		}
+
+		res = append(res, &previewRelease{
+			Name:   rel.Name,
+			URL:    getReleaseURL(rel, subdomains[rel.Name]),
+			Status: status,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
+// getReleaseURL returns the first host that the release is exposed on, preferring a
+// custom domain over the Porter-generated subdomain. The subdomain of the release's DNS
+// record is used when the ingress values don't list any hosts.
+func getReleaseURL(rel *release.Release, subdomain string) string {
+	ingress, ok := rel.Config["ingress"].(map[string]interface{})
+
+	if !ok {
+		return getSubdomainURL(subdomain)
+	}
+
+	if enabled, ok := ingress["enabled"].(bool); ok && !enabled {
+		return ""
+	}
+
+	keys := []string{"porter_hosts"}
+
+	if customDomain, ok := ingress["custom_domain"].(bool); ok && customDomain {
+		keys = []string{"hosts", "porter_hosts"}
+	}
+
+	for _, key := range keys {
+		if hosts, ok := ingress[key].([]interface{}); ok && len(hosts) > 0 {
+			if host, ok := hosts[0].(string); ok && host != "" {
+				return "https://" + host
+			}
+		}
+	}
+
+	return getSubdomainURL(subdomain)
+}
+
+func getSubdomainURL(subdomain string) string {
+	if subdomain == "" {
+		return ""
+	}
+
+	return "https://" + subdomain
+}
+
+func getPreviewCommentBody(
+	config *config.Config,
+	cluster *models.Cluster,
+	depl *models.Deployment,
+	releases []*previewRelease,
+) string {
+	var sb strings.Builder
+
+	sb.WriteString(previewCommentMarker + "\n")
+	sb.WriteString("### Porter preview deployment\n\n")
+
+	sb.WriteString(fmt.Sprintf("**Status:** %s", getDeploymentStatusText(depl.Status)))
+
+	if depl.CommitSHA != "" {
+		sb.WriteString(fmt.Sprintf(" for commit `%s`", depl.CommitSHA))
+	}
+
+	sb.WriteString(fmt.Sprintf(" in namespace `%s`\n\n", depl.Namespace))
+
+	if len(releases) == 0 {
+		sb.WriteString("No releases have been deployed yet.\n")
+
+		return sb.String()
+	}
+
+	sb.WriteString("| Release | URL | Status | Logs |\n")
+	sb.WriteString("| :------ | :-- | :----- | :--- |\n")
+
+	for _, rel := range releases {
+		url := rel.URL
+
+		if url == "" {
+			url = "-"
+		}
+
+		sb.WriteString(fmt.Sprintf(
+			"| %s | %s | %s | [View logs](%s/applications/%s/%s/%s?project_id=%d) |\n",
+			rel.Name, url, rel.Status,
+			config.ServerConf.ServerURL, cluster.Name, depl.Namespace, rel.Name, cluster.ProjectID,
+		))
+	}
+
+	return sb.String()
+}
+
+func getDeploymentStatusText(status types.DeploymentStatus) string {
+	switch status {
+	case types.DeploymentStatusCreated:
+		return "✅ Deployed"
+	case types.DeploymentStatusCreating:
+		return "⏳ Creating"
+	case types.DeploymentStatusUpdating:
+		return "⏳ Updating"
+	case types.DeploymentStatusFailed:
+		return "❌ Failed"
+	case types.DeploymentStatusTimedOut:
+		return "❌ Timed out"
+	case types.DeploymentStatusInactive:
+		return "💤 Inactive"
+	}
+
+	return string(status)
+}
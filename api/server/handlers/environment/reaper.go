@@ -0,0 +1,256 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/jobs"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// StartPreviewReaper periodically deletes the preview deployments that have exceeded the
+// TTL or idle limits of their environment. A warning is posted on the pull request before
+// the deployment is deleted, and an expired deployment is rebuilt on the next push.
+func StartPreviewReaper(config *config.Config) {
+	if config.ServerConf.PreviewReaperInterval <= 0 {
+		return
+	}
+
+	jobs.Start(config, "preview-reaper", config.ServerConf.PreviewReaperInterval, func() {
+		if err := reapPreviewDeployments(config, time.Now()); err != nil {
+			config.Logger.Error().Err(err).Msg("error reaping preview deployments")
+		}
+	})
+}
+
+func reapPreviewDeployments(config *config.Config, now time.Time) error {
+	envs, err := config.Repo.Environment().ListEnvironmentsWithExpiry()
+
+	if err != nil {
+		return err
+	}
+
+	for _, env := range envs {
+		depls, err := config.Repo.Environment().ListDeployments(
+			env.ID,
+			string(types.DeploymentStatusCreated),
+			string(types.DeploymentStatusCreating),
+			string(types.DeploymentStatusUpdating),
+			string(types.DeploymentStatusTimedOut),
+			string(types.DeploymentStatusFailed),
+		)
+
+		if err != nil {
+			config.Logger.Error().Err(err).Msgf("error listing deployments for environment %d", env.ID)
+			continue
+		}
+
+		for _, depl := range depls {
+			if err := reapPreviewDeployment(config, env, depl, now); err != nil {
+				config.Logger.Error().Err(err).Msgf(
+					"error reaping preview deployment %d in namespace %s", depl.ID, depl.Namespace,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func reapPreviewDeployment(config *config.Config, env *models.Environment, depl *models.Deployment, now time.Time) error {
+	expiresAt, ok := depl.ExpiresAt(env)
+
+	if !ok {
+		return nil
+	}
+
+	if !now.Before(expiresAt) {
+		return expirePreviewDeployment(config, env, depl, expiresAt, now)
+	}
+
+	warnAt := expiresAt.Add(-config.ServerConf.PreviewExpiryWarning)
+
+	// only warn once for each expiry time, since a push can push back an idle expiry
+	if now.Before(warnAt) || (depl.WarnedExpiry != nil && depl.WarnedExpiry.Unix() == expiresAt.Unix()) {
+		return nil
+	}
+
+	// a push only extends the idle limit, while the maximum lifetime is counted from when
+	// the deployment was created or last rebuilt
+	advice := "Push a new commit to keep it running longer."
+
+	if !isIdleExpiry(env, depl, expiresAt) {
+		advice = "Pushing new commits won't keep it running longer, but a push after it is deleted rebuilds it."
+	}
+
+	err := commentOnPreview(config, env, depl, fmt.Sprintf(
+		"The preview environment for this %s in namespace `%s` will be deleted at %s "+
+			"when it reaches the %s. %s",
+		previewRequestNoun(env),
+		depl.Namespace,
+		expiresAt.UTC().Format(time.RFC1123),
+		expiryReason(env, depl, expiresAt),
+		advice,
+	))
+
+	if err != nil {
+		return err
+	}
+
+	depl.WarnedExpiry = &expiresAt
+
+	_, err = config.Repo.Environment().UpdateDeployment(depl)
+
+	return err
+}
+
+func expirePreviewDeployment(
+	config *config.Config,
+	env *models.Environment,
+	depl *models.Deployment,
+	expiresAt, now time.Time,
+) error {
+	cluster, err := config.Repo.Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+
+	if err != nil {
+		return err
+	}
+
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Repo:                      config.Repo,
+		DigitalOceanOAuth:         config.DOConf,
+		Cluster:                   cluster,
+		AllowInClusterConnections: config.ServerConf.InitInCluster,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// make sure we don't delete default or kube-system by checking for prefix, for now
+	if strings.Contains(depl.Namespace, "pr-") {
+		err = agent.DeleteNamespace(depl.Namespace)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if env.IsGitlab() {
+		client, err := gitlab.GetClientForEnvironment(config, env)
+
+		if err != nil {
+			return err
+		}
+
+		// stop the environment on GitLab
+		gitlab.StopPreviewEnvironment(client, env.GitRepoOwner, env.GitRepoName, env.Name, depl.PullRequestID)
+	} else {
+		client, err := getGithubClientFromEnvironment(config, env)
+
+		if err != nil {
+			return err
+		}
+
+		// mark the deployment as inactive on GitHub
+		state := "inactive"
+
+		client.Repositories.CreateDeploymentStatus(
+			context.Background(),
+			env.GitRepoOwner,
+			env.GitRepoName,
+			depl.GHDeploymentID,
+			&github.DeploymentStatusRequest{
+				State: &state,
+			},
+		)
+	}
+
+	depl.Status = types.DeploymentStatusInactive
+	depl.ExpiredAt = &now
+	depl.WarnedExpiry = nil
+
+	_, err = config.Repo.Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		return err
+	}
+
+	return commentOnPreview(config, env, depl, fmt.Sprintf(
+		"The preview environment for this %s in namespace `%s` was deleted because it "+
+			"exceeded the %s. Push a new commit to rebuild it.",
+		previewRequestNoun(env),
+		depl.Namespace,
+		expiryReason(env, depl, expiresAt),
+	))
+}
+
+// expiryReason describes the limit that causes the deployment to expire at expiresAt
+func expiryReason(env *models.Environment, depl *models.Deployment, expiresAt time.Time) string {
+	if isIdleExpiry(env, depl, expiresAt) {
+		return fmt.Sprintf("idle limit of %d hours without a push", env.IdleTTLHours)
+	}
+
+	return fmt.Sprintf("maximum lifetime of %d hours", env.TTLHours)
+}
+
+// isIdleExpiry returns true if the deployment expires at expiresAt because of the idle
+// limit rather than the maximum lifetime
+func isIdleExpiry(env *models.Environment, depl *models.Deployment, expiresAt time.Time) bool {
+	activeSince := depl.CreatedAt
+
+	if depl.ActiveSince != nil {
+		activeSince = *depl.ActiveSince
+	}
+
+	ttl := time.Duration(env.TTLHours) * time.Hour
+
+	return env.TTLHours == 0 || expiresAt.Before(activeSince.Add(ttl))
+}
+
+// commentOnPreview comments on the pull request or merge request that the deployment
+// was created for
+func commentOnPreview(config *config.Config, env *models.Environment, depl *models.Deployment, body string) error {
+	if env.IsGitlab() {
+		client, err := gitlab.GetClientForEnvironment(config, env)
+
+		if err != nil {
+			return err
+		}
+
+		return gitlab.CommentOnMergeRequest(client, env.GitRepoOwner, env.GitRepoName, depl.PullRequestID, body)
+	}
+
+	client, err := getGithubClientFromEnvironment(config, env)
+
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.Issues.CreateComment(
+		context.Background(),
+		env.GitRepoOwner,
+		env.GitRepoName,
+		int(depl.PullRequestID),
+		&github.IssueComment{
+			Body: github.String(body),
+		},
+	)
+
+	return err
+}
+
+func previewRequestNoun(env *models.Environment) string {
+	if env.IsGitlab() {
+		return "merge request"
+	}
+
+	return "pull request"
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/go-github/v41/github"
 	"github.com/porter-dev/porter/api/server/authz"
@@ -13,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -54,7 +56,13 @@ func (c *ReenableDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	now := time.Now()
+
 	depl.Status = types.DeploymentStatusCreating
+	depl.ActiveSince = &now
+	depl.LastActivityAt = &now
+	depl.WarnedExpiry = nil
+	depl.ExpiredAt = nil
 
 	depl, err = c.Repo().Environment().UpdateDeployment(depl)
 
@@ -85,6 +93,24 @@ func (c *ReenableDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// GitLab previews are rebuilt by running a new merge request pipeline
+	if env.IsGitlab() {
+		client, err := gitlab.GetClientForEnvironment(c.Config(), env)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		err = gitlab.RunPreviewPipeline(client, env.GitRepoOwner, env.GitRepoName, depl.PullRequestID)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		}
+
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
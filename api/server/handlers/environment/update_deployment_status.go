@@ -73,5 +73,32 @@ func (c *UpdateDeploymentStatusHandler) ServeHTTP(w http.ResponseWriter, r *http
 		return
 	}
 
+	// update the deployment comment in the PR. The deployment has already been updated, so
+	// a failure to update the comment is only logged.
+	if err := c.updatePreviewComment(r, cluster, env, depl); err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
+
+func (c *UpdateDeploymentStatusHandler) updatePreviewComment(
+	r *http.Request,
+	cluster *models.Cluster,
+	env *models.Environment,
+	depl *models.Deployment,
+) error {
+	client, err := getGithubClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		return err
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, depl.Namespace)
+
+	if err != nil {
+		return err
+	}
+
+	return upsertPreviewComment(c.Config(), client, helmAgent, cluster, env, depl)
+}
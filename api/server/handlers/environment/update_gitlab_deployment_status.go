@@ -0,0 +1,96 @@
+package environment
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+type UpdateGitlabDeploymentStatusHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateGitlabDeploymentStatusHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateGitlabDeploymentStatusHandler {
+	return &UpdateGitlabDeploymentStatusHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateGitlabDeploymentStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	envID, reqErr := requestutils.GetURLParamUint(r, "environment_id")
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdateGitlabDeploymentStatusRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	env, depl, ok := readGitlabDeployment(c, w, r, project.ID, cluster.ID, envID, request.Namespace)
+
+	if !ok {
+		return
+	}
+
+	state := gogitlab.Running
+	description := "Deploying preview environment"
+
+	if request.Status == string(types.DeploymentStatusFailed) {
+		state = gogitlab.Failed
+		description = "Preview environment failed to deploy"
+	}
+
+	if depl.Status == types.DeploymentStatusCreated && request.Status == string(types.DeploymentStatusCreating) {
+		depl.Status = types.DeploymentStatusUpdating
+	} else {
+		depl.Status = types.DeploymentStatus(request.Status)
+	}
+
+	if request.CommitSHA != "" {
+		depl.CommitSHA = request.CommitSHA
+	}
+
+	depl, err := c.Repo().Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	client, err := gitlab.GetClientForEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	err = gitlab.SetPreviewCommitStatus(
+		client, env.GitRepoOwner, env.GitRepoName, depl.CommitSHA, state, "", description,
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
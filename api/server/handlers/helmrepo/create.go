@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/loader"
 	"github.com/porter-dev/porter/internal/models"
 	"gorm.io/gorm"
 )
@@ -57,11 +58,40 @@ func (p *HelmRepoCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	// if a registry is specified, verify that the repo is an OCI repo and that the
+	// registry exists in the project
+	if request.RegistryID != 0 {
+		if !loader.IsOCIRepoURL(request.URL) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("a registry can only be linked to helm repos with an oci:// url"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		_, err := p.Repo().Registry().ReadRegistry(proj.ID, request.RegistryID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+					fmt.Errorf("registry with id %d not found in project %d", request.RegistryID, proj.ID),
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
 	hr := &models.HelmRepo{
 		Name:                   request.Name,
 		ProjectID:              proj.ID,
 		RepoURL:                request.URL,
 		BasicAuthIntegrationID: request.BasicIntegrationID,
+		RegistryID:             request.RegistryID,
 	}
 
 	// handle write to the database
@@ -18,6 +18,7 @@ import (
 	"github.com/porter-dev/porter/internal/integrations/slack"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
 	"gorm.io/gorm"
 )
 
@@ -263,9 +264,7 @@ func notifyPodCrashing(
 		}
 	}
 
-	slackInts, _ := config.Repo.SlackIntegration().ListSlackIntegrationsByProjectID(project.ID)
-
-	notifier := slack.NewSlackNotifier(notifConfig, slackInts...)
+	notifier := notifier.NewProjectNotifier(config.Repo, project.ID, notifConfig, config.Logger)
 	notifyOpts.Status = slack.StatusPodCrashed
 
 	err = notifier.Notify(notifyOpts)
@@ -93,4 +93,8 @@ func (c *CloneEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 
 	c.WriteResult(w, r, envGroup)
+
+	if err := enforceEnvGroupRetentionOnWrite(c.Config(), cluster, agent, request.Namespace, request.CloneName); err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
 }
@@ -102,18 +102,25 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	c.WriteResult(w, r, envGroup)
 
-	// trigger rollout of new applications after writing the result
-	errors := rolloutApplications(c.Config(), cluster, helmAgent, envGroup, configMap, releases)
+	if !request.SkipRollout {
+		// trigger rollout of new applications after writing the result
+		errors := rolloutApplications(c.Config(), cluster, helmAgent, envGroup, configMap, releases)
 
-	if len(errors) > 0 {
-		errStrArr := make([]string, 0)
+		if len(errors) > 0 {
+			errStrArr := make([]string, 0)
 
-		for _, err := range errors {
-			errStrArr = append(errStrArr, err.Error())
+			for _, err := range errors {
+				errStrArr = append(errStrArr, err.Error())
+			}
+
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(fmt.Errorf(strings.Join(errStrArr, ","))))
 		}
+	}
 
-		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(fmt.Errorf(strings.Join(errStrArr, ","))))
-		return
+	// versions that are still used by deployed releases are kept by the retention policy, so
+	// old versions are deleted after the rollout
+	if err := enforceEnvGroupRetentionOnWrite(c.Config(), cluster, agent, namespace, request.Name); err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
 	}
 }
 
@@ -73,6 +73,21 @@ func (c *DeleteEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
+
+		// delete the external sources of the env group, so they are no longer synced
+		sources, err := c.Repo().EnvGroupSource().ListEnvGroupSources(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		for _, source := range sources {
+			if err := c.Repo().EnvGroupSource().DeleteEnvGroupSource(source); err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
 	}
 }
 
@@ -0,0 +1,222 @@
+package namespace
+
+import (
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/jobs"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"helm.sh/helm/v3/pkg/release"
+	v1 "k8s.io/api/core/v1"
+)
+
+// StartEnvGroupRetentionJob periodically deletes the env group versions that are no longer
+// kept by the retention policy of their cluster or project
+func StartEnvGroupRetentionJob(config *config.Config) {
+	if config.ServerConf.EnvGroupRetentionInterval <= 0 {
+		return
+	}
+
+	jobs.Start(config, "env-group-retention", config.ServerConf.EnvGroupRetentionInterval, func() {
+		if err := enforceAllEnvGroupRetention(config); err != nil {
+			config.Logger.Error().Err(err).Msg("error enforcing env group retention")
+		}
+	})
+}
+
+func enforceAllEnvGroupRetention(config *config.Config) error {
+	// clusters either set their own retention or use the retention of their project
+	clusters, err := config.Repo.Cluster().ListClustersWithEnvGroupRetention()
+
+	if err != nil {
+		return err
+	}
+
+	projects, err := config.Repo.Project().ListProjectsWithEnvGroupRetention()
+
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		projectClusters, err := config.Repo.Cluster().ListClustersByProjectID(project.ID)
+
+		if err != nil {
+			return err
+		}
+
+		clusters = append(clusters, projectClusters...)
+	}
+
+	seen := make(map[uint]bool)
+
+	for _, cluster := range clusters {
+		if seen[cluster.ID] {
+			continue
+		}
+
+		seen[cluster.ID] = true
+
+		if err := enforceClusterEnvGroupRetention(config, cluster); err != nil {
+			config.Logger.Error().Err(err).Msgf("error enforcing env group retention in cluster %d", cluster.ID)
+		}
+	}
+
+	return nil
+}
+
+func enforceClusterEnvGroupRetention(config *config.Config, cluster *models.Cluster) error {
+	keepLast, err := getEnvGroupVersionRetention(config, cluster)
+
+	if err != nil || keepLast == 0 {
+		return err
+	}
+
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Repo:                      config.Repo,
+		DigitalOceanOAuth:         config.DOConf,
+		Cluster:                   cluster,
+		AllowInClusterConnections: config.ServerConf.InitInCluster,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = enforceEnvGroupRetention(config, agent, "", "", keepLast, false)
+
+	return err
+}
+
+// enforceEnvGroupRetentionOnWrite deletes the versions of an env group that are no longer
+// kept by the retention policy of the cluster, after a new version was written
+func enforceEnvGroupRetentionOnWrite(
+	config *config.Config,
+	cluster *models.Cluster,
+	agent *kubernetes.Agent,
+	namespace, name string,
+) error {
+	keepLast, err := getEnvGroupVersionRetention(config, cluster)
+
+	if err != nil || keepLast == 0 {
+		return err
+	}
+
+	_, err = enforceEnvGroupRetention(config, agent, namespace, name, keepLast, false)
+
+	return err
+}
+
+func getEnvGroupVersionRetention(config *config.Config, cluster *models.Cluster) (uint, error) {
+	if cluster.EnvGroupVersionRetention != 0 {
+		return cluster.EnvGroupVersionRetention, nil
+	}
+
+	project, err := config.Repo.Project().ReadProject(cluster.ProjectID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return cluster.GetEnvGroupVersionRetention(project), nil
+}
+
+// enforceEnvGroupRetention plans which env group versions a policy keeping the last keepLast
+// versions deletes and, unless dryRun is set, deletes them. An empty namespace covers all
+// namespaces, and an empty name covers all env groups.
+func enforceEnvGroupRetention(
+	config *config.Config,
+	agent *kubernetes.Agent,
+	namespace, name string,
+	keepLast uint,
+	dryRun bool,
+) ([]*types.EnvGroupVersionRetention, error) {
+	var configMaps []v1.ConfigMap
+	var err error
+
+	if name != "" {
+		configMaps, err = agent.ListVersionedConfigMaps(name, namespace)
+	} else {
+		configMaps, err = agent.ListAllConfigMapVersions(namespace)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	helmAgent, err := helm.GetAgentFromK8sAgent("secret", namespace, config.Logger, agent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deployed, err := helmAgent.ListDeployedReleases(namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := withRollbackRevisions(config, agent, deployed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plans := envgroup.PlanVersionRetention(configMaps, keepLast, envgroup.GetReferencedVersions(releases))
+
+	if dryRun {
+		return plans, nil
+	}
+
+	for _, plan := range plans {
+		if err := envgroup.DeleteEnvGroupVersions(agent, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	return plans, nil
+}
+
+// withRollbackRevisions adds the revisions that deployed releases may be rolled back to, so
+// that the env group versions those revisions use are kept as well
+func withRollbackRevisions(
+	config *config.Config,
+	agent *kubernetes.Agent,
+	deployed []*release.Release,
+) ([]*release.Release, error) {
+	res := make([]*release.Release, 0)
+
+	// the history is read with an agent for the namespace of each release, since releases
+	// in different namespaces can share a name
+	namespaceAgents := make(map[string]*helm.Agent)
+
+	for _, rel := range deployed {
+		nsAgent, ok := namespaceAgents[rel.Namespace]
+
+		if !ok {
+			var err error
+
+			nsAgent, err = helm.GetAgentFromK8sAgent("secret", rel.Namespace, config.Logger, agent)
+
+			if err != nil {
+				return nil, err
+			}
+
+			namespaceAgents[rel.Namespace] = nsAgent
+		}
+
+		history, err := nsAgent.GetReleaseHistory(rel.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, rel)
+		res = append(res, registry.GetRollbackRevisions(history)...)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package namespace
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/jobs"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// StartEnvGroupSourceSyncer periodically syncs the env groups that have external sources,
+// redeploying the synced applications of an env group when one of its values changed
+func StartEnvGroupSourceSyncer(config *config.Config) {
+	if config.ServerConf.EnvGroupSourceSyncInterval <= 0 {
+		return
+	}
+
+	jobs.Start(config, "env-group-source-sync", config.ServerConf.EnvGroupSourceSyncInterval, func() {
+		if err := syncAllEnvGroupSources(config); err != nil {
+			config.Logger.Error().Err(err).Msg("error syncing env group sources")
+		}
+	})
+}
+
+func syncAllEnvGroupSources(config *config.Config) error {
+	sources, err := config.Repo.EnvGroupSource().ListAllEnvGroupSources()
+
+	if err != nil {
+		return err
+	}
+
+	// group the sources by env group, keeping the order of the env groups
+	groupIDs := make([]string, 0)
+	groups := make(map[string][]*models.EnvGroupSource)
+
+	for _, source := range sources {
+		id := fmt.Sprintf("%d/%d/%s/%s", source.ProjectID, source.ClusterID, source.Namespace, source.EnvGroupName)
+
+		if _, ok := groups[id]; !ok {
+			groupIDs = append(groupIDs, id)
+		}
+
+		groups[id] = append(groups[id], source)
+	}
+
+	for _, id := range groupIDs {
+		group := groups[id]
+
+		if err := syncEnvGroupFromSchedule(config, group); err != nil {
+			config.Logger.Error().Err(err).Msgf("error syncing sources of env group %s", id)
+		}
+	}
+
+	return nil
+}
+
+func syncEnvGroupFromSchedule(config *config.Config, sources []*models.EnvGroupSource) error {
+	first := sources[0]
+
+	cluster, err := config.Repo.Cluster().ReadCluster(first.ProjectID, first.ClusterID)
+
+	if err != nil {
+		return err
+	}
+
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Repo:                      config.Repo,
+		DigitalOceanOAuth:         config.DOConf,
+		Cluster:                   cluster,
+		DefaultNamespace:          first.Namespace,
+		AllowInClusterConnections: config.ServerConf.InitInCluster,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	configMap, res, err := syncEnvGroupSources(config, agent, first.Namespace, first.EnvGroupName, sources)
+
+	if err != nil {
+		return err
+	}
+
+	if !res.Updated {
+		return nil
+	}
+
+	helmAgent, err := helm.GetAgentFromK8sAgent("secret", first.Namespace, config.Logger, agent)
+
+	if err != nil {
+		return err
+	}
+
+	if rolloutErrs := rolloutSyncedApplications(config, cluster, helmAgent, res.EnvGroup, configMap); len(rolloutErrs) > 0 {
+		return joinErrors(rolloutErrs)
+	}
+
+	return enforceEnvGroupRetentionOnWrite(config, cluster, agent, first.Namespace, first.EnvGroupName)
+}
@@ -0,0 +1,52 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListEnvGroupSourcesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListEnvGroupSourcesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListEnvGroupSourcesHandler {
+	return &ListEnvGroupSourcesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListEnvGroupSourcesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.ListEnvGroupSourcesRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	sources, err := c.Repo().EnvGroupSource().ListEnvGroupSources(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListEnvGroupSourcesResponse = make([]*types.EnvGroupSource, 0)
+
+	for _, source := range sources {
+		res = append(res, source.ToEnvGroupSourceType())
+	}
+
+	c.WriteResult(w, r, res)
+}
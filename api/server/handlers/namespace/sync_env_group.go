@@ -0,0 +1,204 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/integrations/envsource"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	v1 "k8s.io/api/core/v1"
+)
+
+type SyncEnvGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewSyncEnvGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SyncEnvGroupHandler {
+	return &SyncEnvGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *SyncEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.SyncEnvGroupRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sources, err := c.Repo().EnvGroupSource().ListEnvGroupSources(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if len(sources) == 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("env group %s has no external sources", request.Name),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	writeSyncResult(c.PorterHandlerReadWriter, w, r, cluster, agent, helmAgent, namespace, request.Name, sources)
+}
+
+// writeSyncResult syncs the sources of an env group and writes the result. If the sync created
+// a new version of the env group, the synced applications are redeployed after the result is
+// written.
+func writeSyncResult(
+	c handlers.PorterHandlerReadWriter,
+	w http.ResponseWriter,
+	r *http.Request,
+	cluster *models.Cluster,
+	agent *kubernetes.Agent,
+	helmAgent *helm.Agent,
+	namespace, name string,
+	sources []*models.EnvGroupSource,
+) {
+	configMap, res, err := syncEnvGroupSources(c.Config(), agent, namespace, name, sources)
+
+	if err != nil && errors.Is(err, kubernetes.IsNotFoundError) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("env group not found"),
+			http.StatusNotFound,
+		))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+
+	if !res.Updated {
+		return
+	}
+
+	// trigger rollout of the linked applications after writing the result
+	if rolloutErrs := rolloutSyncedApplications(c.Config(), cluster, helmAgent, res.EnvGroup, configMap); len(rolloutErrs) > 0 {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(joinErrors(rolloutErrs)))
+	}
+
+	if err := enforceEnvGroupRetentionOnWrite(c.Config(), cluster, agent, namespace, name); err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+}
+
+// syncEnvGroupSources resolves the sources of an env group and saves the results of the sync
+// on the sources. Sources that could not be resolved do not cause an error: their errors are
+// stored on the sources instead.
+func syncEnvGroupSources(
+	config *config.Config,
+	agent *kubernetes.Agent,
+	namespace, name string,
+	sources []*models.EnvGroupSource,
+) (*v1.ConfigMap, *types.SyncEnvGroupResponse, error) {
+	configMap, updated, syncErr := envgroup.SyncEnvGroupSources(
+		agent,
+		name,
+		namespace,
+		sources,
+		func(source *models.EnvGroupSource) (string, string, error) {
+			return envsource.Resolve(config.Repo, source)
+		},
+		time.Now(),
+	)
+
+	if configMap == nil {
+		return nil, nil, syncErr
+	}
+
+	// the errors of sources that could not be resolved are stored on the sources, but are
+	// logged here as well so that failing background syncs show up in the server logs
+	if syncErr != nil {
+		config.Logger.Error().Err(syncErr).Msgf("error syncing sources of env group %s in namespace %s", name, namespace)
+	}
+
+	res := &types.SyncEnvGroupResponse{
+		Sources: make([]*types.EnvGroupSource, 0),
+		Updated: updated,
+	}
+
+	for _, source := range sources {
+		source, err := config.Repo.EnvGroupSource().UpdateEnvGroupSource(source)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res.Sources = append(res.Sources, source.ToEnvGroupSourceType())
+	}
+
+	envGroup, err := envgroup.ToEnvGroup(configMap)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res.EnvGroup = envGroup
+
+	return configMap, res, nil
+}
+
+func rolloutSyncedApplications(
+	config *config.Config,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	envGroup *types.EnvGroup,
+	configMap *v1.ConfigMap,
+) []error {
+	releases, err := envgroup.GetSyncedReleases(helmAgent, configMap)
+
+	if err != nil {
+		return []error{err}
+	}
+
+	return rolloutApplications(config, cluster, helmAgent, envGroup, configMap, releases)
+}
+
+func joinErrors(errs []error) error {
+	errStrArr := make([]string, 0)
+
+	for _, err := range errs {
+		errStrArr = append(errStrArr, err.Error())
+	}
+
+	return fmt.Errorf(strings.Join(errStrArr, ","))
+}
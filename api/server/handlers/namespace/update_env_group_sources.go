@@ -0,0 +1,141 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/envsource"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type UpdateEnvGroupSourcesHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewUpdateEnvGroupSourcesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateEnvGroupSourcesHandler {
+	return &UpdateEnvGroupSourcesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *UpdateEnvGroupSourcesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.UpdateEnvGroupSourcesRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	keys := make(map[string]bool)
+
+	for _, source := range request.Sources {
+		if keys[source.Key] {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("multiple sources found for variable %s", source.Key),
+				http.StatusBadRequest,
+			))
+			return
+		}
+
+		keys[source.Key] = true
+
+		if err := envsource.ValidateIntegration(c.Repo(), cluster.ProjectID, source); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	}
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envGroup, err := envgroup.GetEnvGroup(agent, request.Name, namespace, 0)
+
+	if err != nil && errors.Is(err, kubernetes.IsNotFoundError) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("env group not found"),
+			http.StatusNotFound,
+		))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if envGroup.MetaVersion == 1 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("external sources are not supported for env groups created before versioning"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	// replace the existing sources with the sources from the request. Variables of removed
+	// sources keep their last synced value.
+	oldSources, err := c.Repo().EnvGroupSource().ListEnvGroupSources(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, source := range oldSources {
+		if err := c.Repo().EnvGroupSource().DeleteEnvGroupSource(source); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	sources := make([]*models.EnvGroupSource, 0)
+
+	for _, input := range request.Sources {
+		source, err := c.Repo().EnvGroupSource().CreateEnvGroupSource(&models.EnvGroupSource{
+			ProjectID:     cluster.ProjectID,
+			ClusterID:     cluster.ID,
+			Namespace:     namespace,
+			EnvGroupName:  request.Name,
+			Key:           input.Key,
+			Kind:          input.Kind,
+			IntegrationID: input.IntegrationID,
+			Path:          input.Path,
+			Field:         input.Field,
+		})
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		sources = append(sources, source)
+	}
+
+	// sync the new sources right away, so that the env group reflects them
+	writeSyncResult(c.PorterHandlerReadWriter, w, r, cluster, agent, helmAgent, namespace, request.Name, sources)
+}
@@ -0,0 +1,70 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// maxAuditEventsLimit is the largest number of audit events returned in a single page
+const maxAuditEventsLimit = 500
+
+type AuditLogsListHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewAuditLogsListHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *AuditLogsListHandler {
+	return &AuditLogsListHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *AuditLogsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListAuditEventsRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Skip < 0 {
+		request.Skip = 0
+	}
+
+	// a limit of 0 uses the default page size of the repository
+	if request.Limit < 0 {
+		request.Limit = 0
+	} else if request.Limit > maxAuditEventsLimit {
+		request.Limit = maxAuditEventsLimit
+	}
+
+	events, count, err := p.Repo().AuditEvent().ListAuditEventsByProjectID(proj.ID, request)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ListAuditEventsResponse{
+		Count:       count,
+		Limit:       request.Limit,
+		Skip:        request.Skip,
+		AuditEvents: make([]*types.AuditEvent, 0),
+	}
+
+	for _, event := range events {
+		res.AuditEvents = append(res.AuditEvents, event.ToAuditEventType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -36,11 +36,33 @@ func (p *CollaboratorsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 
 	roleMap := make(map[uint]*models.Role)
 	idArr := make([]uint, 0)
+	hasCustomRoles := false
 
 	for _, role := range roles {
 		roleCp := role
 		roleMap[role.UserID] = &roleCp
 		idArr = append(idArr, role.UserID)
+
+		if role.Kind == types.RoleCustom {
+			hasCustomRoles = true
+		}
+	}
+
+	// map custom policy uids to their names, so that collaborators with custom roles
+	// can be displayed with the policy they are bound to
+	policyNames := make(map[string]string)
+
+	if hasCustomRoles {
+		policies, err := p.Repo().Policy().ListPoliciesByProjectID(proj.ID)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		for _, policy := range policies {
+			policyNames[policy.UniqueID] = policy.Name
+		}
 	}
 
 	users, err := p.Repo().User().ListUsersByIDs(idArr)
@@ -53,12 +75,17 @@ func (p *CollaboratorsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 	var res types.ListCollaboratorsResponse = make([]*types.Collaborator, 0)
 
 	for _, user := range users {
+		role := roleMap[user.ID]
+
 		res = append(res, &types.Collaborator{
-			ID:        roleMap[user.ID].ID,
-			Kind:      string(roleMap[user.ID].Kind),
-			UserID:    roleMap[user.ID].UserID,
-			Email:     user.Email,
-			ProjectID: roleMap[user.ID].ProjectID,
+			ID:         role.ID,
+			Kind:       string(role.Kind),
+			UserID:     role.UserID,
+			Email:      user.Email,
+			ProjectID:  role.ProjectID,
+			PolicyUID:  role.PolicyUID,
+			PolicyName: policyNames[role.PolicyUID],
+			MFAEnabled: user.TOTPEnabled,
 		})
 	}
 
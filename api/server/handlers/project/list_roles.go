@@ -23,7 +23,14 @@ func NewRolesListHandler(
 }
 
 func (p *RolesListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	var res types.ListProjectRolesResponse = []types.RoleKind{types.RoleAdmin, types.RoleDeveloper, types.RoleViewer}
+	// custom roles are listed as well, although binding one also requires the UID of a
+	// policy in the project
+	var res types.ListProjectRolesResponse = []types.RoleKind{
+		types.RoleAdmin,
+		types.RoleDeveloper,
+		types.RoleViewer,
+		types.RoleCustom,
+	}
 
 	p.WriteResult(w, r, res)
 }
@@ -0,0 +1,85 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CollaboratorMFAResetHandler disables multi-factor authentication for a collaborator
+// of the project who has lost access to their second factor. The collaborator can then
+// log in with their password and enrol again. Since the second factor protects every
+// project of the user, only the admin user of the instance can reset it.
+type CollaboratorMFAResetHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCollaboratorMFAResetHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CollaboratorMFAResetHandler {
+	return &CollaboratorMFAResetHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CollaboratorMFAResetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ResetCollaboratorMFARequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if adminEmail := p.Config().ServerConf.AdminEmail; adminEmail == "" || user.Email != adminEmail {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not the admin user of the instance", user.ID),
+		))
+
+		return
+	}
+
+	if request.UserID == user.ID {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("you cannot reset your own multi-factor authentication"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	role, err := p.Repo().Project().ReadProjectRole(proj.ID, request.UserID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not a collaborator of project %d", request.UserID, proj.ID),
+		))
+
+		return
+	}
+
+	collaborator, err := p.Repo().User().ReadUser(role.UserID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	collaborator.DisableTOTP()
+
+	if _, err := p.Repo().User().UpdateUser(collaborator); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, collaborator.ToUserType())
+}
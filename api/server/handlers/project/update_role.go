@@ -1,8 +1,10 @@
 package project
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/porter-dev/porter/api/server/authz/policy"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -44,6 +46,35 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	role.Kind = types.RoleKind(request.Kind)
+	role.PolicyUID = ""
+
+	switch role.Kind {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer:
+	case types.RoleCustom:
+		if request.PolicyUID == "" {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy_uid is required for custom roles"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		// make sure the policy exists in this project before binding it to the role
+		if _, reqErr := policy.GetAPIPolicyFromUID(p.Repo().Policy(), proj.ID, request.PolicyUID); reqErr != nil {
+			p.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		role.PolicyUID = request.PolicyUID
+	default:
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("%s is not a valid role kind", request.Kind),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
 
 	role, err = p.Repo().Project().UpdateProjectRole(proj.ID, role)
 
@@ -0,0 +1,75 @@
+package project_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+var discordWebhookHosts = map[string]bool{
+	"discord.com":        true,
+	"discordapp.com":     true,
+	"ptb.discord.com":    true,
+	"canary.discord.com": true,
+}
+
+type CreateDiscordHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateDiscordHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateDiscordHandler {
+	return &CreateDiscordHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CreateDiscordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateDiscordIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	parsed, err := url.Parse(request.WebhookURL)
+
+	if err != nil || parsed.Scheme != "https" || !discordWebhookHosts[parsed.Host] ||
+		!strings.HasPrefix(parsed.Path, "/api/webhooks/") {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("discord webhook URL must be of the form https://discord.com/api/webhooks/<id>/<token>"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	discordInt, err := p.Repo().DiscordIntegration().CreateDiscordIntegration(&ints.DiscordIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		Webhook:   []byte(request.WebhookURL),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, &types.CreateDiscordIntegrationResponse{
+		DiscordIntegration: discordInt.ToDiscordIntegrationType(),
+	})
+}
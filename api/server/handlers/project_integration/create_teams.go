@@ -0,0 +1,65 @@
+package project_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type CreateTeamsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateTeamsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateTeamsHandler {
+	return &CreateTeamsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CreateTeamsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateTeamsIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// incoming webhooks are created from a connector or workflow in the Teams channel,
+	// and are always served over https
+	if parsed, err := url.Parse(request.WebhookURL); err != nil || parsed.Scheme != "https" {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("teams webhook URL must be an https URL"), http.StatusBadRequest,
+		))
+		return
+	}
+
+	teamsInt, err := p.Repo().TeamsIntegration().CreateTeamsIntegration(&ints.TeamsIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		Webhook:   []byte(request.WebhookURL),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, &types.CreateTeamsIntegrationResponse{
+		TeamsIntegration: teamsInt.ToTeamsIntegrationType(),
+	})
+}
@@ -0,0 +1,71 @@
+package project_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type CreateVaultHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateVaultHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateVaultHandler {
+	return &CreateVaultHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CreateVaultHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateVaultIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// the address is only the scheme and host of the server, secrets are read from the
+	// paths of the env group sources
+	parsed, err := url.Parse(request.Address)
+
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		strings.Trim(parsed.Path, "/") != "" || parsed.RawQuery != "" || parsed.User != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("vault address must be an http or https URL without a path, like https://vault.example.com:8200"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	vaultInt, err := p.Repo().VaultIntegration().CreateVaultIntegration(&ints.VaultIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		Address:   fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host),
+		Token:     []byte(request.Token),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, &types.CreateVaultIntegrationResponse{
+		VaultIntegration: vaultInt.ToVaultIntegrationType(),
+	})
+}
@@ -0,0 +1,80 @@
+package project_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/webhook"
+)
+
+type CreateWebhookHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateWebhookHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateWebhookHandler {
+	return &CreateWebhookHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CreateWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateWebhookIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// webhooks must not be used to reach the internal network. The address is checked
+	// again when notifications are sent, in case the host resolves differently later on.
+	if err := webhook.ValidateURL(r.Context(), request.URL); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	webhookInt := &ints.WebhookIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		URL:       []byte(request.URL),
+	}
+
+	var signingSecret string
+
+	if request.Sign {
+		secret, err := encryption.GenerateRandomBytes(32)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		signingSecret = secret
+		webhookInt.SigningSecret = []byte(signingSecret)
+	}
+
+	webhookInt, err := p.Repo().WebhookIntegration().CreateWebhookIntegration(webhookInt)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, &types.CreateWebhookIntegrationResponse{
+		WebhookIntegration: webhookInt.ToWebhookIntegrationType(),
+		SigningSecret:      signingSecret,
+	})
+}
@@ -0,0 +1,44 @@
+package project_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListDiscordHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListDiscordHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListDiscordHandler {
+	return &ListDiscordHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ListDiscordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	discordInts, err := p.Repo().DiscordIntegration().ListDiscordIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListDiscordIntegrationsResponse = make([]*types.DiscordIntegration, 0)
+
+	for _, discordInt := range discordInts {
+		res = append(res, discordInt.ToDiscordIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
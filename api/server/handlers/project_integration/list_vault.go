@@ -0,0 +1,44 @@
+package project_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListVaultHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListVaultHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListVaultHandler {
+	return &ListVaultHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ListVaultHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	vaultInts, err := p.Repo().VaultIntegration().ListVaultIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListVaultIntegrationsResponse = make([]*types.VaultIntegration, 0)
+
+	for _, vaultInt := range vaultInts {
+		res = append(res, vaultInt.ToVaultIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
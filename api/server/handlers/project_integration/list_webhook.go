@@ -0,0 +1,44 @@
+package project_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListWebhookHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListWebhookHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListWebhookHandler {
+	return &ListWebhookHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ListWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookInts, err := p.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListWebhookIntegrationsResponse = make([]*types.WebhookIntegration, 0)
+
+	for _, webhookInt := range webhookInts {
+		res = append(res, webhookInt.ToWebhookIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type RegistryGetImageRetentionPreviewHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryGetImageRetentionPreviewHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryGetImageRetentionPreviewHandler {
+	return &RegistryGetImageRetentionPreviewHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *RegistryGetImageRetentionPreviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.GetImageRetentionPreviewRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	rules := reg.GetImageRetentionRules()
+
+	if request.KeepLast != 0 || request.MaxAgeDays != 0 {
+		rules.KeepLast = request.KeepLast
+		rules.MaxAgeDays = request.MaxAgeDays
+
+		// deployed images are kept when previewing rules for a registry without retention
+		if !reg.ImageRetentionEnabled() {
+			rules.KeepDeployed = true
+		}
+	}
+
+	// the preview lists the images that would be deleted, without deleting them
+	report, err := enforceImageRetention(c.Config(), reg, rules, true)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, report)
+}
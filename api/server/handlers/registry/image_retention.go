@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/jobs"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// StartImageRetentionJob periodically deletes the images that the retention rules of each
+// registry no longer keep
+func StartImageRetentionJob(config *config.Config) {
+	if config.ServerConf.ImageRetentionInterval <= 0 {
+		return
+	}
+
+	jobs.Start(config, "image-retention", config.ServerConf.ImageRetentionInterval, func() {
+		enforceAllImageRetention(config)
+	})
+}
+
+func enforceAllImageRetention(config *config.Config) {
+	regs, err := config.Repo.Registry().ListRegistriesWithImageRetention()
+
+	if err != nil {
+		config.Logger.Error().Err(err).Msg("error listing registries with image retention")
+		return
+	}
+
+	for _, reg := range regs {
+		report, err := enforceImageRetention(config, reg, reg.GetImageRetentionRules(), false)
+
+		if err != nil {
+			config.Logger.Error().Err(err).Msgf("error enforcing image retention in registry %d", reg.ID)
+			continue
+		}
+
+		for _, repoRetention := range report.Repositories {
+			if repoRetention.Error != "" {
+				config.Logger.Error().Msgf(
+					"error enforcing image retention in repository %s of registry %d: %s",
+					repoRetention.RepositoryName, reg.ID, repoRetention.Error,
+				)
+			}
+		}
+	}
+}
+
+// enforceImageRetention plans which images the retention rules delete from each repository of
+// a registry and, unless dryRun is set, deletes them. Errors for a single repository are
+// reported in the repository's retention instead of stopping the other repositories.
+func enforceImageRetention(
+	config *config.Config,
+	reg *models.Registry,
+	rules *types.ImageRetentionRules,
+	dryRun bool,
+) (*types.ImageRetentionReport, error) {
+	var deployed registry.DeployedImages
+	var err error
+
+	if rules.KeepDeployed {
+		deployed, err = getDeployedImages(config, reg.ProjectID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// cast to a registry from registry package
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	repos, err := regAPI.ListRepositories(config.Repo, config.DOConf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.ImageRetentionReport{
+		Rules:        rules,
+		Repositories: make([]*types.RepositoryImageRetention, 0),
+	}
+
+	now := time.Now()
+
+	for _, repo := range repos {
+		images, err := regAPI.ListImageDetails(repo.Name, config.Repo, config.DOConf)
+
+		if err != nil {
+			report.Repositories = append(report.Repositories, &types.RepositoryImageRetention{
+				RepositoryName: repo.Name,
+				KeptTags:       make([]string, 0),
+				DeployedTags:   make([]string, 0),
+				DeletedImages:  make([]*types.Image, 0),
+				Error:          err.Error(),
+			})
+
+			continue
+		}
+
+		repoURI := repo.URI
+
+		retention := registry.PlanImageRetention(repo.Name, images, rules, func(tag string) bool {
+			return deployed.IsDeployed(repoURI, repo.Name, tag)
+		}, now)
+
+		if !dryRun {
+			if err := regAPI.DeleteImages(repo.Name, retention.DeletedImages, config.Repo, config.DOConf); err != nil {
+				retention.Error = err.Error()
+			}
+		}
+
+		report.Repositories = append(report.Repositories, retention)
+	}
+
+	return report, nil
+}
+
+// getDeployedImages returns the images used by releases in the clusters of a project,
+// including the recent revisions that releases may be rolled back to. It returns an error
+// if any cluster cannot be reached, so that deployed images are never deleted.
+func getDeployedImages(config *config.Config, projectID uint) (registry.DeployedImages, error) {
+	clusters, err := config.Repo.Cluster().ListClustersByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(registry.DeployedImages)
+
+	for _, cluster := range clusters {
+		releases, err := getClusterDeployedReleases(config, cluster)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not list releases in cluster %d: %w", cluster.ID, err)
+		}
+
+		res.AddReleases(releases)
+	}
+
+	return res, nil
+}
+
+func getClusterDeployedReleases(config *config.Config, cluster *models.Cluster) ([]*release.Release, error) {
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Repo:                      config.Repo,
+		DigitalOceanOAuth:         config.DOConf,
+		Cluster:                   cluster,
+		AllowInClusterConnections: config.ServerConf.InitInCluster,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	helmAgent, err := helm.GetAgentFromK8sAgent("secret", "", config.Logger, agent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deployed, err := helmAgent.ListDeployedReleases("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*release.Release, 0)
+
+	// the history is read with an agent for the namespace of each release, since releases
+	// in different namespaces can share a name
+	namespaceAgents := make(map[string]*helm.Agent)
+
+	for _, rel := range deployed {
+		nsAgent, ok := namespaceAgents[rel.Namespace]
+
+		if !ok {
+			nsAgent, err = helm.GetAgentFromK8sAgent("secret", rel.Namespace, config.Logger, agent)
+
+			if err != nil {
+				return nil, err
+			}
+
+			namespaceAgents[rel.Namespace] = nsAgent
+		}
+
+		history, err := nsAgent.GetReleaseHistory(rel.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, rel)
+		res = append(res, registry.GetRollbackRevisions(history)...)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type RegistryUpdateImageRetentionHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryUpdateImageRetentionHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryUpdateImageRetentionHandler {
+	return &RegistryUpdateImageRetentionHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *RegistryUpdateImageRetentionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.UpdateImageRetentionRequest{}
+
+	ok := p.DecodeAndValidate(w, r, request)
+
+	if !ok {
+		return
+	}
+
+	// images used by releases are kept unless explicitly disabled
+	keepDeployed := true
+
+	if request.KeepDeployed != nil {
+		keepDeployed = *request.KeepDeployed
+	}
+
+	reg.ImageRetentionKeepLast = request.KeepLast
+	reg.ImageRetentionMaxAgeDays = request.MaxAgeDays
+	reg.ImageRetentionKeepDeployed = keepDeployed
+
+	reg, err := p.Repo().Registry().UpdateRegistry(reg)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, reg.ToRegistryType())
+}
@@ -0,0 +1,266 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+const (
+	autoRollbackEventID      = "auto_rollback"
+	autoRollbackPollInterval = 5 * time.Second
+)
+
+type autoRollbackOpts struct {
+	helmAgent   *helm.Agent
+	release     *models.Release
+	helmRelease *release.Release
+	notifier    slack.Notifier
+	notifyOpts  *slack.NotifyOpts
+	notify      bool
+}
+
+// autoRollback holds copies of everything the background health check needs, so that it
+// does not share the release, the helm release or the notification options with the
+// handler which started it
+type autoRollback struct {
+	helmAgent      *helm.Agent
+	eventContainer uint
+	name           string
+	namespace      string
+	version        int
+	manifest       string
+	timeout        time.Duration
+	notifier       slack.Notifier
+	notifyOpts     slack.NotifyOpts
+	notify         bool
+}
+
+// startAutoRollback watches the controllers of an upgraded revision in the background
+// if auto rollback is enabled for the release. If the controllers are not ready within
+// the configured timeout, the release is rolled back to the previous revision. The
+// progress is recorded as a step of the release, and a notification is sent on rollback.
+func startAutoRollback(config *config.Config, opts *autoRollbackOpts) {
+	rollback, err := newAutoRollback(config, opts)
+
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf(
+			"error starting auto rollback for release %s in namespace %s",
+			opts.helmRelease.Name,
+			opts.helmRelease.Namespace,
+		)
+
+		return
+	} else if rollback == nil {
+		return
+	}
+
+	go func() {
+		if err := rollback.run(config); err != nil {
+			config.Logger.Error().Err(err).Msgf(
+				"error running auto rollback for release %s in namespace %s",
+				rollback.name,
+				rollback.namespace,
+			)
+		}
+	}()
+}
+
+// newAutoRollback returns the health check of an upgraded revision, or nil if the revision
+// should not be checked. The event container of the release is created before the health
+// check starts, so that the check only appends steps and never writes the release itself.
+func newAutoRollback(config *config.Config, opts *autoRollbackOpts) (*autoRollback, error) {
+	if opts.release == nil || !opts.release.AutoRollbackEnabled || opts.helmRelease == nil || opts.helmRelease.Version <= 1 {
+		return nil, nil
+	}
+
+	// jobs do not have long-running controllers to check
+	if opts.helmRelease.Chart != nil && opts.helmRelease.Chart.Metadata.Name == "job" {
+		return nil, nil
+	}
+
+	containerID, err := ensureEventContainer(config, opts.release)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &autoRollback{
+		helmAgent:      opts.helmAgent,
+		eventContainer: containerID,
+		name:           opts.helmRelease.Name,
+		namespace:      opts.helmRelease.Namespace,
+		version:        opts.helmRelease.Version,
+		manifest:       opts.helmRelease.Manifest,
+		timeout:        time.Duration(opts.release.ToAutoRollbackType().TimeoutSeconds) * time.Second,
+		notifier:       opts.notifier,
+		notify:         opts.notify,
+	}
+
+	if opts.notifyOpts != nil {
+		res.notifyOpts = *opts.notifyOpts
+	}
+
+	return res, nil
+}
+
+func (a *autoRollback) run(config *config.Config) error {
+	since := time.Now().Add(-autoRollbackPollInterval)
+
+	controllers := grapher.ParseControllers(grapher.ImportMultiDocYAML([]byte(a.manifest)))
+
+	for i := range controllers {
+		controllers[i].Namespace = a.namespace
+	}
+
+	err := a.appendStep(config, 200, types.EventStatusInProgress, fmt.Sprintf(
+		"Waiting up to %s for version %d to become ready", a.timeout, a.version,
+	))
+
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+
+	healthErr := a.helmAgent.K8sAgent.WaitForControllersReady(ctx, controllers, since, autoRollbackPollInterval)
+
+	if healthErr == nil {
+		return a.appendStep(config, 210, types.EventStatusSuccess, fmt.Sprintf("Version %d is ready", a.version))
+	}
+
+	// if the release was upgraded or rolled back again while waiting, the newer
+	// operation takes precedence
+	latest, err := a.helmAgent.GetRelease(a.name, 0, false)
+
+	if err != nil {
+		return err
+	}
+
+	if latest.Version != a.version {
+		return a.appendStep(config, 210, types.EventStatusFailed, fmt.Sprintf(
+			"Version %d failed its health check but was replaced by version %d, so it was not rolled back: %s",
+			a.version,
+			latest.Version,
+			healthErr.Error(),
+		))
+	}
+
+	history, err := a.helmAgent.GetReleaseHistory(a.name)
+
+	if err != nil {
+		return err
+	}
+
+	prevVersion, ok := getLastDeployedVersion(history, a.version)
+
+	if !ok {
+		return a.appendStep(config, 210, types.EventStatusFailed, fmt.Sprintf(
+			"Version %d failed its health check and there is no previously deployed version to roll back to: %s",
+			a.version,
+			healthErr.Error(),
+		))
+	}
+
+	if err := a.helmAgent.RollbackRelease(a.name, prevVersion); err != nil {
+		a.appendStep(config, 210, types.EventStatusFailed, fmt.Sprintf(
+			"Version %d failed its health check and could not be rolled back: %s", a.version, err.Error(),
+		))
+
+		return err
+	}
+
+	if a.notify {
+		a.notifyOpts.Status = slack.StatusHelmRolledBack
+		a.notifyOpts.Version = prevVersion
+		a.notifyOpts.Info = healthErr.Error()
+
+		a.notifier.Notify(&a.notifyOpts)
+	}
+
+	return a.appendStep(config, 210, types.EventStatusFailed, fmt.Sprintf(
+		"Version %d failed its health check and was rolled back to version %d: %s",
+		a.version,
+		prevVersion,
+		healthErr.Error(),
+	))
+}
+
+func (a *autoRollback) appendStep(config *config.Config, index int64, status types.EventStatus, info string) error {
+	container, err := config.Repo.BuildEvent().ReadEventContainer(a.eventContainer)
+
+	if err != nil {
+		return err
+	}
+
+	return config.Repo.BuildEvent().AppendEvent(container, &models.SubEvent{
+		EventContainerID: container.ID,
+		EventID:          autoRollbackEventID,
+		Name:             "Health check",
+		Index:            index,
+		Status:           status,
+		Info:             info,
+	})
+}
+
+// getLastDeployedVersion returns the latest revision older than the given version that was
+// successfully deployed. Revisions that were deployed and later replaced are superseded, while
+// failed and pending revisions were never deployed.
+func getLastDeployedVersion(history []*release.Release, version int) (int, bool) {
+	res := 0
+
+	for _, rel := range history {
+		if rel.Info == nil || rel.Version >= version || rel.Version <= res {
+			continue
+		}
+
+		if rel.Info.Status == release.StatusDeployed || rel.Info.Status == release.StatusSuperseded {
+			res = rel.Version
+		}
+	}
+
+	return res, res != 0
+}
+
+// ensureEventContainer returns the event container of a release, creating it if the release
+// does not have one yet. The release is read again before it is updated, so that changes
+// made since the caller read it are not overwritten.
+func ensureEventContainer(config *config.Config, rel *models.Release) (uint, error) {
+	if rel.EventContainer != 0 {
+		return rel.EventContainer, nil
+	}
+
+	latest, err := config.Repo.Release().ReadRelease(rel.ClusterID, rel.Name, rel.Namespace)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if latest.EventContainer == 0 {
+		container, err := config.Repo.BuildEvent().CreateEventContainer(&models.EventContainer{ReleaseID: latest.ID})
+
+		if err != nil {
+			return 0, err
+		}
+
+		latest.EventContainer = container.ID
+
+		if _, err := config.Repo.Release().UpdateRelease(latest); err != nil {
+			return 0, err
+		}
+	}
+
+	// the caller may save its copy of the release later on, which must keep the container
+	rel.EventContainer = latest.EventContainer
+
+	return latest.EventContainer, nil
+}
@@ -0,0 +1,168 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const autoRollbackTestManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+`
+
+func newReadyDeploymentFixture() *appsv1.Deployment {
+	replicas := int32(1)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "web",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  replicas,
+		},
+	}
+}
+
+// TestAutoRollbackDoesNotShareHandlerState runs the health check while the handler keeps
+// changing its own release, helm release and notification options, which is only
+// meaningful with the race detector (go test -race)
+func TestAutoRollbackDoesNotShareHandlerState(t *testing.T) {
+	config := apitest.LoadConfig(t)
+
+	_, err := config.Repo.Release().CreateRelease(&models.Release{
+		Name:                "web",
+		Namespace:           "default",
+		ProjectID:           1,
+		ClusterID:           1,
+		AutoRollbackEnabled: true,
+		AutoRollbackTimeout: 1,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the handler works on its own copy of the release row
+	rel := &models.Release{
+		Name:                "web",
+		Namespace:           "default",
+		ProjectID:           1,
+		ClusterID:           1,
+		AutoRollbackEnabled: true,
+		AutoRollbackTimeout: 1,
+	}
+
+	rel.ID = 1
+
+	helmRelease := &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   2,
+		Manifest:  autoRollbackTestManifest,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "web"},
+		},
+	}
+
+	notifyOpts := &slack.NotifyOpts{
+		Name:      "web",
+		Namespace: "default",
+	}
+
+	rollback, err := newAutoRollback(config, &autoRollbackOpts{
+		helmAgent: &helm.Agent{
+			K8sAgent: kubernetes.GetAgentTesting(newReadyDeploymentFixture()),
+		},
+		release:     rel,
+		helmRelease: helmRelease,
+		notifyOpts:  notifyOpts,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, rollback)
+	assert.NotZero(t, rel.EventContainer, "the event container should be set on the handler's release")
+
+	stored, err := config.Repo.Release().ReadRelease(1, "web", "default")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, rel.EventContainer, stored.EventContainer)
+
+	done := make(chan error)
+
+	go func() {
+		done <- rollback.run(config)
+	}()
+
+	// the handler carries on with the request while the health check runs
+	rel.ImageRepoURI = "registry.example.com/web"
+	helmRelease.Version = 3
+	helmRelease.Manifest = ""
+	notifyOpts.Status = slack.StatusHelmDeployed
+	notifyOpts.Version = 3
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := config.Repo.BuildEvent().ReadEventsByContainerID(rel.EventContainer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, events, 2) {
+		assert.EqualValues(t, types.EventStatusInProgress, events[0].Status)
+		assert.Equal(t, types.EventStatusSuccess, events[1].Status)
+		assert.Equal(t, "Version 2 is ready", events[1].Info)
+	}
+}
+
+func TestNewAutoRollbackSkipsFirstRevision(t *testing.T) {
+	config := apitest.LoadConfig(t)
+
+	rollback, err := newAutoRollback(config, &autoRollbackOpts{
+		release: &models.Release{
+			Name:                "web",
+			Namespace:           "default",
+			AutoRollbackEnabled: true,
+		},
+		helmRelease: &release.Release{
+			Name:      "web",
+			Namespace: "default",
+			Version:   1,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, rollback)
+}
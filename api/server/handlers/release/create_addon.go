@@ -13,6 +13,7 @@ import (
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/helm/repo"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/oauth"
 	"helm.sh/helm/v3/pkg/chart"
@@ -139,22 +140,7 @@ func LoadChart(config *config.Config, opts *LoadAddonChartOpts) (*chart.Chart, e
 
 		for _, hr := range hrs {
 			if hr.RepoURL == opts.RepoURL {
-				if hr.BasicAuthIntegrationID != 0 {
-					// read the basic integration id
-					basic, err := config.Repo.BasicIntegration().ReadBasicIntegration(opts.ProjectID, hr.BasicAuthIntegrationID)
-
-					if err != nil {
-
-						return nil, err
-					}
-
-					return loader.LoadChart(&loader.BasicAuthClient{
-						Username: string(basic.Username),
-						Password: string(basic.Password),
-					}, hr.RepoURL, opts.TemplateName, opts.TemplateVersion)
-				} else {
-					return loader.LoadChartPublic(hr.RepoURL, opts.TemplateName, opts.TemplateVersion)
-				}
+				return (*repo.HelmRepo)(hr).GetChart(config.Repo, config.DOConf, opts.TemplateName, opts.TemplateVersion)
 			}
 		}
 	}
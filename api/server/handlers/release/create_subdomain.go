@@ -33,6 +33,7 @@ func NewCreateSubdomainHandler(
 func (c *CreateSubdomainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
 
 	agent, err := c.GetAgent(r, cluster, "")
 
@@ -51,6 +52,8 @@ func (c *CreateSubdomainHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	}
 
 	createDomain := domain.CreateDNSRecordConfig{
+		ClusterID:   cluster.ID,
+		Namespace:   namespace,
 		ReleaseName: name,
 		RootDomain:  c.Config().ServerConf.AppRootDomain,
 		Endpoint:    endpoint,
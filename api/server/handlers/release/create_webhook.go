@@ -8,7 +8,9 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 	"helm.sh/helm/v3/pkg/release"
 )
 
@@ -18,10 +20,11 @@ type CreateWebhookHandler struct {
 
 func NewCreateWebhookHandler(
 	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
 	writer shared.ResultWriter,
 ) *CreateWebhookHandler {
 	return &CreateWebhookHandler{
-		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
 	}
 }
 
@@ -29,12 +32,51 @@ func (c *CreateWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
-	release, err := createReleaseFromHelmRelease(c.Config(), cluster.ProjectID, cluster.ID, helmRelease)
+	request := &types.CreateWebhookRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	release, err := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+	if err == gorm.ErrRecordNotFound {
+		release, err = createReleaseFromHelmRelease(c.Config(), cluster.ProjectID, cluster.ID, helmRelease)
+	}
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	c.WriteResult(w, r, release.ToReleaseType())
+	if request.RotateSigningSecret || request.RequireSignature != nil {
+		if request.RotateSigningSecret || release.WebhookSigningSecret == "" {
+			secret, err := encryption.GenerateRandomBytes(32)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			release.WebhookSigningSecret = secret
+			release.WebhookLastSignedAt = 0
+			release.WebhookLastSignature = ""
+		}
+
+		if request.RequireSignature != nil {
+			release.WebhookSignatureRequired = *request.RequireSignature
+		}
+
+		release, err = c.Repo().Release().UpdateRelease(release)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	res := release.ToReleaseType()
+	res.WebhookSigningSecret = release.WebhookSigningSecret
+
+	c.WriteResult(w, r, res)
 }
@@ -43,5 +43,8 @@ func (c *GetWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c.WriteResult(w, r, release.ToReleaseType())
+	res := release.ToReleaseType()
+	res.WebhookSigningSecret = release.WebhookSigningSecret
+
+	c.WriteResult(w, r, res)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/integrations/slack"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
 	"helm.sh/helm/v3/pkg/release"
 )
 
@@ -123,8 +124,6 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		helmRelease = newHelmRelease
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
-
 	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
 	var notifConf *types.NotificationConfig
@@ -140,7 +139,7 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	notifier := slack.NewSlackNotifier(notifConf, slackInts...)
+	notifier := notifier.NewProjectNotifier(c.Repo(), cluster.ProjectID, notifConf, c.Config().Logger)
 
 	notifyOpts := &slack.NotifyOpts{
 		ProjectID:   cluster.ProjectID,
@@ -183,6 +182,17 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	if releaseErr == nil && rel != nil {
+		startAutoRollback(c.Config(), &autoRollbackOpts{
+			helmAgent:   helmAgent,
+			release:     rel,
+			helmRelease: helmRelease,
+			notifier:    notifier,
+			notifyOpts:  notifyOpts,
+			notify:      !cluster.NotificationsDisabled,
+		})
+	}
+
 	// update the github actions env if the release exists and is built from source
 	if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
 		if releaseErr == nil && rel != nil {
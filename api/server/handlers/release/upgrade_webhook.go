@@ -1,12 +1,16 @@
 package release
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/router/middleware"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -15,6 +19,9 @@ import (
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/webhook"
 	"gorm.io/gorm"
 )
 
@@ -54,6 +61,14 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the webhook is not scoped by its URL, so its audit event is attributed to the
+	// release of the token, including calls with an invalid signature
+	middleware.SetAuditRelease(r, release)
+
+	if ok := c.verifySignature(w, r, release); !ok {
+		return
+	}
+
 	cluster, err := c.Repo().Cluster().ReadCluster(release.ProjectID, release.ClusterID)
 
 	if err != nil {
@@ -133,8 +148,6 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Values:     rel.Config,
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(release.ProjectID)
-
 	var notifConf *types.NotificationConfig
 	notifConf = nil
 	if release != nil && release.NotificationConfig != 0 {
@@ -148,7 +161,7 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	notifier := slack.NewSlackNotifier(notifConf, slackInts...)
+	notifier := notifier.NewProjectNotifier(c.Repo(), release.ProjectID, notifConf, c.Config().Logger)
 
 	notifyOpts := &slack.NotifyOpts{
 		ProjectID:   release.ProjectID,
@@ -193,6 +206,15 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	startAutoRollback(c.Config(), &autoRollbackOpts{
+		helmAgent:   helmAgent,
+		release:     release,
+		helmRelease: rel,
+		notifier:    notifier,
+		notifyOpts:  notifyOpts,
+		notify:      !cluster.NotificationsDisabled,
+	})
+
 	c.Config().AnalyticsClient.Track(analytics.ApplicationDeploymentWebhookTrack(&analytics.ApplicationDeploymentWebhookTrackOpts{
 		ImageURI: fmt.Sprintf("%v", repository),
 		ApplicationScopedTrackOpts: analytics.GetApplicationScopedTrackOpts(
@@ -205,3 +227,73 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		),
 	}))
 }
+
+// verifySignature verifies the signature header of a webhook request if the release has
+// a signing secret. Unsigned requests are only accepted if the release does not require
+// signatures. Since the signature only covers the request body, signed requests must
+// send the commit in the body rather than as a query parameter.
+func (c *WebhookHandler) verifySignature(w http.ResponseWriter, r *http.Request, release *models.Release) bool {
+	header := r.Header.Get(webhook.SignatureHeader)
+
+	if header == "" && !release.WebhookSignatureRequired {
+		return true
+	}
+
+	if release.WebhookSigningSecret == "" {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("release %s does not have a webhook signing secret", release.Name),
+		))
+
+		return false
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return false
+	}
+
+	// restore the body so that it can be decoded
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	sig, err := webhook.Verify(release.WebhookSigningSecret, header, body, time.Now(), webhook.DefaultSignatureTolerance)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+		return false
+	}
+
+	if r.URL.Query().Get("commit") != "" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("commit must be sent in the request body for signed webhook requests"),
+			http.StatusBadRequest,
+		))
+
+		return false
+	}
+
+	// reject replays of the last accepted request, and of any request signed before it. The
+	// check and the update are a single statement, so that concurrent replays of the same
+	// request cannot both be accepted.
+	accepted, err := c.Repo().Release().RecordWebhookSignature(release.ID, sig.Timestamp.Unix(), sig.Value)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return false
+	}
+
+	if !accepted {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("webhook request has already been received"),
+			http.StatusForbidden,
+		))
+
+		return false
+	}
+
+	release.WebhookLastSignedAt = sig.Timestamp.Unix()
+	release.WebhookLastSignature = sig.Value
+
+	return true
+}
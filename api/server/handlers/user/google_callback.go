@@ -80,6 +80,18 @@ func (p *UserOAuthGoogleCallbackHandler) ServeHTTP(w http.ResponseWriter, r *htt
 
 	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
 
+	// users with a second factor verify a one-time password on the login page of the
+	// dashboard before the session is authenticated
+	if user.TOTPEnabled {
+		if err := authn.SaveUserMFAPending(w, r, p.Config(), user); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		http.Redirect(w, r, "/login?mfa=true", 302)
+		return
+	}
+
 	// save the user as authenticated in the session
 	redirect, err := authn.SaveUserAuthenticated(w, r, p.Config(), user)
 
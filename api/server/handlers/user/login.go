@@ -62,6 +62,24 @@ func (u *UserLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// if the user has enabled a second factor, the session is only authenticated once a
+	// one-time password is verified through the /login/mfa endpoint
+	if storedUser.TOTPEnabled {
+		if err := authn.SaveUserMFAPending(w, r, u.Config(), storedUser); err != nil {
+			u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		u.WriteResult(w, r, &types.LoginUserResponse{
+			ID:          storedUser.ID,
+			Email:       storedUser.Email,
+			MFAEnabled:  true,
+			MFARequired: true,
+		})
+
+		return
+	}
+
 	// save the user as authenticated in the session
 	redirect, err := authn.SaveUserAuthenticated(w, r, u.Config(), storedUser)
 
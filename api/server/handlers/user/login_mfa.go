@@ -0,0 +1,126 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+)
+
+const (
+	// mfaLoginTimeout is the time that a user has to verify a one-time password after
+	// verifying their password
+	mfaLoginTimeout = 10 * time.Minute
+
+	// mfaLoginMaxAttempts is the number of invalid codes after which the user has to log
+	// in with their password again
+	mfaLoginMaxAttempts = 5
+)
+
+type UserLoginMFAHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserLoginMFAHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserLoginMFAHandler {
+	return &UserLoginMFAHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *UserLoginMFAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.LoginMFARequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	session, err := u.Config().Store.Get(r, u.Config().ServerConf.CookieName)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	userID, ok := session.Values["mfa_user_id"].(uint)
+
+	if !ok || userID == 0 {
+		u.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("no login is waiting for a one-time password")))
+		return
+	}
+
+	startedAt, _ := session.Values["mfa_started_at"].(int64)
+	attempts, _ := session.Values["mfa_attempts"].(int)
+
+	if time.Since(time.Unix(startedAt, 0)) > mfaLoginTimeout || attempts >= mfaLoginMaxAttempts {
+		if err := authn.SaveUserUnauthenticated(w, r, u.Config()); err != nil {
+			u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("login expired, please log in again"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	user, err := u.Repo().User().ReadUser(userID)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	valid, err := verifyMFACode(u.Config(), user, request.Code)
+
+	if errors.Is(err, errMFALocked) {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusTooManyRequests))
+		return
+	} else if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !valid {
+		session.Values["mfa_attempts"] = attempts + 1
+
+		if err := session.Save(r, w); err != nil {
+			u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid one-time password"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	// save the user as authenticated in the session
+	redirect, err := authn.SaveUserAuthenticated(w, r, u.Config(), user)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	u.WriteResult(w, r, user.ToUserType())
+}
@@ -0,0 +1,156 @@
+package user_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+)
+
+func TestLoginUserMFARequired(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	secret := createTestUserWithTOTP(t, config)
+
+	rr := loginWithPassword(t, config)
+
+	apitest.AssertResponseExpected(t, rr, &types.LoginUserResponse{
+		ID:          1,
+		Email:       "test@test.it",
+		MFAEnabled:  true,
+		MFARequired: true,
+	}, &types.LoginUserResponse{})
+
+	code, err := totp.GenerateCode(secret, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr = loginWithMFACode(t, config, rr.Result().Cookies(), code)
+
+	apitest.AssertResponseExpected(t, rr, &types.LoginUserResponse{
+		ID:            1,
+		Email:         "test@test.it",
+		EmailVerified: true,
+		MFAEnabled:    true,
+	}, &types.LoginUserResponse{})
+}
+
+func TestLoginUserMFAInvalidCode(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	createTestUserWithTOTP(t, config)
+
+	rr := loginWithPassword(t, config)
+	rr = loginWithMFACode(t, config, rr.Result().Cookies(), "000000")
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: fmt.Sprintf("invalid one-time password"),
+	})
+}
+
+func TestLoginUserMFALockedAcrossLogins(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	secret := createTestUserWithTOTP(t, config)
+
+	// logging in with the password again does not reset the failed attempts of the user
+	for i := 0; i < 10; i++ {
+		rr := loginWithPassword(t, config)
+		loginWithMFACode(t, config, rr.Result().Cookies(), "000000")
+	}
+
+	code, err := totp.GenerateCode(secret, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := loginWithPassword(t, config)
+	rr = loginWithMFACode(t, config, rr.Result().Cookies(), code)
+
+	apitest.AssertResponseError(t, rr, http.StatusTooManyRequests, &types.ExternalError{
+		Error: fmt.Sprintf("too many invalid one-time passwords, please try again later"),
+	})
+}
+
+func TestLoginUserMFANotPending(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	createTestUserWithTOTP(t, config)
+
+	rr := loginWithMFACode(t, config, nil, "000000")
+
+	apitest.AssertResponseForbidden(t, rr)
+}
+
+func createTestUserWithTOTP(t *testing.T, config *config.Config) string {
+	u := apitest.CreateTestUser(t, config, true)
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u.TOTPSecret = []byte(secret)
+	u.TOTPEnabled = true
+
+	if _, err := config.Repo.User().UpdateUser(u); err != nil {
+		t.Fatal(err)
+	}
+
+	return secret
+}
+
+func loginWithPassword(t *testing.T, config *config.Config) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login",
+		&types.LoginUserRequest{
+			Email:    "test@test.it",
+			Password: "hello",
+		},
+	)
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func loginWithMFACode(t *testing.T, config *config.Config, cookies []*http.Cookie, code string) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login/mfa",
+		&types.LoginMFARequest{
+			Code: code,
+		},
+	)
+
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	handler := user.NewUserLoginMFAHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
@@ -0,0 +1,85 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const (
+	recoveryCodeCount = 10
+
+	// mfaMaxFailedAttempts is the number of invalid codes after which the codes of a user are
+	// rejected for mfaLockoutDuration. Unlike the attempts of a single login, which are
+	// stored in the session, the failed attempts are stored on the user, so logging in with
+	// the password again does not reset them.
+	mfaMaxFailedAttempts = 10
+	mfaLockoutDuration   = 15 * time.Minute
+)
+
+var errMFALocked = errors.New("too many invalid one-time passwords, please try again later")
+
+// verifyMFACode checks a one-time password or a recovery code for a user with TOTP
+// enabled. Since codes can only be used once, the user is updated on success. It returns
+// errMFALocked if the user has entered too many invalid codes.
+func verifyMFACode(config *config.Config, user *models.User, code string) (bool, error) {
+	if !user.TOTPEnabled || len(user.TOTPSecret) == 0 {
+		return false, nil
+	}
+
+	now := time.Now()
+
+	if user.TOTPLockedUntil != nil && now.Before(*user.TOTPLockedUntil) {
+		return false, errMFALocked
+	}
+
+	valid := false
+
+	if step, ok := totp.Validate(string(user.TOTPSecret), code, now, user.TOTPLastStep); ok {
+		user.TOTPLastStep = step
+		valid = true
+	} else {
+		hash := totp.HashRecoveryCode(code)
+		hashes := splitRecoveryCodes(user.TOTPRecoveryCodes)
+
+		for i, stored := range hashes {
+			if stored == hash {
+				user.TOTPRecoveryCodes = strings.Join(append(hashes[:i], hashes[i+1:]...), ",")
+				valid = true
+				break
+			}
+		}
+	}
+
+	if valid {
+		user.TOTPFailedAttempts = 0
+		user.TOTPLockedUntil = nil
+	} else {
+		user.TOTPFailedAttempts++
+
+		if user.TOTPFailedAttempts >= mfaMaxFailedAttempts {
+			lockedUntil := now.Add(mfaLockoutDuration)
+
+			user.TOTPFailedAttempts = 0
+			user.TOTPLockedUntil = &lockedUntil
+		}
+	}
+
+	if _, err := config.Repo.User().UpdateUser(user); err != nil {
+		return false, err
+	}
+
+	return valid, nil
+}
+
+func splitRecoveryCodes(codes string) []string {
+	if codes == "" {
+		return []string{}
+	}
+
+	return strings.Split(codes, ",")
+}
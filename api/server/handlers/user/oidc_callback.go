@@ -0,0 +1,302 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+type UserOAuthOIDCCallbackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserOAuthOIDCCallbackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCCallbackHandler {
+	return &UserOAuthOIDCCallbackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.Config().OIDCConf == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("oidc login is not enabled")))
+		return
+	}
+
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, ok := session.Values["state"]; !ok {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("state not found in session")))
+		return
+	}
+
+	if r.URL.Query().Get("state") != session.Values["state"] {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("state does not match")))
+		return
+	}
+
+	nonce, ok := session.Values["oidc_nonce"].(string)
+
+	if !ok || nonce == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("nonce not found in session")))
+		return
+	}
+
+	// the nonce can only be used once
+	delete(session.Values, "oidc_nonce")
+
+	if err := session.Save(r, w); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if errMsg := r.URL.Query().Get("error"); errMsg != "" {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(errMsg), 302)
+		return
+	}
+
+	rawIDToken, err := p.Config().OIDCConf.Exchange(r.Context(), r.URL.Query().Get("code"))
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	claims, err := p.Config().OIDCConf.VerifyIDToken(rawIDToken, nonce, p.Config().ServerConf.OIDCGroupsClaim)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	// otherwise, create the user if not exists
+	user, err := upsertOIDCUserFromClaims(p.Config(), claims)
+
+	if err != nil && (strings.Contains(err.Error(), "already registered") ||
+		strings.Contains(err.Error(), "not allowed")) {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(err.Error()), 302)
+		return
+	} else if err != nil {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if err := applyOIDCGroupRoles(p.Config(), user, claims.Groups); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
+
+	// users with a second factor verify a one-time password on the login page of the
+	// dashboard before the session is authenticated
+	if user.TOTPEnabled {
+		if err := authn.SaveUserMFAPending(w, r, p.Config(), user); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		http.Redirect(w, r, "/login?mfa=true", 302)
+		return
+	}
+
+	// save the user as authenticated in the session
+	redirect, err := authn.SaveUserAuthenticated(w, r, p.Config(), user)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// non-fatal send email verification
+	if !user.EmailVerified {
+		err = startEmailVerification(p.Config(), w, r, user)
+
+		if err != nil {
+			p.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard", 302)
+}
+
+func upsertOIDCUserFromClaims(config *config.Config, claims *oauth.OIDCClaims) (*models.User, error) {
+	if claims.Email == "" {
+		return nil, fmt.Errorf("email claim not returned by oidc provider")
+	}
+
+	if err := checkUserRestrictions(config.ServerConf, claims.Email); err != nil {
+		return nil, err
+	}
+
+	// if the app has allowed domains, the email must be verified by the provider and
+	// belong to one of the domains
+	if allowed := config.ServerConf.OIDCAllowedDomains; len(allowed) > 0 {
+		if !claims.EmailVerified || !isEmailInDomains(claims.Email, allowed) {
+			return nil, fmt.Errorf("email domain not allowed")
+		}
+	}
+
+	user, err := config.Repo.User().ReadUserByOIDCUserID(claims.Subject)
+
+	// if the user does not exist, create new user
+	if err != nil && err == gorm.ErrRecordNotFound {
+		// check if a user with that email address already exists
+		_, err = config.Repo.User().ReadUserByEmail(claims.Email)
+
+		if err == gorm.ErrRecordNotFound {
+			user = &models.User{
+				Email:         claims.Email,
+				EmailVerified: !config.Metadata.Email || claims.EmailVerified,
+				OIDCUserID:    claims.Subject,
+			}
+
+			user, err = config.Repo.User().CreateUser(user)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = addUserToDefaultProject(config, user)
+
+			if err != nil {
+				return nil, err
+			}
+
+			config.AnalyticsClient.Track(analytics.UserCreateTrack(&analytics.UserCreateTrackOpts{
+				UserScopedTrackOpts: analytics.GetUserScopedTrackOpts(user.ID),
+				Email:               user.Email,
+			}))
+		} else if err == nil {
+			return nil, fmt.Errorf("email already registered")
+		} else if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("unexpected error occurred:%s", err.Error())
+	}
+
+	return user, nil
+}
+
+func isEmailInDomains(email string, domains []string) bool {
+	atIdx := strings.LastIndex(email, "@")
+
+	if atIdx == -1 {
+		return false
+	}
+
+	emailDomain := strings.ToLower(email[atIdx+1:])
+
+	for _, domain := range domains {
+		if strings.ToLower(strings.TrimSpace(domain)) == emailDomain {
+			return true
+		}
+	}
+
+	return false
+}
+
+var roleRank = map[types.RoleKind]int{
+	types.RoleViewer:    1,
+	types.RoleDeveloper: 2,
+	types.RoleAdmin:     3,
+}
+
+// applyOIDCGroupRoles grants the user the highest role mapped from each of their groups.
+// Existing roles are only ever upgraded: roles are not removed or downgraded when the user
+// leaves a group, and custom roles are left untouched.
+func applyOIDCGroupRoles(config *config.Config, user *models.User, groups []string) error {
+	groupRoles := config.OIDCConf.GroupRoles
+
+	if len(groupRoles) == 0 || len(groups) == 0 {
+		return nil
+	}
+
+	userGroups := make(map[string]bool)
+
+	for _, group := range groups {
+		userGroups[group] = true
+	}
+
+	projectKinds := make(map[uint]types.RoleKind)
+
+	for _, groupRole := range groupRoles {
+		if !userGroups[groupRole.Group] {
+			continue
+		}
+
+		if roleRank[groupRole.Kind] > roleRank[projectKinds[groupRole.ProjectID]] {
+			projectKinds[groupRole.ProjectID] = groupRole.Kind
+		}
+	}
+
+	for projID, kind := range projectKinds {
+		role, err := config.Repo.Project().ReadProjectRole(projID, user.ID)
+
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		} else if err == gorm.ErrRecordNotFound {
+			project, err := config.Repo.Project().ReadProject(projID)
+
+			if err == gorm.ErrRecordNotFound {
+				// the mapping refers to a project that no longer exists
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			_, err = config.Repo.Project().CreateProjectRole(project, &models.Role{
+				Role: types.Role{
+					UserID:    user.ID,
+					ProjectID: projID,
+					Kind:      kind,
+				},
+			})
+
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if role.Kind == types.RoleCustom || roleRank[kind] <= roleRank[role.Kind] {
+			continue
+		}
+
+		role.Kind = kind
+
+		if _, err := config.Repo.Project().UpdateProjectRole(projID, role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+type UserOAuthOIDCHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserOAuthOIDCHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCHandler {
+	return &UserOAuthOIDCHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.Config().OIDCConf == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("oidc login is not enabled")))
+		return
+	}
+
+	state := oauth.CreateRandomState()
+
+	if err := p.PopulateOAuthSession(w, r, state, false, false, "", 0); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the nonce is checked against the ID token to prevent replay of the token
+	nonce := oauth.CreateRandomState()
+
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	session.Values["oidc_nonce"] = nonce
+
+	if err := session.Save(r, w); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	url, err := p.Config().OIDCConf.AuthCodeURL(state, nonce)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	http.Redirect(w, r, url, 302)
+}
@@ -0,0 +1,209 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// TOTPEnrollHandler starts TOTP enrolment by generating a new secret for the user.
+// The secret is not required at login until a code is verified with TOTPVerifyHandler.
+type TOTPEnrollHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewTOTPEnrollHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *TOTPEnrollHandler {
+	return &TOTPEnrollHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (u *TOTPEnrollHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("multi-factor authentication is already enabled"),
+			http.StatusConflict,
+		))
+
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	user.TOTPSecret = []byte(secret)
+	user.TOTPLastStep = 0
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.EnrollTOTPResponse{
+		Secret: secret,
+		URI:    totp.ProvisioningURI("Porter", user.Email, secret),
+	})
+}
+
+// TOTPVerifyHandler completes TOTP enrolment by verifying a code generated from the
+// secret, and returns the recovery codes of the user
+type TOTPVerifyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTOTPVerifyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TOTPVerifyHandler {
+	return &TOTPVerifyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *TOTPVerifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.VerifyTOTPRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("multi-factor authentication is already enabled"),
+			http.StatusConflict,
+		))
+
+		return
+	}
+
+	if len(user.TOTPSecret) == 0 {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("multi-factor authentication enrolment has not been started"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	step, ok := totp.Validate(string(user.TOTPSecret), request.Code, time.Now(), user.TOTPLastStep)
+
+	if !ok {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid one-time password"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	hashes := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		hashes = append(hashes, totp.HashRecoveryCode(code))
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	user.TOTPRecoveryCodes = strings.Join(hashes, ",")
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.VerifyTOTPResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// TOTPDisableHandler disables TOTP for the user, after verifying a one-time password
+// or a recovery code
+type TOTPDisableHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTOTPDisableHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TOTPDisableHandler {
+	return &TOTPDisableHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *TOTPDisableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.DisableTOTPRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("multi-factor authentication is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	valid, err := verifyMFACode(u.Config(), user, request.Code)
+
+	if errors.Is(err, errMFALocked) {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusTooManyRequests))
+		return
+	} else if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !valid {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid one-time password"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	user.DisableTOTP()
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, user.ToUserType())
+}
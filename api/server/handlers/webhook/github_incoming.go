@@ -2,10 +2,12 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v41/github"
@@ -17,6 +19,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 )
 
 type GithubIncomingWebhookHandler struct {
@@ -83,58 +86,78 @@ func (c *GithubIncomingWebhookHandler) processPullRequestEvent(event *github.Pul
 		return err
 	}
 
-	if env.Mode == "auto" && event.GetAction() == "opened" {
-		_, err := client.Actions.CreateWorkflowDispatchEventByFileName(
-			r.Context(), owner, repo, fmt.Sprintf("porter_%s_env.yml", env.Name),
-			github.CreateWorkflowDispatchEventRequest{
-				Ref: event.PullRequest.GetHead().GetRef(),
-				Inputs: map[string]interface{}{
-					"pr_number":      strconv.FormatUint(uint64(event.PullRequest.GetNumber()), 10),
-					"pr_title":       event.PullRequest.GetTitle(),
-					"pr_branch_from": event.PullRequest.GetHead().GetRef(),
-					"pr_branch_into": event.PullRequest.GetBase().GetRef(),
-				},
-			},
-		)
+	action := event.GetAction()
+
+	// events other than closing the pull request never deploy a pull request that is not open
+	if action != "closed" && event.GetPullRequest().GetState() != "open" {
+		return nil
+	}
+
+	depl, err := c.Repo().Environment().ReadDeploymentByGitDetails(
+		env.ID, owner, repo, uint(event.GetPullRequest().GetNumber()),
+	)
+
+	// pull requests that have not been deployed yet are deployed in auto mode once they
+	// match the trigger rules of the environment. Without any rules, every pull request is
+	// deployed when it is opened.
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		canTrigger := action == "opened" ||
+			((action == "labeled" || action == "synchronize") && env.HasTriggerRules())
+
+		if env.Mode != "auto" || !canTrigger {
+			return nil
+		}
+
+		shouldTrigger, err := shouldTriggerDeployment(r.Context(), client, env, event)
 
 		if err != nil {
 			return err
 		}
-	} else if event.GetAction() == "synchronize" || event.GetAction() == "closed" {
-		depl, err := c.Repo().Environment().ReadDeploymentByGitDetails(
-			env.ID, owner, repo, uint(event.GetPullRequest().GetNumber()),
-		)
+
+		if !shouldTrigger {
+			return nil
+		}
+
+		// the pending deployment is recorded before the workflow is dispatched, so that later
+		// events for the same pull request, such as a label added right after opening it, don't
+		// dispatch the workflow again
+		depl, err = c.createPendingDeployment(r, env, event)
 
 		if err != nil {
 			return err
 		}
 
-		if depl.Status != types.DeploymentStatusInactive {
-			if event.GetAction() == "synchronize" {
-				_, err := client.Actions.CreateWorkflowDispatchEventByFileName(
-					r.Context(), owner, repo, fmt.Sprintf("porter_%s_env.yml", env.Name),
-					github.CreateWorkflowDispatchEventRequest{
-						Ref: event.PullRequest.GetHead().GetRef(),
-						Inputs: map[string]interface{}{
-							"pr_number":      strconv.FormatUint(uint64(event.PullRequest.GetNumber()), 10),
-							"pr_title":       event.PullRequest.GetTitle(),
-							"pr_branch_from": event.PullRequest.GetHead().GetRef(),
-							"pr_branch_into": event.PullRequest.GetBase().GetRef(),
-						},
-					},
-				)
-
-				if err != nil {
-					return err
-				}
-			} else {
-				err = c.deleteDeployment(r, depl, env, client)
-
-				if err != nil {
-					return err
-				}
+		err = dispatchDeploymentWorkflow(r.Context(), client, env, event)
+
+		if err != nil {
+			// mark the deployment as failed so that the next push dispatches the workflow again
+			depl.Status = types.DeploymentStatusFailed
+
+			if _, updateErr := c.Repo().Environment().UpdateDeployment(depl); updateErr != nil {
+				return updateErr
 			}
 		}
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if action == "synchronize" {
+		// deployments deleted by the preview reaper are rebuilt on the next push
+		if depl.Status == types.DeploymentStatusInactive && depl.ExpiredAt != nil {
+			err = c.reenableExpiredDeployment(r, depl, env)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if depl.Status != types.DeploymentStatusInactive {
+			return dispatchDeploymentWorkflow(r.Context(), client, env, event)
+		}
+	} else if action == "closed" && depl.Status != types.DeploymentStatusInactive {
+		return c.deleteDeployment(r, depl, env, client)
 	}
 
 	return nil
@@ -194,6 +217,156 @@ func (c *GithubIncomingWebhookHandler) deleteDeployment(
 	return nil
 }
 
+func (c *GithubIncomingWebhookHandler) createPendingDeployment(
+	r *http.Request,
+	env *models.Environment,
+	event *github.PullRequestEvent,
+) (*models.Deployment, error) {
+	pr := event.GetPullRequest()
+	namespace := env.GetPreviewNamespace(uint(pr.GetNumber()))
+
+	cluster, err := c.Repo().Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	// create the backing namespace before the deployment, so that a failure here doesn't
+	// leave behind a creating deployment which keeps later events from deploying the
+	// pull request
+	_, err = agent.CreateNamespace(namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return c.Repo().Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID:  env.ID,
+		Namespace:      namespace,
+		Status:         types.DeploymentStatusCreating,
+		PullRequestID:  uint(pr.GetNumber()),
+		RepoOwner:      env.GitRepoOwner,
+		RepoName:       env.GitRepoName,
+		PRName:         pr.GetTitle(),
+		PRBranchFrom:   pr.GetHead().GetRef(),
+		PRBranchInto:   pr.GetBase().GetRef(),
+		ActiveSince:    &now,
+		LastActivityAt: &now,
+	})
+}
+
+func (c *GithubIncomingWebhookHandler) reenableExpiredDeployment(
+	r *http.Request,
+	depl *models.Deployment,
+	env *models.Environment,
+) error {
+	cluster, err := c.Repo().Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+
+	if err != nil {
+		return err
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = agent.CreateNamespace(depl.Namespace)
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	depl.Status = types.DeploymentStatusCreating
+	depl.ActiveSince = &now
+	depl.LastActivityAt = &now
+	depl.WarnedExpiry = nil
+	depl.ExpiredAt = nil
+
+	_, err = c.Repo().Environment().UpdateDeployment(depl)
+
+	return err
+}
+
+func dispatchDeploymentWorkflow(
+	ctx context.Context,
+	client *github.Client,
+	env *models.Environment,
+	event *github.PullRequestEvent,
+) error {
+	_, err := client.Actions.CreateWorkflowDispatchEventByFileName(
+		ctx, env.GitRepoOwner, env.GitRepoName, fmt.Sprintf("porter_%s_env.yml", env.Name),
+		github.CreateWorkflowDispatchEventRequest{
+			Ref: event.PullRequest.GetHead().GetRef(),
+			Inputs: map[string]interface{}{
+				"pr_number":      strconv.FormatUint(uint64(event.PullRequest.GetNumber()), 10),
+				"pr_title":       event.PullRequest.GetTitle(),
+				"pr_branch_from": event.PullRequest.GetHead().GetRef(),
+				"pr_branch_into": event.PullRequest.GetBase().GetRef(),
+			},
+		},
+	)
+
+	return err
+}
+
+// shouldTriggerDeployment evaluates the trigger rules of the environment against the
+// labels, base branch and changed files of a pull request
+func shouldTriggerDeployment(
+	ctx context.Context,
+	client *github.Client,
+	env *models.Environment,
+	event *github.PullRequestEvent,
+) (bool, error) {
+	labels := make([]string, 0)
+
+	for _, label := range event.GetPullRequest().Labels {
+		labels = append(labels, label.GetName())
+	}
+
+	var changedFiles []string
+
+	// only list the changed files when they are needed, since this can take several requests
+	if env.HasPathTrigger() {
+		opts := &github.ListOptions{
+			PerPage: 100,
+		}
+
+		for {
+			files, resp, err := client.PullRequests.ListFiles(
+				ctx, env.GitRepoOwner, env.GitRepoName, event.GetPullRequest().GetNumber(), opts,
+			)
+
+			if err != nil {
+				return false, err
+			}
+
+			for _, file := range files {
+				changedFiles = append(changedFiles, file.GetFilename())
+			}
+
+			if resp.NextPage == 0 {
+				break
+			}
+
+			opts.Page = resp.NextPage
+		}
+	}
+
+	return env.ShouldTrigger(labels, event.GetPullRequest().GetBase().GetRef(), changedFiles), nil
+}
+
 func getGithubClientFromEnvironment(config *config.Config, env *models.Environment) (*github.Client, error) {
 	// get the github app client
 	ghAppId, err := strconv.Atoi(config.ServerConf.GithubAppID)
@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	gogitlab "github.com/xanzy/go-gitlab"
+	"gorm.io/gorm"
+)
+
+// GitlabIncomingWebhookHandler receives merge request events for preview environments
+// backed by GitLab. Deployments are created by the preview job in the merge request
+// pipeline, so this handler only tears them down when a merge request is closed.
+type GitlabIncomingWebhookHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGitlabIncomingWebhookHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GitlabIncomingWebhookHandler {
+	return &GitlabIncomingWebhookHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GitlabIncomingWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Gitlab-Token")
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(c.Config().ServerConf.GitlabIncomingWebhookSecret)) != 1 {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("invalid gitlab webhook token")))
+		return
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	event, err := gogitlab.ParseWebhook(gogitlab.HookEventType(r), payload)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	switch event := event.(type) {
+	case *gogitlab.MergeEvent:
+		err = c.processMergeRequestEvent(event, r)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+}
+
+func (c *GitlabIncomingWebhookHandler) processMergeRequestEvent(event *gogitlab.MergeEvent, r *http.Request) error {
+	action := event.ObjectAttributes.Action
+
+	if action != "close" && action != "merge" {
+		return nil
+	}
+
+	// get the webhook id from the request
+	webhookID, reqErr := requestutils.GetURLParamString(r, types.URLParamIncomingWebhookID)
+
+	if reqErr != nil {
+		return fmt.Errorf(reqErr.Error())
+	}
+
+	pathWithNamespace := event.Project.PathWithNamespace
+	idx := strings.LastIndex(pathWithNamespace, "/")
+
+	if idx == -1 {
+		return fmt.Errorf("invalid project path %s", pathWithNamespace)
+	}
+
+	owner, repo := pathWithNamespace[:idx], pathWithNamespace[idx+1:]
+
+	env, err := c.Repo().Environment().ReadEnvironmentByWebhookIDOwnerRepoName(webhookID, owner, repo)
+
+	if err != nil {
+		return err
+	}
+
+	depl, err := c.Repo().Environment().ReadDeploymentByGitDetails(
+		env.ID, owner, repo, uint(event.ObjectAttributes.IID),
+	)
+
+	// the merge request was never deployed
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if depl.Status == types.DeploymentStatusInactive {
+		return nil
+	}
+
+	return c.deleteDeployment(r, depl, env)
+}
+
+func (c *GitlabIncomingWebhookHandler) deleteDeployment(
+	r *http.Request,
+	depl *models.Deployment,
+	env *models.Environment,
+) error {
+	cluster, err := c.Repo().Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+
+	if err != nil {
+		return err
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		return err
+	}
+
+	// make sure we don't delete default or kube-system by checking for prefix, for now
+	if strings.Contains(depl.Namespace, "pr-") {
+		err = agent.DeleteNamespace(depl.Namespace)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := gitlab.GetClientForEnvironment(c.Config(), env)
+
+	if err != nil {
+		return err
+	}
+
+	// stopping the GitLab environment is best-effort, since it is only created once the
+	// preview job has run
+	gitlab.StopPreviewEnvironment(client, env.GitRepoOwner, env.GitRepoName, env.Name, depl.PullRequestID)
+
+	depl.Status = types.DeploymentStatusInactive
+
+	// update the deployment to mark it inactive
+	_, err = c.Repo().Environment().UpdateDeployment(depl)
+
+	return err
+}
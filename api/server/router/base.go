@@ -222,6 +222,30 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// POST /api/login/mfa -> user.NewUserLoginMFAHandler
+	loginMFAEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/login/mfa",
+			},
+		},
+	)
+
+	loginMFAHandler := user.NewUserLoginMFAHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: loginMFAEndpoint,
+		Handler:  loginMFAHandler,
+		Router:   r,
+	})
+
 	// POST /api/cli/login/exchange -> user.NewCLILoginExchangeHandler
 	cliLoginExchangeEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -327,7 +351,8 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/webhooks/deploy/{token}",
 			},
-			Scopes: []types.PermissionScope{},
+			Scopes:  []types.PermissionScope{},
+			Audited: true,
 		},
 	)
 
@@ -491,6 +516,56 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/oauth/login/oidc
+	oidcLoginStartEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/login/oidc",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginStartHandler := user.NewUserOAuthOIDCHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginStartEndpoint,
+		Handler:  oidcLoginStartHandler,
+		Router:   r,
+	})
+
+	// GET /api/oauth/oidc/callback
+	oidcLoginCallbackEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/oidc/callback",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginCallbackHandler := user.NewUserOAuthOIDCCallbackHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginCallbackEndpoint,
+		Handler:  oidcLoginCallbackHandler,
+		Router:   r,
+	})
+
 	// GET /api/internal/credentials
 	getCredentialsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -567,5 +642,32 @@ func GetBaseRoutes(
 		})
 	}
 
+	if config.ServerConf.GitlabIncomingWebhookSecret != "" {
+		// POST /api/gitlab/incoming_webhook/{webhook_id} -> webhook.NewGitlabIncomingWebhookHandler
+		gitlabIncomingWebhookEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: fmt.Sprintf("/gitlab/incoming_webhook/{%s}", types.URLParamIncomingWebhookID),
+				},
+				Scopes: []types.PermissionScope{},
+			},
+		)
+
+		gitlabIncomingWebhookHandler := webhook.NewGitlabIncomingWebhookHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: gitlabIncomingWebhookEndpoint,
+			Handler:  gitlabIncomingWebhookHandler,
+			Router:   r,
+		})
+	}
+
 	return routes
 }
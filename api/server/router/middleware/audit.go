@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditMiddleware records an audit event for every mutating request made within
+// a project. It must be attached before the authentication and policy middleware, so
+// that requests which are rejected with a 401 or 403 are recorded as well. The request
+// scopes are resolved from the URL parameters, and the caller is filled in by
+// RecordCaller once the request has been authenticated.
+type AuditMiddleware struct {
+	config       *config.Config
+	endpointMeta types.APIRequestMetadata
+}
+
+func NewAuditMiddleware(config *config.Config, endpointMeta types.APIRequestMetadata) *AuditMiddleware {
+	return &AuditMiddleware{config, endpointMeta}
+}
+
+// ShouldAudit returns true if requests to an endpoint should be recorded in the
+// project audit log: project-scoped endpoints with a write verb, and endpoints
+// which attribute themselves to a project through SetAuditRelease, are recorded.
+func ShouldAudit(endpointMeta types.APIRequestMetadata) bool {
+	if endpointMeta.Audited {
+		return true
+	}
+
+	switch endpointMeta.Verb {
+	case types.APIVerbCreate, types.APIVerbUpdate, types.APIVerbDelete:
+	default:
+		return false
+	}
+
+	for _, scope := range endpointMeta.Scopes {
+		if scope == types.ProjectScope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type auditRecordCtxKey struct{}
+
+// auditRecord collects what is learned about a request by the middleware and handlers
+// which run after the audit middleware, since their context values are not visible to it
+type auditRecord struct {
+	userID      uint
+	apiTokenUID string
+	scopes      map[types.PermissionScope]*types.RequestAction
+}
+
+// RecordCaller attaches the authenticated user or API token to the audit event of the
+// request. It must be attached right after the authentication middleware.
+func (mw *AuditMiddleware) RecordCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rec, ok := r.Context().Value(auditRecordCtxKey{}).(*auditRecord); ok {
+			// API tokens are not backed by a real user, so the token uid is recorded instead
+			if apiToken, ok := r.Context().Value("api_token").(*models.APIToken); ok && apiToken != nil {
+				rec.apiTokenUID = apiToken.UniqueID
+			} else if user, ok := r.Context().Value(types.UserScope).(*models.User); ok && user != nil {
+				rec.userID = user.ID
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SetAuditRelease attributes the audit event of a request that is not scoped by its URL,
+// such as the deploy webhook, to a release
+func SetAuditRelease(r *http.Request, release *models.Release) {
+	rec, ok := r.Context().Value(auditRecordCtxKey{}).(*auditRecord)
+
+	if !ok {
+		return
+	}
+
+	rec.scopes = map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Resource: types.NameOrUInt{UInt: release.ProjectID},
+		},
+		types.ClusterScope: {
+			Resource: types.NameOrUInt{UInt: release.ClusterID},
+		},
+		types.NamespaceScope: {
+			Resource: types.NameOrUInt{Name: release.Namespace},
+		},
+		types.ReleaseScope: {
+			Resource: types.NameOrUInt{Name: release.Name},
+		},
+	}
+}
+
+func (mw *AuditMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := newRequestLoggerResponseWriter(w)
+		rec := &auditRecord{}
+
+		// malformed URL parameters are rejected by the policy middleware, and there is
+		// no project to attribute such requests to
+		if reqScopes, reqErr := authz.GetRequestActionForEndpoint(r, mw.endpointMeta); reqErr == nil {
+			rec.scopes = reqScopes
+		}
+
+		next.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), auditRecordCtxKey{}, rec)))
+
+		event := &models.AuditEvent{
+			Verb:        mw.endpointMeta.Verb,
+			Method:      mw.endpointMeta.Method,
+			Endpoint:    mw.endpointMeta.Path.RelativePath,
+			Path:        r.URL.Path,
+			StatusCode:  rw.statusCode,
+			Outcome:     types.AuditEventOutcomeSuccess,
+			UserID:      rec.userID,
+			APITokenUID: rec.apiTokenUID,
+		}
+
+		if rw.statusCode >= http.StatusBadRequest {
+			event.Outcome = types.AuditEventOutcomeFailure
+		}
+
+		scopes := make(map[string]string)
+
+		for scope, action := range rec.scopes {
+			if action.Resource.Name != "" {
+				scopes[string(scope)] = action.Resource.Name
+			} else if action.Resource.UInt != 0 {
+				scopes[string(scope)] = fmt.Sprintf("%d", action.Resource.UInt)
+			}
+
+			switch scope {
+			case types.ProjectScope:
+				event.ProjectID = action.Resource.UInt
+			case types.ClusterScope:
+				event.ClusterID = action.Resource.UInt
+			case types.NamespaceScope:
+				event.Namespace = action.Resource.Name
+			case types.ReleaseScope:
+				event.ReleaseName = action.Resource.Name
+			}
+		}
+
+		if event.ProjectID == 0 {
+			return
+		}
+
+		// unauthenticated requests can name any project id, so they are only recorded
+		// against projects which exist
+		if event.UserID == 0 && event.APITokenUID == "" {
+			if _, err := mw.config.Repo.Project().ReadProject(event.ProjectID); err != nil {
+				return
+			}
+		}
+
+		scopesBytes, err := json.Marshal(scopes)
+
+		if err != nil {
+			apierrors.HandleAPIError(mw.config.Logger, mw.config.Alerter, w, r, apierrors.NewErrInternal(err), false)
+			return
+		}
+
+		event.ScopesBytes = scopesBytes
+
+		// the response has already been written, so failing to record the event is
+		// only reported, not returned to the client
+		if _, err := mw.config.Repo.AuditEvent().CreateAuditEvent(event); err != nil {
+			apierrors.HandleAPIError(mw.config.Logger, mw.config.Alerter, w, r, apierrors.NewErrInternal(err), false)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/handlers/environment"
 	project_integration "github.com/porter-dev/porter/api/server/handlers/project_integration"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -569,5 +570,387 @@ func getProjectIntegrationRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/integrations/webhook -> project_integration.NewListWebhookHandler
+	listWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/webhook",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listWebhookHandler := project_integration.NewListWebhookHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listWebhookEndpoint,
+		Handler:  listWebhookHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/integrations/webhook -> project_integration.NewCreateWebhookHandler
+	createWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/webhook",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createWebhookHandler := project_integration.NewCreateWebhookHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createWebhookEndpoint,
+		Handler:  createWebhookHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/integrations/webhook/{integration_id} -> project_integration.NewDeleteWebhookHandler
+	deleteWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/webhook/{%s}", relPath, types.URLParamIntegrationID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteWebhookHandler := project_integration.NewDeleteWebhookHandler(config)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteWebhookEndpoint,
+		Handler:  deleteWebhookHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/integrations/vault -> project_integration.NewListVaultHandler
+	listVaultEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/vault",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listVaultHandler := project_integration.NewListVaultHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listVaultEndpoint,
+		Handler:  listVaultHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/integrations/vault -> project_integration.NewCreateVaultHandler
+	createVaultEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/vault",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createVaultHandler := project_integration.NewCreateVaultHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createVaultEndpoint,
+		Handler:  createVaultHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/integrations/vault/{integration_id} -> project_integration.NewDeleteVaultHandler
+	deleteVaultEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/vault/{%s}", relPath, types.URLParamIntegrationID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteVaultHandler := project_integration.NewDeleteVaultHandler(config)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteVaultEndpoint,
+		Handler:  deleteVaultHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/integrations/teams -> project_integration.NewListTeamsHandler
+	listTeamsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/teams",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listTeamsHandler := project_integration.NewListTeamsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listTeamsEndpoint,
+		Handler:  listTeamsHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/integrations/teams -> project_integration.NewCreateTeamsHandler
+	createTeamsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/teams",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createTeamsHandler := project_integration.NewCreateTeamsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createTeamsEndpoint,
+		Handler:  createTeamsHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/integrations/teams/{integration_id} -> project_integration.NewDeleteTeamsHandler
+	deleteTeamsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/teams/{%s}", relPath, types.URLParamIntegrationID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteTeamsHandler := project_integration.NewDeleteTeamsHandler(config)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteTeamsEndpoint,
+		Handler:  deleteTeamsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/integrations/discord -> project_integration.NewListDiscordHandler
+	listDiscordEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/discord",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listDiscordHandler := project_integration.NewListDiscordHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listDiscordEndpoint,
+		Handler:  listDiscordHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/integrations/discord -> project_integration.NewCreateDiscordHandler
+	createDiscordEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/discord",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createDiscordHandler := project_integration.NewCreateDiscordHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createDiscordEndpoint,
+		Handler:  createDiscordHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/integrations/discord/{integration_id} -> project_integration.NewDeleteDiscordHandler
+	deleteDiscordEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/discord/{%s}", relPath, types.URLParamIntegrationID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteDiscordHandler := project_integration.NewDeleteDiscordHandler(config)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteDiscordEndpoint,
+		Handler:  deleteDiscordHandler,
+		Router:   r,
+	})
+
+	if config.ServerConf.GitlabIncomingWebhookSecret != "" {
+		// POST /api/projects/{project_id}/integrations/gitlab/{integration_id}/repos/{owner}/{name}/clusters/{cluster_id}/environment ->
+		// environment.NewCreateGitlabEnvironmentHandler
+		createGitlabEnvironmentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent: basePath,
+					RelativePath: fmt.Sprintf("%s/gitlab/{%s}/repos/{%s}/{%s}/clusters/{cluster_id}/environment", relPath,
+						types.URLParamIntegrationID, types.URLParamGitRepoOwner, types.URLParamGitRepoName),
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.GitlabIntegrationScope,
+					types.ClusterScope,
+				},
+			},
+		)
+
+		createGitlabEnvironmentHandler := environment.NewCreateGitlabEnvironmentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: createGitlabEnvironmentEndpoint,
+			Handler:  createGitlabEnvironmentHandler,
+			Router:   r,
+		})
+
+		// DELETE /api/projects/{project_id}/integrations/gitlab/{integration_id}/repos/{owner}/{name}/clusters/{cluster_id}/environment ->
+		// environment.NewDeleteGitlabEnvironmentHandler
+		deleteGitlabEnvironmentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbDelete,
+				Method: types.HTTPVerbDelete,
+				Path: &types.Path{
+					Parent: basePath,
+					RelativePath: fmt.Sprintf("%s/gitlab/{%s}/repos/{%s}/{%s}/clusters/{cluster_id}/environment", relPath,
+						types.URLParamIntegrationID, types.URLParamGitRepoOwner, types.URLParamGitRepoName),
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.GitlabIntegrationScope,
+					types.ClusterScope,
+				},
+			},
+		)
+
+		deleteGitlabEnvironmentHandler := environment.NewDeleteGitlabEnvironmentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: deleteGitlabEnvironmentEndpoint,
+			Handler:  deleteGitlabEnvironmentHandler,
+			Router:   r,
+		})
+	}
+
 	return routes, newPath
 }
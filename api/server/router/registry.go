@@ -229,5 +229,65 @@ func getRegistryRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/registries/{registry_id}/image_retention ->
+	// registry.NewRegistryUpdateImageRetentionHandler
+	updateImageRetentionEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/image_retention",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	updateImageRetentionHandler := registry.NewRegistryUpdateImageRetentionHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateImageRetentionEndpoint,
+		Handler:  updateImageRetentionHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/registries/{registry_id}/image_retention/preview ->
+	// registry.NewRegistryGetImageRetentionPreviewHandler
+	getImageRetentionPreviewEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/image_retention/preview",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	getImageRetentionPreviewHandler := registry.NewRegistryGetImageRetentionPreviewHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getImageRetentionPreviewEndpoint,
+		Handler:  getImageRetentionPreviewHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
@@ -223,6 +223,16 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 	for _, route := range routes {
 		atomicGroup := route.Router.Group(nil)
 
+		// record mutating requests in the project audit log, including the ones which are
+		// rejected by the authentication or policy middleware
+		var auditMw *middleware.AuditMiddleware
+
+		if middleware.ShouldAudit(*route.Endpoint.Metadata) {
+			auditMw = middleware.NewAuditMiddleware(config, *route.Endpoint.Metadata)
+
+			atomicGroup.Use(auditMw.Middleware)
+		}
+
 		for _, scope := range route.Endpoint.Metadata.Scopes {
 			switch scope {
 			case types.UserScope:
@@ -232,6 +242,10 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 				} else {
 					atomicGroup.Use(authNFactory.NewAuthenticated)
 				}
+
+				if auditMw != nil {
+					atomicGroup.Use(auditMw.RecordCaller)
+				}
 			case types.ProjectScope:
 				policyFactory := authz.NewPolicyMiddleware(config, *route.Endpoint.Metadata, policyDocLoader)
 
@@ -169,6 +169,80 @@ func getUserRoutes(
 		Router:   r,
 	})
 
+	// POST /api/users/current/mfa/totp -> user.NewTOTPEnrollHandler
+	enrollTOTPEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/mfa/totp",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	enrollTOTPHandler := user.NewTOTPEnrollHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: enrollTOTPEndpoint,
+		Handler:  enrollTOTPHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/mfa/totp/verify -> user.NewTOTPVerifyHandler
+	verifyTOTPEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/mfa/totp/verify",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	verifyTOTPHandler := user.NewTOTPVerifyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: verifyTOTPEndpoint,
+		Handler:  verifyTOTPHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/users/current/mfa/totp -> user.NewTOTPDisableHandler
+	disableTOTPEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/mfa/totp",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	disableTOTPHandler := user.NewTOTPDisableHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: disableTOTPEndpoint,
+		Handler:  disableTOTPHandler,
+		Router:   r,
+	})
+
 	// POST /api/projects -> project.NewProjectCreateHandler
 	createEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -66,6 +66,9 @@ type Config struct {
 	// GoogleConf is the configuration for a Google OAuth client
 	GoogleConf *oauth2.Config
 
+	// OIDCConf is the configuration for a generic OIDC login provider
+	OIDCConf *oauth.OIDCConf
+
 	// SlackConf is the configuration for a Slack OAuth client
 	SlackConf *oauth2.Config
 
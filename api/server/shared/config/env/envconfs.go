@@ -40,6 +40,28 @@ type ServerConf struct {
 
 	GithubIncomingWebhookSecret string `env:"GITHUB_INCOMING_WEBHOOK_SECRET"`
 
+	// GitlabIncomingWebhookSecret is the token that GitLab sends with merge request
+	// webhooks for preview environments
+	GitlabIncomingWebhookSecret string `env:"GITLAB_INCOMING_WEBHOOK_SECRET"`
+
+	// PreviewReaperInterval is how often preview deployments are checked against the TTL
+	// and idle limits of their environment, and PreviewExpiryWarning is how long before
+	// deletion a warning is posted on the pull request
+	PreviewReaperInterval time.Duration `env:"PREVIEW_REAPER_INTERVAL,default=15m"`
+	PreviewExpiryWarning  time.Duration `env:"PREVIEW_EXPIRY_WARNING,default=24h"`
+
+	// EnvGroupSourceSyncInterval is how often env groups are synced with their sources in
+	// external secret managers. A value of 0 disables the scheduled sync.
+	EnvGroupSourceSyncInterval time.Duration `env:"ENV_GROUP_SOURCE_SYNC_INTERVAL,default=15m"`
+
+	// EnvGroupRetentionInterval is how often env group versions are deleted in clusters with
+	// a retention policy. A value of 0 disables the scheduled cleanup.
+	EnvGroupRetentionInterval time.Duration `env:"ENV_GROUP_RETENTION_INTERVAL,default=1h"`
+
+	// ImageRetentionInterval is how often images are deleted from registries with image
+	// retention rules. A value of 0 disables the scheduled cleanup.
+	ImageRetentionInterval time.Duration `env:"IMAGE_RETENTION_INTERVAL,default=24h"`
+
 	GithubAppClientID      string `env:"GITHUB_APP_CLIENT_ID"`
 	GithubAppClientSecret  string `env:"GITHUB_APP_CLIENT_SECRET"`
 	GithubAppName          string `env:"GITHUB_APP_NAME"`
@@ -51,6 +73,16 @@ type ServerConf struct {
 	GoogleClientSecret     string `env:"GOOGLE_CLIENT_SECRET"`
 	GoogleRestrictedDomain string `env:"GOOGLE_RESTRICTED_DOMAIN"`
 
+	OIDCIssuerURL      string   `env:"OIDC_ISSUER_URL"`
+	OIDCClientID       string   `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret   string   `env:"OIDC_CLIENT_SECRET"`
+	OIDCAllowedDomains []string `env:"OIDC_ALLOWED_DOMAINS"`
+	OIDCGroupsClaim    string   `env:"OIDC_GROUPS_CLAIM,default=groups"`
+
+	// OIDCGroupRoleMappings maps groups at the OIDC provider to project roles, in the
+	// form <group>:<project_id>:<role>
+	OIDCGroupRoleMappings []string `env:"OIDC_GROUP_ROLE_MAPPINGS"`
+
 	SendgridAPIKey                  string `env:"SENDGRID_API_KEY"`
 	SendgridPWResetTemplateID       string `env:"SENDGRID_PW_RESET_TEMPLATE_ID"`
 	SendgridPWGHTemplateID          string `env:"SENDGRID_PW_GH_TEMPLATE_ID"`
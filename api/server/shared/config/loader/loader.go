@@ -144,6 +144,29 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		})
 	}
 
+	if res.Metadata.OIDCLogin {
+		groupRoles, err := oauth.ParseOIDCGroupRoles(sc.OIDCGroupRoleMappings)
+
+		if err != nil {
+			return nil, err
+		}
+
+		scopes := []string{"openid", "profile", "email"}
+
+		if len(sc.OIDCGroupRoleMappings) > 0 && sc.OIDCGroupsClaim == "groups" {
+			scopes = append(scopes, "groups")
+		}
+
+		res.OIDCConf = oauth.NewOIDCClient(&oauth.Config{
+			ClientID:     sc.OIDCClientID,
+			ClientSecret: sc.OIDCClientSecret,
+			Scopes:       scopes,
+			BaseURL:      sc.ServerURL,
+		}, sc.OIDCIssuerURL)
+
+		res.OIDCConf.GroupRoles = groupRoles
+	}
+
 	if sc.GithubClientID != "" && sc.GithubClientSecret != "" {
 		res.GithubConf = oauth.NewGithubClient(&oauth.Config{
 			ClientID:     sc.GithubClientID,
@@ -10,6 +10,7 @@ type Metadata struct {
 	BasicLogin         bool   `json:"basic_login"`
 	GithubLogin        bool   `json:"github_login"`
 	GoogleLogin        bool   `json:"google_login"`
+	OIDCLogin          bool   `json:"oidc_login"`
 	SlackNotifications bool   `json:"slack_notifications"`
 	Email              bool   `json:"email"`
 	Analytics          bool   `json:"analytics"`
@@ -24,6 +25,7 @@ func MetadataFromConf(sc *env.ServerConf, version string) *Metadata {
 		GithubLogin:        sc.GithubClientID != "" && sc.GithubClientSecret != "" && sc.GithubLoginEnabled,
 		BasicLogin:         sc.BasicLoginEnabled,
 		GoogleLogin:        sc.GoogleClientID != "" && sc.GoogleClientSecret != "",
+		OIDCLogin:          hasOIDCVars(sc),
 		SlackNotifications: sc.SlackClientID != "" && sc.SlackClientSecret != "",
 		Email:              sc.SendgridAPIKey != "",
 		Analytics:          sc.SegmentClientKey != "",
@@ -32,6 +34,10 @@ func MetadataFromConf(sc *env.ServerConf, version string) *Metadata {
 	}
 }
 
+func hasOIDCVars(sc *env.ServerConf) bool {
+	return sc.OIDCIssuerURL != "" && sc.OIDCClientID != "" && sc.OIDCClientSecret != ""
+}
+
 func hasGithubAppVars(sc *env.ServerConf) bool {
 	return sc.GithubAppClientID != "" &&
 		sc.GithubAppClientSecret != "" &&
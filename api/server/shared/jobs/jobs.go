@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+)
+
+// holder identifies this server when acquiring job locks
+var holder = newHolder()
+
+func newHolder() string {
+	hostname, err := os.Hostname()
+
+	if err != nil {
+		hostname = "porter"
+	}
+
+	suffix := make([]byte, 8)
+
+	if _, err := rand.Read(suffix); err != nil {
+		return hostname
+	}
+
+	return fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(suffix))
+}
+
+// Start runs fn every interval in the background. When several Porter servers share a
+// database, fn only runs on the server that holds the job's lock: the holder renews the
+// lock on every tick and while fn runs, and another server takes over once the lock has
+// not been renewed for two intervals.
+func Start(config *config.Config, name string, interval time.Duration, fn func()) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			run(config, name, interval, fn)
+		}
+	}()
+}
+
+// run calls fn if this server acquires the job's lock, and keeps renewing the lock until
+// fn returns, so that another server does not start the job while a slow run is still
+// in progress
+func run(config *config.Config, name string, interval time.Duration, fn func()) {
+	acquired, err := config.Repo.JobLock().AcquireJobLock(name, holder, 2*interval)
+
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error acquiring lock for job %s", name)
+		return
+	}
+
+	if !acquired {
+		return
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				renewed, err := config.Repo.JobLock().AcquireJobLock(name, holder, 2*interval)
+
+				if err != nil {
+					config.Logger.Error().Err(err).Msgf("error renewing lock for job %s", name)
+				} else if !renewed {
+					config.Logger.Warn().Msgf("lock for job %s was taken over by another server", name)
+				}
+			}
+		}
+	}()
+
+	fn()
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+)
+
+func TestRunRenewsLockWhileRunning(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	interval := 20 * time.Millisecond
+
+	var takenOver bool
+
+	run(config, "test-job", interval, func() {
+		// wait for longer than the lease, and check that another server can't take the
+		// lock while the job is still running
+		time.Sleep(5 * interval)
+
+		acquired, err := config.Repo.JobLock().AcquireJobLock("test-job", "other-server", 2*interval)
+
+		if err != nil {
+			t.Error(err)
+		}
+
+		takenOver = acquired
+	})
+
+	if takenOver {
+		t.Fatalf("expected the lock to be held while the job runs")
+	}
+
+	// once the job has returned, the lock expires after two intervals
+	time.Sleep(3 * interval)
+
+	acquired, err := config.Repo.JobLock().AcquireJobLock("test-job", "other-server", 2*interval)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !acquired {
+		t.Fatalf("expected the lock to expire after the job returned")
+	}
+}
+
+func TestRunSkipsLockedJob(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	interval := 20 * time.Millisecond
+
+	if _, err := config.Repo.JobLock().AcquireJobLock("test-job", "other-server", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	var ran bool
+
+	run(config, "test-job", interval, func() {
+		ran = true
+	})
+
+	if ran {
+		t.Fatalf("expected the job not to run while another server holds the lock")
+	}
+}
@@ -0,0 +1,61 @@
+package types
+
+import "time"
+
+type AuditEventOutcome string
+
+const (
+	AuditEventOutcomeSuccess AuditEventOutcome = "success"
+	AuditEventOutcomeFailure AuditEventOutcome = "failure"
+)
+
+// AuditEvent is a record of a mutating API call made within a project
+type AuditEvent struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+
+	// The actor of the request: either a user id or the uid of an API token
+	UserID      uint   `json:"user_id,omitempty"`
+	APITokenUID string `json:"api_token_uid,omitempty"`
+
+	// The scopes resolved by the authorization middleware, such as the cluster id,
+	// namespace or release name
+	ClusterID   uint              `json:"cluster_id,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	ReleaseName string            `json:"release_name,omitempty"`
+	Scopes      map[string]string `json:"scopes"`
+
+	Verb       APIVerb           `json:"verb"`
+	Method     HTTPVerb          `json:"method"`
+	Endpoint   string            `json:"endpoint"`
+	Path       string            `json:"path"`
+	StatusCode int               `json:"status_code"`
+	Outcome    AuditEventOutcome `json:"outcome"`
+}
+
+type ListAuditEventsRequest struct {
+	Limit int `schema:"limit"`
+	Skip  int `schema:"skip"`
+
+	UserID      uint              `schema:"user_id"`
+	APITokenUID string            `schema:"api_token_uid"`
+	ClusterID   uint              `schema:"cluster_id"`
+	Namespace   string            `schema:"namespace"`
+	ReleaseName string            `schema:"release_name"`
+	Verb        APIVerb           `schema:"verb"`
+	Outcome     AuditEventOutcome `schema:"outcome"`
+
+	// (optional) only return events created after/before the given unix timestamps,
+	// in seconds
+	Since int64 `schema:"since"`
+	Until int64 `schema:"until"`
+}
+
+type ListAuditEventsResponse struct {
+	Count int64 `json:"count"`
+	Limit int   `json:"limit"`
+	Skip  int   `json:"skip"`
+
+	AuditEvents []*AuditEvent `json:"audit_events"`
+}
@@ -30,6 +30,10 @@ type Cluster struct {
 
 	// (optional) The aws integration id, if available
 	AWSIntegrationID uint `json:"aws_integration_id"`
+
+	// The number of versions of each env group kept in the cluster, where 0 uses the
+	// retention of the project
+	EnvGroupVersionRetention uint `json:"env_group_version_retention"`
 }
 
 type ClusterCandidate struct {
@@ -259,6 +263,53 @@ type GetIncidentEventLogsRequest struct {
 	LogID string `schema:"log_id"`
 }
 
+type SearchLogsRequest struct {
+	ReleaseName string `schema:"release_name" form:"required"`
+	Namespace   string `schema:"namespace"`
+
+	// (optional) only return lines containing the search string, ignoring case
+	Search string `schema:"search"`
+
+	// (optional) only return logs captured after/before the given unix timestamps,
+	// in seconds
+	Since int64 `schema:"since"`
+	Until int64 `schema:"until"`
+
+	// (optional) continue after the last line of the previous page, using the cursor
+	// returned with that page
+	AfterTimestamp int64  `schema:"after_timestamp"`
+	AfterLogID     string `schema:"after_log_id"`
+	AfterLine      int    `schema:"after_line"`
+
+	// (optional) the maximum number of lines to return, which is capped at 1000
+	Limit int `schema:"limit"`
+}
+
+// SearchLogsCursor points to the last line of a page of log search results
+type SearchLogsCursor struct {
+	AfterTimestamp int64  `json:"after_timestamp"`
+	AfterLogID     string `json:"after_log_id"`
+	AfterLine      int    `json:"after_line"`
+}
+
+type LogLine struct {
+	// Timestamp is the unix timestamp, in seconds, of the event that the logs were
+	// captured for
+	Timestamp     int64  `json:"timestamp"`
+	PodName       string `json:"pod_name"`
+	ContainerName string `json:"container_name"`
+	Line          string `json:"line"`
+}
+
+type SearchLogsResponse struct {
+	Lines []*LogLine `json:"lines"`
+
+	// HasMore is true if there are more lines after this page, which are returned by
+	// passing Next as the cursor of the next request
+	HasMore bool              `json:"has_more"`
+	Next    *SearchLogsCursor `json:"next,omitempty"`
+}
+
 type IncidentNotifyRequest struct {
 	IncidentID string `json:"incident_id" form:"required"`
 }
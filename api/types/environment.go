@@ -3,22 +3,52 @@ package types
 import "time"
 
 type Environment struct {
-	ID                uint   `json:"id"`
-	ProjectID         uint   `json:"project_id"`
-	ClusterID         uint   `json:"cluster_id"`
-	GitInstallationID uint   `json:"git_installation_id"`
-	GitRepoOwner      string `json:"git_repo_owner"`
-	GitRepoName       string `json:"git_repo_name"`
+	ID                  uint   `json:"id"`
+	ProjectID           uint   `json:"project_id"`
+	ClusterID           uint   `json:"cluster_id"`
+	GitInstallationID   uint   `json:"git_installation_id"`
+	GitlabIntegrationID uint   `json:"gitlab_integration_id"`
+	GitRepoOwner        string `json:"git_repo_owner"`
+	GitRepoName         string `json:"git_repo_name"`
 
 	Name                 string `json:"name"`
 	Mode                 string `json:"mode"`
+	TTLHours             uint   `json:"ttl_hours"`
+	IdleTTLHours         uint   `json:"idle_ttl_hours"`
 	DeploymentCount      uint   `json:"deployment_count"`
 	LastDeploymentStatus string `json:"last_deployment_status"`
+
+	PreviewTriggerRules
+}
+
+// PreviewTriggerRules restrict which pull requests are deployed automatically. A pull
+// request has to have one of TriggerLabels, change a file matching one of the TriggerPaths
+// globs, and target a branch matching one of TriggerBaseBranches. Empty rules always match.
+type PreviewTriggerRules struct {
+	TriggerLabels       []string `json:"trigger_labels"`
+	TriggerPaths        []string `json:"trigger_paths"`
+	TriggerBaseBranches []string `json:"trigger_base_branches"`
 }
 
 type CreateEnvironmentRequest struct {
 	Name string `json:"name" form:"required"`
 	Mode string `json:"mode" form:"oneof=auto manual" default:"manual"`
+
+	// TTLHours and IdleTTLHours limit how long preview deployments live in total and
+	// without a new push, respectively. 0 disables the limit.
+	TTLHours     uint `json:"ttl_hours"`
+	IdleTTLHours uint `json:"idle_ttl_hours"`
+
+	PreviewTriggerRules
+}
+
+// UpdateEnvironmentSettingsRequest updates the settings of an environment. Trigger rules
+// that are omitted are left unchanged, and an empty list removes the rule.
+type UpdateEnvironmentSettingsRequest struct {
+	TTLHours     uint `json:"ttl_hours"`
+	IdleTTLHours uint `json:"idle_ttl_hours"`
+
+	PreviewTriggerRules
 }
 
 type GitHubMetadata struct {
@@ -55,6 +85,7 @@ type Deployment struct {
 	PullRequestID      uint             `json:"pull_request_id"`
 	InstallationID     uint             `json:"gh_installation_id"`
 	LastWorkflowRunURL string           `json:"last_workflow_run_url"`
+	ExpiredAt          *time.Time       `json:"expired_at,omitempty"`
 }
 
 type CreateGHDeploymentRequest struct {
@@ -94,6 +125,21 @@ type UpdateDeploymentStatusRequest struct {
 	Namespace    string `json:"namespace" form:"required"`
 }
 
+type CreateGitlabDeploymentRequest struct {
+	Namespace         string `json:"namespace" form:"required"`
+	MergeRequestIID   uint   `json:"merge_request_iid" form:"required"`
+	MergeRequestTitle string `json:"merge_request_title"`
+	BranchFrom        string `json:"branch_from" form:"required"`
+	BranchInto        string `json:"branch_into" form:"required"`
+	CommitSHA         string `json:"commit_sha" form:"required"`
+}
+
+type UpdateGitlabDeploymentStatusRequest struct {
+	Namespace string `json:"namespace" form:"required"`
+	Status    string `json:"status" form:"required,oneof=creating failed"`
+	CommitSHA string `json:"commit_sha"`
+}
+
 type DeleteDeploymentRequest struct {
 	Namespace string `json:"namespace" form:"required"`
 }
@@ -10,6 +10,9 @@ type HelmRepo struct {
 	Name string `json:"name"`
 
 	RepoURL string `json:"repo_name"`
+
+	// The registry used to authenticate with an OCI helm repo
+	RegistryID uint `json:"registry_id,omitempty"`
 }
 
 type GetHelmRepoResponse HelmRepo
@@ -18,4 +21,9 @@ type CreateHelmRepoRequest struct {
 	URL                string `json:"url"`
 	Name               string `json:"name" form:"required"`
 	BasicIntegrationID uint   `json:"basic_integration_id"`
+
+	// RegistryID is the id of a registry in the project, which is used to
+	// authenticate with an OCI helm repo. It can only be set if the url is
+	// prefixed with oci://
+	RegistryID uint `json:"registry_id"`
 }
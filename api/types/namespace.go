@@ -95,6 +95,10 @@ type EnvGroup struct {
 	Namespace    string            `json:"namespace"`
 	Applications []string          `json:"applications"`
 	Variables    map[string]string `json:"variables"`
+
+	// SourceVersions maps the variables that were resolved from an external secret manager
+	// to the external version that the value was read from
+	SourceVersions map[string]string `json:"source_versions,omitempty"`
 }
 
 type EnvGroupMeta struct {
@@ -121,6 +125,133 @@ type GetEnvGroupAllRequest struct {
 	Name string `schema:"name,required"`
 }
 
+type GetEnvGroupDiffRequest struct {
+	Name string `schema:"name,required"`
+	From uint   `schema:"from,required"`
+
+	// To is the version to compare against, which defaults to the latest version
+	To uint `schema:"to"`
+}
+
+// EnvGroupVariableChange is a variable that was added, removed or changed between two versions
+// of an env group. The values of secret variables are masked.
+type EnvGroupVariableChange struct {
+	Key      string `json:"key"`
+	Secret   bool   `json:"secret"`
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+type GetEnvGroupDiffResponse struct {
+	Name        string                    `json:"name"`
+	Namespace   string                    `json:"namespace"`
+	FromVersion uint                      `json:"from_version"`
+	ToVersion   uint                      `json:"to_version"`
+	Added       []*EnvGroupVariableChange `json:"added"`
+	Removed     []*EnvGroupVariableChange `json:"removed"`
+	Changed     []*EnvGroupVariableChange `json:"changed"`
+}
+
+type RollbackEnvGroupRequest struct {
+	Name    string `json:"name" form:"required"`
+	Version uint   `json:"version" form:"required"`
+}
+
+type EnvGroupSourceKind string
+
+const (
+	EnvGroupSourceKindAWSSecretsManager EnvGroupSourceKind = "aws_secrets_manager"
+	EnvGroupSourceKindGCPSecretManager  EnvGroupSourceKind = "gcp_secret_manager"
+	EnvGroupSourceKindVault             EnvGroupSourceKind = "vault"
+)
+
+// EnvGroupSourceInput references a secret in an external secret manager that is synced
+// into a secret variable of an env group
+type EnvGroupSourceInput struct {
+	// Key is the name of the env group variable that the secret is synced into
+	Key string `json:"key" form:"required"`
+
+	Kind EnvGroupSourceKind `json:"kind" form:"required,oneof=aws_secrets_manager gcp_secret_manager vault"`
+
+	// IntegrationID is the id of the AWS, GCP or Vault integration used to read the secret
+	IntegrationID uint `json:"integration_id" form:"required"`
+
+	// Path is the name or ARN of an AWS secret, the resource name of a GCP secret such as
+	// projects/my-project/secrets/my-secret, or the path of a Vault KV secret relative to
+	// the address of the Vault integration, such as secret/data/my-secret
+	Path string `json:"path" form:"required"`
+
+	// Field is the key to read from a secret that stores a JSON object. It is required for
+	// Vault secrets.
+	Field string `json:"field,omitempty"`
+}
+
+type EnvGroupSource struct {
+	*EnvGroupSourceInput
+
+	ID uint `json:"id"`
+
+	// ExternalVersion is the version of the external secret that was last synced
+	ExternalVersion string     `json:"external_version,omitempty"`
+	LastSyncedAt    *time.Time `json:"last_synced_at,omitempty"`
+	LastSyncError   string     `json:"last_sync_error,omitempty"`
+}
+
+type ListEnvGroupSourcesRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type ListEnvGroupSourcesResponse []*EnvGroupSource
+
+// UpdateEnvGroupSourcesRequest replaces the sources of an env group and syncs them
+type UpdateEnvGroupSourcesRequest struct {
+	Name    string                 `json:"name" form:"required"`
+	Sources []*EnvGroupSourceInput `json:"sources" form:"dive"`
+}
+
+type SyncEnvGroupRequest struct {
+	Name string `json:"name" form:"required"`
+}
+
+type SyncEnvGroupResponse struct {
+	EnvGroup *EnvGroup         `json:"env_group"`
+	Sources  []*EnvGroupSource `json:"sources"`
+
+	// Updated is true if the sync created a new version of the env group
+	Updated bool `json:"updated"`
+}
+
+// EnvGroupVersionRetention lists the versions of an env group that a retention policy keeps
+// and deletes
+type EnvGroupVersionRetention struct {
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	LatestVersion uint   `json:"latest_version"`
+
+	// KeptVersions are the last versions kept by the policy, including the latest version
+	KeptVersions []uint `json:"kept_versions"`
+
+	// ReferencedVersions are older versions that are kept because a deployed release, or a
+	// revision that it may be rolled back to, uses them
+	ReferencedVersions []uint `json:"referenced_versions"`
+
+	DeletedVersions []uint `json:"deleted_versions"`
+}
+
+type GetEnvGroupRetentionReportRequest struct {
+	// KeepLast previews a policy that keeps a different number of versions than the policy of
+	// the cluster
+	KeepLast uint `schema:"keep_last"`
+}
+
+// EnvGroupRetentionReport lists the env group versions that the retention policy of a cluster
+// would delete, without deleting them
+type EnvGroupRetentionReport struct {
+	// KeepLast is the number of versions kept for each env group, where 0 keeps every version
+	KeepLast  uint                        `json:"keep_last"`
+	EnvGroups []*EnvGroupVersionRetention `json:"env_groups"`
+}
+
 type DeleteEnvGroupRequest struct {
 	Name string `json:"name,required"`
 }
@@ -136,6 +267,10 @@ type CreateEnvGroupRequest struct {
 	Name            string            `json:"name,required"`
 	Variables       map[string]string `json:"variables,required"`
 	SecretVariables map[string]string `json:"secret_variables,required"`
+
+	// SkipRollout creates the new version without redeploying the applications that are
+	// synced with the env group
+	SkipRollout bool `json:"skip_rollout"`
 }
 
 type CreateConfigMapResponse struct {
@@ -8,6 +8,11 @@ type Project struct {
 	RDSDatabasesEnabled bool    `json:"enable_rds_databases"`
 	ManagedInfraEnabled bool    `json:"managed_infra_enabled"`
 	APITokensEnabled    bool    `json:"api_tokens_enabled"`
+	MFARequired         bool    `json:"mfa_required"`
+
+	// EnvGroupVersionRetention is the number of versions of each env group kept in the
+	// clusters of the project, where 0 keeps every version
+	EnvGroupVersionRetention uint `json:"env_group_version_retention"`
 }
 
 type CreateProjectRequest struct {
@@ -40,11 +45,14 @@ type GetProjectPolicyResponse []*PolicyDocument
 type ListProjectRolesResponse []RoleKind
 
 type Collaborator struct {
-	ID        uint   `json:"id"`
-	Kind      string `json:"kind"`
-	UserID    uint   `json:"user_id"`
-	Email     string `json:"email"`
-	ProjectID uint   `json:"project_id"`
+	ID         uint   `json:"id"`
+	Kind       string `json:"kind"`
+	UserID     uint   `json:"user_id"`
+	Email      string `json:"email"`
+	ProjectID  uint   `json:"project_id"`
+	PolicyUID  string `json:"policy_uid,omitempty"`
+	PolicyName string `json:"policy_name,omitempty"`
+	MFAEnabled bool   `json:"mfa_enabled"`
 }
 
 type ListCollaboratorsResponse []*Collaborator
@@ -52,12 +60,28 @@ type ListCollaboratorsResponse []*Collaborator
 type UpdateRoleRequest struct {
 	UserID uint   `json:"user_id,required"`
 	Kind   string `json:"kind,required"`
+
+	// PolicyUID is required when Kind is "custom", and must reference a policy
+	// in the project
+	PolicyUID string `json:"policy_uid"`
 }
 
 type UpdateRoleResponse struct {
 	*Role
 }
 
+type UpdateProjectMFARequest struct {
+	Required bool `json:"required"`
+}
+
+type UpdateEnvGroupRetentionRequest struct {
+	KeepLast uint `json:"keep_last"`
+}
+
+type ResetCollaboratorMFARequest struct {
+	UserID uint `json:"user_id" form:"required"`
+}
+
 type DeleteRoleRequest struct {
 	UserID uint `schema:"user_id,required"`
 }
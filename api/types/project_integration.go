@@ -193,3 +193,111 @@ type GitIntegration struct {
 }
 
 type ListGitIntegrationResponse []*GitIntegration
+
+type WebhookIntegration struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	ID uint `json:"id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	Name string `json:"name"`
+
+	// The host of the webhook URL. The full URL is not returned, since it may
+	// contain credentials.
+	Host string `json:"host"`
+
+	// Whether notifications sent to this webhook are signed
+	Signed bool `json:"signed"`
+}
+
+type ListWebhookIntegrationsResponse []*WebhookIntegration
+
+type CreateWebhookIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+	URL  string `json:"url" form:"required,url"`
+
+	// Sign notifications with a generated secret, which is only returned once
+	Sign bool `json:"sign"`
+}
+
+type CreateWebhookIntegrationResponse struct {
+	*WebhookIntegration
+
+	SigningSecret string `json:"signing_secret,omitempty"`
+}
+
+type VaultIntegration struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	ID uint `json:"id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	Name string `json:"name"`
+
+	// The address of the Vault server. The token is never returned.
+	Address string `json:"address"`
+}
+
+type ListVaultIntegrationsResponse []*VaultIntegration
+
+type CreateVaultIntegrationRequest struct {
+	Name    string `json:"name" form:"required"`
+	Address string `json:"address" form:"required,url"`
+	Token   string `json:"token" form:"required"`
+}
+
+type CreateVaultIntegrationResponse struct {
+	*VaultIntegration
+}
+
+type TeamsIntegration struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	ID uint `json:"id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	Name string `json:"name"`
+}
+
+type ListTeamsIntegrationsResponse []*TeamsIntegration
+
+type CreateTeamsIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	// The incoming webhook URL created in Microsoft Teams
+	WebhookURL string `json:"webhook_url" form:"required,url"`
+}
+
+type CreateTeamsIntegrationResponse struct {
+	*TeamsIntegration
+}
+
+type DiscordIntegration struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	ID uint `json:"id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	Name string `json:"name"`
+}
+
+type ListDiscordIntegrationsResponse []*DiscordIntegration
+
+type CreateDiscordIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	// The incoming webhook URL created in Discord
+	WebhookURL string `json:"webhook_url" form:"required,url"`
+}
+
+type CreateDiscordIntegrationResponse struct {
+	*DiscordIntegration
+}
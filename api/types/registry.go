@@ -39,6 +39,9 @@ type Registry struct {
 
 	// The basic integration that was used to connect the registry:
 	BasicIntegrationID uint `json:"basic_integration_id,omitempty"`
+
+	// The image retention rules of the registry, if image retention is enabled
+	ImageRetention *ImageRetentionRules `json:"image_retention,omitempty"`
 }
 
 // Repository is a collection of images
@@ -128,3 +131,50 @@ type GetRegistryDOCRTokenRequest struct {
 type ListRegistryRepositoryResponse []*RegistryRepository
 
 type ListImageResponse []*Image
+
+// ImageRetentionRules decide which images are deleted from the repositories of a registry. An
+// image is deleted when it is not one of the last KeepLast images and is older than
+// MaxAgeDays, where a rule set to 0 is ignored. Retention is disabled if both are 0.
+type ImageRetentionRules struct {
+	KeepLast   uint `json:"keep_last"`
+	MaxAgeDays uint `json:"max_age_days"`
+
+	// KeepDeployed keeps the images used by a release in any cluster of the project, including
+	// the recent revisions that the release can be rolled back to
+	KeepDeployed bool `json:"keep_deployed"`
+}
+
+type UpdateImageRetentionRequest struct {
+	KeepLast   uint `json:"keep_last"`
+	MaxAgeDays uint `json:"max_age_days"`
+
+	// KeepDeployed defaults to true
+	KeepDeployed *bool `json:"keep_deployed"`
+}
+
+type GetImageRetentionPreviewRequest struct {
+	// KeepLast and MaxAgeDays preview different rules than the rules of the registry
+	KeepLast   uint `schema:"keep_last"`
+	MaxAgeDays uint `schema:"max_age_days"`
+}
+
+// RepositoryImageRetention lists the images of a repository that retention rules keep and
+// delete
+type RepositoryImageRetention struct {
+	RepositoryName string   `json:"repository_name"`
+	KeptTags       []string `json:"kept_tags"`
+
+	// DeployedTags are kept only because a release uses them
+	DeployedTags []string `json:"deployed_tags"`
+
+	DeletedImages []*Image `json:"deleted_images"`
+
+	// Error is set if the images of the repository could not be listed or deleted
+	Error string `json:"error,omitempty"`
+}
+
+// ImageRetentionReport lists the images that the retention rules of a registry delete
+type ImageRetentionReport struct {
+	Rules        *ImageRetentionRules        `json:"rules"`
+	Repositories []*RepositoryImageRetention `json:"repositories"`
+}
@@ -22,6 +22,31 @@ type PorterRelease struct {
 	ImageRepoURI    string           `json:"image_repo_uri"`
 	BuildConfig     *BuildConfig     `json:"build_config,omitempty"`
 	Tags            []string         `json:"tags,omitempty"`
+	AutoRollback    *AutoRollback    `json:"auto_rollback,omitempty"`
+
+	// WebhookSigningSecret is only returned by the webhook endpoints
+	WebhookSigningSecret     string `json:"webhook_signing_secret,omitempty"`
+	WebhookSignatureRequired bool   `json:"webhook_signature_required"`
+}
+
+// DefaultAutoRollbackTimeoutSeconds is the time that the controllers of an upgraded
+// revision are given to become ready, if no timeout is configured
+const DefaultAutoRollbackTimeoutSeconds uint = 300
+
+// AutoRollback configures whether a release is rolled back to its previous revision
+// when the controllers of an upgraded revision do not become ready in time
+type AutoRollback struct {
+	Enabled        bool `json:"enabled"`
+	TimeoutSeconds uint `json:"timeout_seconds"`
+}
+
+type GetAutoRollbackResponse AutoRollback
+
+type UpdateAutoRollbackRequest struct {
+	Enabled bool `json:"enabled"`
+
+	// TimeoutSeconds defaults to DefaultAutoRollbackTimeoutSeconds if unset
+	TimeoutSeconds uint `json:"timeout_seconds" form:"omitempty,min=30,max=3600"`
 }
 
 type GetReleaseResponse Release
@@ -79,6 +104,18 @@ type GetJobsStatusResponse struct {
 
 const URLParamToken URLParam = "token"
 
+// CreateWebhookRequest creates the deploy webhook of a release, or updates the signing
+// settings of an existing webhook
+type CreateWebhookRequest struct {
+	// RotateSigningSecret generates a new signing secret for the webhook. The previous
+	// secret is no longer accepted.
+	RotateSigningSecret bool `json:"rotate_signing_secret"`
+
+	// RequireSignature rejects unsigned webhook requests if set. A signing secret is
+	// generated if the webhook does not have one.
+	RequireSignature *bool `json:"require_signature,omitempty"`
+}
+
 type WebhookRequest struct {
 	Commit string `schema:"commit"`
 
@@ -71,6 +71,10 @@ type APIRequestMetadata struct {
 
 	// The usage metric that the request should check for, if CheckUsage
 	UsageMetric UsageMetric
+
+	// Whether the endpoint is recorded in the project audit log even though it is not
+	// project-scoped. The handler attributes the request to a project itself.
+	Audited bool
 }
 
 const RequestScopeCtxKey = "requestscopes"
@@ -13,4 +13,8 @@ type Role struct {
 	Kind      RoleKind `json:"kind"`
 	UserID    uint     `json:"user_id"`
 	ProjectID uint     `json:"project_id"`
+
+	// PolicyUID is the unique id of the custom policy bound to this role, and is
+	// only set when Kind is RoleCustom
+	PolicyUID string `json:"policy_uid,omitempty"`
 }
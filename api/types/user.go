@@ -4,6 +4,11 @@ type User struct {
 	ID            uint   `json:"id"`
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
+	MFAEnabled    bool   `json:"mfa_enabled"`
+
+	// MFARequired is set on login responses if the user must verify a one-time password
+	// with /login/mfa before the session is authenticated
+	MFARequired bool `json:"mfa_required,omitempty"`
 }
 
 type CreateUserRequest struct {
@@ -22,6 +27,33 @@ type LoginUserRequest struct {
 
 type LoginUserResponse User
 
+type LoginMFARequest struct {
+	// Code is either a one-time password or a recovery code
+	Code string `json:"code" form:"required"`
+}
+
+type EnrollTOTPResponse struct {
+	Secret string `json:"secret"`
+
+	// URI is the otpauth:// URI of the secret, which can be rendered as a QR code
+	URI string `json:"uri"`
+}
+
+type VerifyTOTPRequest struct {
+	Code string `json:"code" form:"required"`
+}
+
+type VerifyTOTPResponse struct {
+	// RecoveryCodes can each be used once instead of a one-time password. They are only
+	// returned when enrolment completes.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type DisableTOTPRequest struct {
+	// Code is either a one-time password or a recovery code
+	Code string `json:"code" form:"required"`
+}
+
 type CLILoginUserRequest struct {
 	Redirect string `schema:"redirect" form:"required"`
 }
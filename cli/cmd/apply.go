@@ -49,6 +49,12 @@ environment variables will always take precendence over values specified in the
 
 By default, this command expects to be run from a local git repository.
 
+To see the changes that would be made without making them, pass --dry-run. For each release,
+this prints the changes to its Helm values compared to the currently deployed revision, along
+with the images that would be built and the env groups that would be created:
+
+  %s
+
 The following are the environment variables that can be used to set certain values while
 applying a configuration:
   PORTER_CLUSTER              Cluster ID that contains the project
@@ -61,6 +67,7 @@ applying a configuration:
 	`,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml --dry-run"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, apply)
@@ -72,12 +79,20 @@ applying a configuration:
 }
 
 var porterYAML string
+var applyDryRun bool
 
 func init() {
 	rootCmd.AddCommand(applyCmd)
 
 	applyCmd.Flags().StringVarP(&porterYAML, "file", "f", "", "path to porter.yaml")
 	applyCmd.MarkFlagRequired("file")
+
+	applyCmd.Flags().BoolVar(
+		&applyDryRun,
+		"dry-run",
+		false,
+		"print the changes that would be made to each resource without building, pushing or deploying anything",
+	)
 }
 
 func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -98,6 +113,39 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []str
 	}
 
 	worker := worker.NewWorker()
+
+	if applyDryRun {
+		// the plan drivers only print the changes that the other drivers would make, and the
+		// deployment hooks are not registered since they report the deployment to Porter
+		worker.RegisterDriver("deploy", NewPorterPlanDriver)
+		worker.RegisterDriver("build-image", preview.NewBuildPlanDriver)
+		worker.RegisterDriver("push-image", preview.NewPushPlanDriver)
+		worker.RegisterDriver("update-config", preview.NewUpdateConfigPlanDriver)
+		worker.RegisterDriver("random-string", preview.NewRandomStringDriver)
+		worker.RegisterDriver("env-group", preview.NewEnvGroupPlanDriver)
+		worker.RegisterDriver("os-env", preview.NewOSEnvDriver)
+
+		worker.SetDefaultDriver("deploy")
+
+		cloneEnvGroupHook := NewCloneEnvGroupHook(client, resGroup)
+		cloneEnvGroupHook.dryRun = true
+		worker.RegisterHook("cloneenvgroup", cloneEnvGroupHook)
+
+		color.New(color.FgBlue, color.Bold).Println("Running in dry-run mode, no changes will be made")
+
+		err = worker.Apply(resGroup, &switchboardTypes.ApplyOpts{
+			BasePath: basePath,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		color.New(color.FgGreen).Println("\nDry run complete, no changes were made")
+
+		return nil
+	}
+
 	worker.RegisterDriver("deploy", NewPorterDriver)
 	worker.RegisterDriver("build-image", preview.NewBuildDriver)
 	worker.RegisterDriver("push-image", preview.NewPushDriver)
@@ -108,7 +156,21 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []str
 
 	worker.SetDefaultDriver("deploy")
 
-	if hasDeploymentHookEnvVars() {
+	if os.Getenv("PORTER_GITLAB_ENVIRONMENT_ID") != "" {
+		deplNamespace := os.Getenv("PORTER_NAMESPACE")
+
+		if deplNamespace == "" {
+			return fmt.Errorf("namespace must be set by PORTER_NAMESPACE")
+		}
+
+		deploymentHook, err := NewGitlabDeploymentHook(client, resGroup, deplNamespace)
+
+		if err != nil {
+			return err
+		}
+
+		worker.RegisterHook("deployment", deploymentHook)
+	} else if hasDeploymentHookEnvVars() {
 		deplNamespace := os.Getenv("PORTER_NAMESPACE")
 
 		if deplNamespace == "" {
@@ -884,9 +946,131 @@ func (t *DeploymentHook) OnError(err error) {
 	}
 }
 
+// GitlabDeploymentHook reports the status of a preview deployment that was triggered
+// by a GitLab merge request pipeline. The merge request details are read from the
+// predefined CI/CD variables that GitLab sets for merge request pipelines.
+type GitlabDeploymentHook struct {
+	*DeploymentHook
+
+	environmentID, mrIID uint
+	mrTitle              string
+}
+
+func NewGitlabDeploymentHook(client *api.Client, resourceGroup *switchboardTypes.ResourceGroup, namespace string) (*GitlabDeploymentHook, error) {
+	res := &GitlabDeploymentHook{
+		DeploymentHook: &DeploymentHook{
+			client:        client,
+			resourceGroup: resourceGroup,
+			namespace:     namespace,
+			projectID:     cliConf.Project,
+			clusterID:     cliConf.Cluster,
+			branchFrom:    os.Getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"),
+			branchInto:    os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"),
+			commitSHA:     os.Getenv("CI_COMMIT_SHA"),
+		},
+		mrTitle: os.Getenv("CI_MERGE_REQUEST_TITLE"),
+	}
+
+	if res.projectID == 0 {
+		return nil, fmt.Errorf("project id must be set")
+	}
+
+	if res.clusterID == 0 {
+		return nil, fmt.Errorf("cluster id must be set")
+	}
+
+	envID, err := strconv.Atoi(os.Getenv("PORTER_GITLAB_ENVIRONMENT_ID"))
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid PORTER_GITLAB_ENVIRONMENT_ID: %w", err)
+	}
+
+	res.environmentID = uint(envID)
+
+	mrIID, err := strconv.Atoi(os.Getenv("CI_MERGE_REQUEST_IID"))
+
+	if err != nil {
+		return nil, fmt.Errorf("CI_MERGE_REQUEST_IID must be set: preview deployments only run in merge request pipelines")
+	}
+
+	res.mrIID = uint(mrIID)
+
+	if res.commitSHA == "" {
+		commit, err := git.LastCommit()
+
+		if err != nil {
+			return nil, fmt.Errorf(err.Error())
+		}
+
+		res.commitSHA = commit.Sha
+	}
+
+	return res, nil
+}
+
+func (t *GitlabDeploymentHook) PreApply() error {
+	_, err := t.client.CreateGitlabDeployment(
+		context.Background(),
+		t.projectID, t.clusterID, t.environmentID,
+		&types.CreateGitlabDeploymentRequest{
+			Namespace:         t.namespace,
+			MergeRequestIID:   t.mrIID,
+			MergeRequestTitle: t.mrTitle,
+			BranchFrom:        t.branchFrom,
+			BranchInto:        t.branchInto,
+			CommitSHA:         t.commitSHA,
+		},
+	)
+
+	return err
+}
+
+func (t *GitlabDeploymentHook) PostApply(populatedData map[string]interface{}) error {
+	subdomains := make([]string, 0)
+
+	for _, data := range populatedData {
+		domain, ok := data.(string)
+
+		if !ok {
+			continue
+		}
+
+		if _, err := url.Parse("https://" + domain); err == nil {
+			subdomains = append(subdomains, "https://"+domain)
+		}
+	}
+
+	// finalize the deployment
+	_, err := t.client.FinalizeGitlabDeployment(
+		context.Background(),
+		t.projectID, t.clusterID, t.environmentID,
+		&types.FinalizeDeploymentRequest{
+			Namespace: t.namespace,
+			Subdomain: strings.Join(subdomains, ","),
+		},
+	)
+
+	return err
+}
+
+func (t *GitlabDeploymentHook) OnError(err error) {
+	t.client.UpdateGitlabDeploymentStatus(
+		context.Background(),
+		t.projectID, t.clusterID, t.environmentID,
+		&types.UpdateGitlabDeploymentStatusRequest{
+			Namespace: t.namespace,
+			Status:    string(types.DeploymentStatusFailed),
+			CommitSHA: t.commitSHA,
+		},
+	)
+}
+
 type CloneEnvGroupHook struct {
 	client   *api.Client
 	resGroup *switchboardTypes.ResourceGroup
+
+	// if set, the env groups that would be cloned are only printed
+	dryRun bool
 }
 
 func NewCloneEnvGroupHook(client *api.Client, resourceGroup *switchboardTypes.ResourceGroup) *CloneEnvGroupHook {
@@ -939,6 +1123,14 @@ func (t *CloneEnvGroupHook) PreApply() error {
 
 					color.New(color.FgBlue, color.Bold).
 						Printf("Env group '%s' does not exist in the target namespace '%s'\n", group.Name, target.Namespace)
+
+					if t.dryRun {
+						color.New(color.FgGreen).Printf("  + would clone env group '%s' from namespace '%s'\n",
+							group.Name, group.Namespace)
+
+						continue
+					}
+
 					color.New(color.FgBlue, color.Bold).
 						Printf("Cloning env group '%s' from namespace '%s' to target namespace '%s'\n",
 							group.Name, group.Namespace, target.Namespace)
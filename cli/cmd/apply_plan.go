@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/deploy"
+	"github.com/porter-dev/porter/cli/cmd/preview"
+	"github.com/porter-dev/porter/internal/templater/utils"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+)
+
+// PlanDriver prints the changes that the deploy driver would make to a release when porter
+// apply is run with --dry-run
+type PlanDriver struct {
+	*Driver
+}
+
+func NewPorterPlanDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver, err := NewPorterDriver(resource, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlanDriver{driver.(*Driver)}, nil
+}
+
+func (d *PlanDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	client := config.GetAPIClient()
+
+	if resource.Name == "" {
+		return nil, fmt.Errorf("empty app name")
+	}
+
+	preview.PrintPlanHeader(resource.Name, "deploy")
+
+	release, err := client.GetRelease(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		resource.Name,
+	)
+
+	shouldCreate := err != nil
+
+	if !d.source.IsApplication {
+		addonConfig, err := d.getAddonConfig(resource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if shouldCreate {
+			color.New(color.FgGreen).Printf("  + would create %s release %s in namespace %s\n",
+				d.source.Name, resource.Name, d.target.Namespace)
+
+			preview.PlanReleaseCreate(addonConfig)
+		} else {
+			color.New(color.FgYellow).Printf("  ~ would upgrade %s release %s in namespace %s\n",
+				d.source.Name, resource.Name, d.target.Namespace)
+
+			// addons are upgraded with the values in porter.yaml, replacing the deployed values
+			preview.PrintValuesDiff(release.Config, addonConfig)
+		}
+
+		d.output = utils.CoalesceValues(d.source.SourceValues, addonConfig)
+
+		return resource, nil
+	}
+
+	appConfig, err := d.getApplicationConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	method := appConfig.Build.Method
+
+	if method != "pack" && method != "docker" && method != "registry" {
+		return nil, fmt.Errorf("method should either be \"docker\", \"pack\" or \"registry\"")
+	}
+
+	tag, err := preview.GetImageTag(method, appConfig.Build.Image)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fullPath, err := filepath.Abs(appConfig.Build.Context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+
+	if shouldCreate {
+		color.New(color.FgGreen).Printf("  + would create %s release %s in namespace %s\n",
+			d.source.Name, resource.Name, d.target.Namespace)
+
+		if method == "registry" {
+			fmt.Printf("  would deploy image %s\n", appConfig.Build.Image)
+		} else {
+			imageURL, err := preview.GetImageRepoURL(client, d.target, resource.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			fmt.Printf("  would build image %s:%s with %s from %s\n", imageURL, tag, method, fullPath)
+		}
+
+		values = preview.PlanReleaseCreate(appConfig.Values)
+	} else if appConfig.OnlyCreate {
+		fmt.Printf("  release %s already exists and onlyCreate is set, nothing to update\n", resource.Name)
+
+		values = release.Config
+	} else {
+		color.New(color.FgYellow).Printf("  ~ would upgrade %s release %s in namespace %s to tag %s\n",
+			d.source.Name, resource.Name, d.target.Namespace, tag)
+
+		if method != "registry" {
+			fmt.Printf("  would build and push a new image with %s from %s\n", method, fullPath)
+		}
+
+		values, err = preview.PlanReleaseUpdate(client, release, &deploy.SharedOpts{
+			ProjectID:   d.target.Project,
+			ClusterID:   d.target.Cluster,
+			Namespace:   d.target.Namespace,
+			OverrideTag: tag,
+			EnvGroups:   appConfig.EnvGroups,
+		}, appConfig.Values)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if d.source.Name == "job" && appConfig.WaitForJob && (shouldCreate || !appConfig.OnlyCreate) {
+		fmt.Println("  would wait for the job to finish")
+	}
+
+	d.output = utils.CoalesceValues(d.source.SourceValues, values)
+
+	return resource, nil
+}
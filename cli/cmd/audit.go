@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+// auditCmd represents the "porter audit" base command when called
+// without any subcommands
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Commands that read the audit log of the current project",
+}
+
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the mutating API calls made in the current project, most recent first",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listAuditEvents)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var auditListOpts = &types.ListAuditEventsRequest{}
+var auditSince time.Duration
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.AddCommand(auditListCmd)
+
+	auditListCmd.PersistentFlags().UintVar(
+		&auditListOpts.UserID,
+		"user-id",
+		0,
+		"only list events made by the user with this id",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditListOpts.APITokenUID,
+		"api-token",
+		"",
+		"only list events made with the API token with this uid",
+	)
+
+	auditListCmd.PersistentFlags().UintVar(
+		&auditListOpts.ClusterID,
+		"cluster-id",
+		0,
+		"only list events in the cluster with this id",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditListOpts.Namespace,
+		"namespace",
+		"",
+		"only list events in this namespace",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditListOpts.ReleaseName,
+		"release",
+		"",
+		"only list events for the release with this name",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		(*string)(&auditListOpts.Verb),
+		"verb",
+		"",
+		"only list events with this verb: create, update or delete",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		(*string)(&auditListOpts.Outcome),
+		"outcome",
+		"",
+		"only list events with this outcome: success or failure",
+	)
+
+	auditListCmd.PersistentFlags().DurationVar(
+		&auditSince,
+		"since",
+		0,
+		"only list events newer than a relative duration like 5s, 2m, or 3h",
+	)
+
+	auditListCmd.PersistentFlags().IntVar(
+		&auditListOpts.Limit,
+		"limit",
+		50,
+		"the maximum number of events to list",
+	)
+
+	auditListCmd.PersistentFlags().IntVar(
+		&auditListOpts.Skip,
+		"skip",
+		0,
+		"the number of events to skip, for paging through older events",
+	)
+}
+
+func listAuditEvents(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if auditSince != 0 {
+		auditListOpts.Since = time.Now().Add(-auditSince).Unix()
+	}
+
+	resp, err := client.ListAuditLogs(context.Background(), cliConf.Project, auditListOpts)
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(resp, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "TIME", "ACTOR", "VERB", "ENDPOINT", "STATUS", "OUTCOME")
+
+		for _, event := range resp.AuditEvents {
+			actor := fmt.Sprintf("user %d", event.UserID)
+
+			if event.APITokenUID != "" {
+				actor = fmt.Sprintf("token %s", event.APITokenUID)
+			}
+
+			fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%s %s\t%d\t%s\n",
+				event.CreatedAt.Local().Format(time.RFC822),
+				actor,
+				event.Verb,
+				event.Method,
+				event.Path,
+				event.StatusCode,
+				event.Outcome,
+			)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if shown := resp.Skip + len(resp.AuditEvents); int64(shown) < resp.Count {
+			fmt.Printf("\nShowing %d of %d events, use --skip %d to list older events\n", shown, resp.Count, shown)
+		}
+
+		return nil
+	})
+}
@@ -58,6 +58,50 @@ var logoutCmd = &cobra.Command{
 	},
 }
 
+var mfaCmd = &cobra.Command{
+	Use:   "mfa",
+	Short: "Commands for managing multi-factor authentication for the logged in user",
+}
+
+var mfaEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enables time-based one-time passwords as a second factor for logging in",
+	Long: fmt.Sprintf(`
+%s
+
+Enables time-based one-time passwords as a second factor for logging in with an email and
+password. The command prints a secret to add to an authenticator app, and prompts for a
+one-time password generated by the app to complete enrolment:
+
+  %s
+
+Once enabled, the command prints a set of recovery codes. Each recovery code can be used
+once instead of a one-time password, so store them somewhere safe.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter auth mfa enable\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter auth mfa enable"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, enableMFA)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var mfaDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Disables multi-factor authentication for the logged in user",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, disableMFA)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
 var manual bool = false
 
 func init() {
@@ -66,6 +110,10 @@ func init() {
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(registerCmd)
 	authCmd.AddCommand(logoutCmd)
+	authCmd.AddCommand(mfaCmd)
+
+	mfaCmd.AddCommand(mfaEnableCmd)
+	mfaCmd.AddCommand(mfaDisableCmd)
 
 	loginCmd.PersistentFlags().BoolVar(
 		&manual,
@@ -179,7 +227,7 @@ func setProjectForUser(client *api.Client, userID uint) error {
 }
 
 func loginManual() error {
-	client := api.NewClient(cliConf.Host+"/api", "cookie.json")
+	client := api.NewClient(cliConf.Host+"/api", cliConf.CookieFileName())
 
 	var username, pw string
 
@@ -197,7 +245,7 @@ func loginManual() error {
 		return err
 	}
 
-	_, err = client.Login(context.Background(), &types.LoginUserRequest{
+	loginResp, err := client.Login(context.Background(), &types.LoginUserRequest{
 		Email:    username,
 		Password: pw,
 	})
@@ -206,6 +254,22 @@ func loginManual() error {
 		return err
 	}
 
+	if loginResp.MFARequired {
+		code, err := utils.PromptPlaintext("One-time password or recovery code: ")
+
+		if err != nil {
+			return err
+		}
+
+		_, err = client.LoginMFA(context.Background(), &types.LoginMFARequest{
+			Code: code,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
 	// set the token to empty since this is manual (cookie-based) login
 	cliConf.SetToken("")
 
@@ -277,3 +341,72 @@ func logout(user *types.GetAuthenticatedUserResponse, client *api.Client, args [
 
 	return nil
 }
+
+func enableMFA(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if user.MFAEnabled {
+		color.Yellow("Multi-factor authentication is already enabled. To enrol a new device, run \"porter auth mfa disable\" first.")
+		return nil
+	}
+
+	resp, err := client.EnrollTOTP(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Add the following secret to your authenticator app, or open the URI on a device with an authenticator app:")
+	fmt.Println()
+	fmt.Printf("  Secret: %s\n", resp.Secret)
+	fmt.Printf("  URI:    %s\n", resp.URI)
+	fmt.Println()
+
+	code, err := utils.PromptPlaintext("One-time password: ")
+
+	if err != nil {
+		return err
+	}
+
+	verifyResp, err := client.VerifyTOTP(context.Background(), &types.VerifyTOTPRequest{
+		Code: code,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Println("Enabled multi-factor authentication!")
+	fmt.Println()
+	fmt.Println("Each of the following recovery codes can be used once instead of a one-time password. They will not be shown again:")
+	fmt.Println()
+
+	for _, recoveryCode := range verifyResp.RecoveryCodes {
+		fmt.Printf("  %s\n", recoveryCode)
+	}
+
+	return nil
+}
+
+func disableMFA(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if !user.MFAEnabled {
+		color.Yellow("Multi-factor authentication is not enabled.")
+		return nil
+	}
+
+	code, err := utils.PromptPlaintext("One-time password or recovery code: ")
+
+	if err != nil {
+		return err
+	}
+
+	err = client.DisableTOTP(context.Background(), &types.DisableTOTPRequest{
+		Code: code,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Println("Disabled multi-factor authentication")
+
+	return nil
+}
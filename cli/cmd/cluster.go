@@ -85,24 +85,24 @@ func listClusters(user *types.GetAuthenticatedUserResponse, client *api.Client,
 
 	clusters := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+	return writeOutput(clusters, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
 
-	fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "NAME", "SERVER")
+		fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "NAME", "SERVER")
 
-	currClusterID := cliConf.Cluster
+		currClusterID := cliConf.Cluster
 
-	for _, cluster := range clusters {
-		if currClusterID == cluster.ID {
-			color.New(color.FgGreen).Fprintf(w, "%d\t%s\t%s (current cluster)\n", cluster.ID, cluster.Name, cluster.Server)
-		} else {
-			fmt.Fprintf(w, "%d\t%s\t%s\n", cluster.ID, cluster.Name, cluster.Server)
+		for _, cluster := range clusters {
+			if currClusterID == cluster.ID {
+				color.New(color.FgGreen).Fprintf(w, "%d\t%s\t%s (current cluster)\n", cluster.ID, cluster.Name, cluster.Server)
+			} else {
+				fmt.Fprintf(w, "%d\t%s\t%s\n", cluster.ID, cluster.Name, cluster.Server)
+			}
 		}
-	}
-
-	w.Flush()
 
-	return nil
+		return w.Flush()
+	})
 }
 
 func deleteCluster(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -154,16 +154,16 @@ func listNamespaces(user *types.GetAuthenticatedUserResponse, client *api.Client
 		return err
 	}
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+	return writeOutput(namespaces, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
 
-	fmt.Fprintf(w, "%s\t%s\n", "NAME", "STATUS")
+		fmt.Fprintf(w, "%s\t%s\n", "NAME", "STATUS")
 
-	for _, namespace := range namespaces.Items {
-		fmt.Fprintf(w, "%s\t%s\n", namespace.Name, namespace.Status.Phase)
-	}
-
-	w.Flush()
+		for _, namespace := range namespaces.Items {
+			fmt.Fprintf(w, "%s\t%s\n", namespace.Name, namespace.Status.Phase)
+		}
 
-	return nil
+		return w.Flush()
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -168,6 +169,67 @@ var configSetKubeconfigCmd = &cobra.Command{
 	},
 }
 
+var configGetContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "Lists the contexts in the configuration",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := printContexts(); err != nil {
+			color.New(color.FgRed).Printf("An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Sets the current context in the configuration",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := cliConf.UseContext(args[0])
+
+		if err != nil {
+			color.New(color.FgRed).Printf("An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var configCreateContextCmd = &cobra.Command{
+	Use:   "create-context [name] [host]",
+	Args:  cobra.RangeArgs(1, 2),
+	Short: "Creates a new context in the configuration",
+	Long: fmt.Sprintf(`
+%s
+
+Creates a new named context, which stores its own host, project, cluster and login. If
+the host is omitted, https://dashboard.getporter.dev is used. For example, to create a
+context for a staging Porter instance and switch to it:
+
+  %s
+
+After switching, commands like porter auth login and porter config set-project only
+modify the current context. A context can also be selected for a single command with the
+--context flag or the PORTER_CONTEXT environment variable.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter config create-context\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter config create-context staging https://porter.staging.example.com && porter config use-context staging"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		host := "https://dashboard.getporter.dev"
+
+		if len(args) == 2 {
+			host = args[1]
+		}
+
+		err := cliConf.CreateContext(args[0], host)
+
+		if err != nil {
+			color.New(color.FgRed).Printf("An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 
@@ -177,6 +239,9 @@ func init() {
 	configCmd.AddCommand(configSetRegistryCmd)
 	configCmd.AddCommand(configSetHelmRepoCmd)
 	configCmd.AddCommand(configSetKubeconfigCmd)
+	configCmd.AddCommand(configGetContextsCmd)
+	configCmd.AddCommand(configUseContextCmd)
+	configCmd.AddCommand(configCreateContextCmd)
 }
 
 func printConfig() error {
@@ -191,6 +256,51 @@ func printConfig() error {
 	return nil
 }
 
+// cliContext is a named CLI context, as printed by "porter config get-contexts"
+type cliContext struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	Host    string `json:"host"`
+	Project uint   `json:"project"`
+	Cluster uint   `json:"cluster"`
+}
+
+func printContexts() error {
+	contexts := make([]*cliContext, 0)
+
+	for _, name := range cliConfig.ListContexts() {
+		project, _ := strconv.ParseUint(cliConfig.GetContextValue(name, "project"), 10, 64)
+		cluster, _ := strconv.ParseUint(cliConfig.GetContextValue(name, "cluster"), 10, 64)
+
+		contexts = append(contexts, &cliContext{
+			Name:    name,
+			Current: name == cliConf.Context,
+			Host:    cliConfig.GetContextValue(name, "host"),
+			Project: uint(project),
+			Cluster: uint(cluster),
+		})
+	}
+
+	return writeOutput(contexts, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "CURRENT", "NAME", "HOST", "PROJECT", "CLUSTER")
+
+		for _, c := range contexts {
+			current := ""
+
+			if c.Current {
+				current = "*"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\n", current, c.Name, c.Host, c.Project, c.Cluster)
+		}
+
+		return w.Flush()
+	})
+}
+
 func listAndSetProject(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
 	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 	s.Color("cyan")
@@ -6,6 +6,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
@@ -20,6 +22,13 @@ var home = homedir.HomeDir()
 // config is a shared object used by all commands
 var config = &CLIConfig{}
 
+// DefaultContext is the name of the context stored at the top level of the config
+// file, which is used when no other context is selected. Named contexts are stored
+// under the "contexts" key of the config file.
+const DefaultContext = "default"
+
+var contextNameRegex = regexp.MustCompile("^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$")
+
 // CLIConfig is the set of shared configuration options for the CLI commands.
 // This config is used by viper: calling Set() function for any parameter will
 // update the corresponding field in the viper config file.
@@ -37,6 +46,11 @@ type CLIConfig struct {
 	Registry   uint   `yaml:"registry"`
 	HelmRepo   uint   `yaml:"helm_repo"`
 	Kubeconfig string `yaml:"kubeconfig"`
+
+	// Context is the name of the context that this config was loaded from. It is
+	// read from the --context flag, the PORTER_CONTEXT env variable or the
+	// current_context key of the config file, in that order.
+	Context string `yaml:"-" mapstructure:"-"`
 }
 
 // InitAndLoadConfig populates the config object with the following precedence rules:
@@ -45,20 +59,21 @@ type CLIConfig struct {
 // 3. config
 // 4. default
 //
-// It populates the shared config object above
-func InitAndLoadConfig() {
-	initAndLoadConfig(config)
+// It populates the shared config object above. Since the config is loaded before
+// the command line is parsed, the value of the --context flag is passed separately.
+func InitAndLoadConfig(contextFlag string) {
+	initAndLoadConfig(config, contextFlag)
 }
 
 func InitAndLoadNewConfig() *CLIConfig {
 	newConfig := &CLIConfig{}
 
-	initAndLoadConfig(newConfig)
+	initAndLoadConfig(newConfig, "")
 
 	return newConfig
 }
 
-func initAndLoadConfig(_config *CLIConfig) {
+func initAndLoadConfig(_config *CLIConfig, contextFlag string) {
 	initFlagSet()
 
 	// check that the .porter folder exists; create if not
@@ -75,18 +90,7 @@ func initAndLoadConfig(_config *CLIConfig) {
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(porterDir)
 
-	// Bind the flagset initialized above
-	viper.BindPFlags(utils.DriverFlagSet)
-	viper.BindPFlags(utils.DefaultFlagSet)
-	viper.BindPFlags(utils.RegistryFlagSet)
-	viper.BindPFlags(utils.HelmRepoFlagSet)
-
-	// Bind the environment variables with prefix "PORTER_"
-	viper.SetEnvPrefix("PORTER")
-	viper.BindEnv("host")
-	viper.BindEnv("project")
-	viper.BindEnv("cluster")
-	viper.BindEnv("token")
+	bindFlagsAndEnv(viper.GetViper())
 
 	err := viper.ReadInConfig()
 
@@ -106,8 +110,87 @@ func initAndLoadConfig(_config *CLIConfig) {
 		}
 	}
 
-	// unmarshal the config into the shared config struct
-	viper.Unmarshal(_config)
+	_config.Context = getCurrentContext(contextFlag)
+
+	if _config.Context == DefaultContext {
+		// unmarshal the config into the shared config struct
+		viper.Unmarshal(_config)
+		return
+	}
+
+	if !contextExists(_config.Context) {
+		color.New(color.FgRed).Printf("context %s does not exist, create it with porter config create-context %s\n", _config.Context, _config.Context)
+		os.Exit(1)
+	}
+
+	// named contexts are read with a separate viper instance, so that flags and env
+	// variables take precedence over the context in the same way as the default context
+	contextViper := viper.New()
+	contextViper.MergeConfigMap(viper.GetStringMap(contextKey(_config.Context)))
+
+	bindFlagsAndEnv(contextViper)
+
+	contextViper.Unmarshal(_config)
+}
+
+// bindFlagsAndEnv binds the shared flagsets and the environment variables with the
+// prefix "PORTER_" to a viper instance
+func bindFlagsAndEnv(v *viper.Viper) {
+	// Bind the flagset initialized above
+	v.BindPFlags(utils.DriverFlagSet)
+	v.BindPFlags(utils.DefaultFlagSet)
+	v.BindPFlags(utils.RegistryFlagSet)
+	v.BindPFlags(utils.HelmRepoFlagSet)
+
+	// Bind the environment variables with prefix "PORTER_"
+	v.SetEnvPrefix("PORTER")
+	v.BindEnv("host")
+	v.BindEnv("project")
+	v.BindEnv("cluster")
+	v.BindEnv("token")
+}
+
+// getCurrentContext returns the name of the selected context
+func getCurrentContext(contextFlag string) string {
+	res := contextFlag
+
+	if res == "" {
+		res = os.Getenv("PORTER_CONTEXT")
+	}
+
+	if res == "" {
+		res = viper.GetString("current_context")
+	}
+
+	if res == "" {
+		res = DefaultContext
+	}
+
+	return res
+}
+
+func contextKey(name string) string {
+	return "contexts." + name
+}
+
+func contextExists(name string) bool {
+	if name == DefaultContext {
+		return true
+	}
+
+	_, ok := viper.GetStringMap("contexts")[name]
+
+	return ok
+}
+
+// configKey returns the key of a config value in the config file, which is nested
+// under the context key for named contexts
+func (c *CLIConfig) configKey(key string) string {
+	if c.Context == "" || c.Context == DefaultContext {
+		return key
+	}
+
+	return contextKey(c.Context) + "." + key
 }
 
 // initFlagSet initializes the shared flags used by multiple commands
@@ -147,6 +230,14 @@ func initFlagSet() {
 		"token for Porter authentication",
 	)
 
+	// the context flag is only registered so that cobra accepts it, its value is
+	// parsed before the config is loaded
+	utils.ContextFlagSet.String(
+		"context",
+		"",
+		"name of the config context to use, overriding the current context",
+	)
+
 	utils.RegistryFlagSet.UintVar(
 		&config.Registry,
 		"registry",
@@ -177,11 +268,90 @@ func GetAPIClient() *api.Client {
 		return api.NewClientWithToken(config.Host+"/api", token)
 	}
 
-	return api.NewClient(config.Host+"/api", "cookie.json")
+	return api.NewClient(config.Host+"/api", config.CookieFileName())
+}
+
+// CookieFileName returns the name of the file in the ~/.porter directory that stores
+// the session cookie, which is separate for each context
+func (c *CLIConfig) CookieFileName() string {
+	if c.Context == "" || c.Context == DefaultContext {
+		return "cookie.json"
+	}
+
+	return fmt.Sprintf("cookie-%s.json", c.Context)
+}
+
+// ListContexts returns the names of all contexts in the config file, including
+// the default context
+func ListContexts() []string {
+	res := []string{DefaultContext}
+	names := make([]string, 0)
+
+	for name := range viper.GetStringMap("contexts") {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return append(res, names...)
+}
+
+// GetContextValue returns a value stored in a context of the config file
+func GetContextValue(name, key string) string {
+	if name == DefaultContext {
+		return viper.GetString(key)
+	}
+
+	return viper.GetString(contextKey(name) + "." + key)
+}
+
+// CreateContext adds a new context with the given host to the config file
+func (c *CLIConfig) CreateContext(name, host string) error {
+	if name == DefaultContext || !contextNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid context name %s: names must consist of lowercase alphanumeric characters, '-' or '_', and cannot be %s", name, DefaultContext)
+	}
+
+	if contextExists(name) {
+		return fmt.Errorf("context %s already exists", name)
+	}
+
+	// a trailing / can lead to errors with the api server
+	host = strings.TrimRight(host, "/")
+
+	viper.Set(contextKey(name)+".host", host)
+	err := viper.WriteConfig()
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Created context %s with host %s\n", name, host)
+
+	return nil
+}
+
+// UseContext sets the current context in the config file
+func (c *CLIConfig) UseContext(name string) error {
+	if !contextExists(name) {
+		return fmt.Errorf("context %s does not exist", name)
+	}
+
+	viper.Set("current_context", name)
+	err := viper.WriteConfig()
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Switched to context %s\n", name)
+
+	c.Context = name
+
+	return nil
 }
 
 func (c *CLIConfig) SetDriver(driver string) error {
-	viper.Set("driver", driver)
+	viper.Set(c.configKey("driver"), driver)
 	color.New(color.FgGreen).Printf("Set the current driver as %s\n", driver)
 	err := viper.WriteConfig()
 
@@ -198,7 +368,7 @@ func (c *CLIConfig) SetHost(host string) error {
 	// a trailing / can lead to errors with the api server
 	host = strings.TrimRight(host, "/")
 
-	viper.Set("host", host)
+	viper.Set(c.configKey("host"), host)
 	color.New(color.FgGreen).Printf("Set the current host as %s\n", host)
 	err := viper.WriteConfig()
 
@@ -212,13 +382,13 @@ func (c *CLIConfig) SetHost(host string) error {
 }
 
 func (c *CLIConfig) SetProject(projectID uint) error {
-	if config.Kubeconfig != "" || viper.IsSet("kubeconfig") {
-		viper.Set("kubeconfig", "")
+	if config.Kubeconfig != "" || viper.IsSet(c.configKey("kubeconfig")) {
+		viper.Set(c.configKey("kubeconfig"), "")
 		color.New(color.FgBlue).Println("Removing local kubeconfig")
 		config.Kubeconfig = ""
 	}
 
-	viper.Set("project", projectID)
+	viper.Set(c.configKey("project"), projectID)
 	color.New(color.FgGreen).Printf("Set the current project as %d\n", projectID)
 	err := viper.WriteConfig()
 
@@ -232,13 +402,13 @@ func (c *CLIConfig) SetProject(projectID uint) error {
 }
 
 func (c *CLIConfig) SetCluster(clusterID uint) error {
-	if config.Kubeconfig != "" || viper.IsSet("kubeconfig") {
-		viper.Set("kubeconfig", "")
+	if config.Kubeconfig != "" || viper.IsSet(c.configKey("kubeconfig")) {
+		viper.Set(c.configKey("kubeconfig"), "")
 		color.New(color.FgBlue).Println("Removing local kubeconfig")
 		config.Kubeconfig = ""
 	}
 
-	viper.Set("cluster", clusterID)
+	viper.Set(c.configKey("cluster"), clusterID)
 	color.New(color.FgGreen).Printf("Set the current cluster as %d\n", clusterID)
 	err := viper.WriteConfig()
 
@@ -252,7 +422,7 @@ func (c *CLIConfig) SetCluster(clusterID uint) error {
 }
 
 func (c *CLIConfig) SetToken(token string) error {
-	viper.Set("token", token)
+	viper.Set(c.configKey("token"), token)
 	err := viper.WriteConfig()
 
 	if err != nil {
@@ -265,7 +435,7 @@ func (c *CLIConfig) SetToken(token string) error {
 }
 
 func (c *CLIConfig) SetRegistry(registryID uint) error {
-	viper.Set("registry", registryID)
+	viper.Set(c.configKey("registry"), registryID)
 	color.New(color.FgGreen).Printf("Set the current registry as %d\n", registryID)
 	err := viper.WriteConfig()
 
@@ -279,7 +449,7 @@ func (c *CLIConfig) SetRegistry(registryID uint) error {
 }
 
 func (c *CLIConfig) SetHelmRepo(helmRepoID uint) error {
-	viper.Set("helm_repo", helmRepoID)
+	viper.Set(c.configKey("helm_repo"), helmRepoID)
 	color.New(color.FgGreen).Printf("Set the current Helm repo as %d\n", helmRepoID)
 	err := viper.WriteConfig()
 
@@ -303,7 +473,7 @@ func (c *CLIConfig) SetKubeconfig(kubeconfig string) error {
 		return fmt.Errorf("%s does not exist", path)
 	}
 
-	viper.Set("kubeconfig", path)
+	viper.Set(c.configKey("kubeconfig"), path)
 	color.New(color.FgGreen).Printf("Set the path to kubeconfig as %s\n", path)
 	err = viper.WriteConfig()
 
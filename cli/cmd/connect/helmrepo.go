@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/porter-dev/porter/api/types"
 
@@ -28,7 +30,7 @@ Name: `))
 		return 0, err
 	}
 
-	repoURL, err := utils.PromptPlaintext(fmt.Sprintf(`Provide the Helm registry URL, make sure to include the protocol. For example, https://charts.bitnami.com/bitnami.
+	repoURL, err := utils.PromptPlaintext(fmt.Sprintf(`Provide the Helm registry URL, make sure to include the protocol. For example, https://charts.bitnami.com/bitnami, or oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts for an OCI registry.
 Registry URL: `))
 
 	if err != nil {
@@ -39,6 +41,40 @@ Registry URL: `))
 		return 0, fmt.Errorf("not a valid url: %s", err)
 	}
 
+	// OCI repos authenticate with the credentials of a connected registry
+	if strings.HasPrefix(repoURL, "oci://") {
+		registryIDStr, err := utils.PromptPlaintext(fmt.Sprintf(`Provide the id of the connected registry which hosts this OCI Helm repo. You can list registries with porter registry list.
+Registry ID: `))
+
+		if err != nil {
+			return 0, err
+		}
+
+		registryID, err := strconv.ParseUint(strings.TrimSpace(registryIDStr), 10, 64)
+
+		if err != nil {
+			return 0, fmt.Errorf("not a valid registry id: %s", err)
+		}
+
+		reg, err := client.CreateHelmRepo(
+			context.Background(),
+			projectID,
+			&types.CreateHelmRepoRequest{
+				URL:        repoURL,
+				Name:       repoName,
+				RegistryID: uint(registryID),
+			},
+		)
+
+		if err != nil {
+			return 0, err
+		}
+
+		color.New(color.FgGreen).Printf("created helm registry integration with id %d and name %s\n", reg.ID, reg.Name)
+
+		return reg.ID, nil
+	}
+
 	username, err := utils.PromptPlaintext(fmt.Sprintf(`Helm repo username (press enter for a public registry):`))
 
 	if err != nil {
@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// cpCmd represents the "porter cp" base command when called
+// without any subcommands
+var cpCmd = &cobra.Command{
+	Use:   "cp [release]:[remote path] [local path] | [local path] [release]:[remote path]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Copies files and directories to and from a container of a release.",
+	Long: fmt.Sprintf(`
+%s
+
+Copies files and directories between your machine and a running container of a release.
+The container must have the tar binary installed.
+
+To copy a file or directory out of the container, pass the release and the path in the
+container first:
+
+  %s
+
+To copy a file or directory into the container, pass the local path first:
+
+  %s
+
+If the release has more than one pod, the first pod is used unless --existing_pod is set, in
+which case you are prompted for the pod.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter cp\":"),
+		color.GreenString("porter cp web:/app/logs ./logs"),
+		color.GreenString("porter cp ./seed.sql web:/tmp/seed.sql --container web"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, cp)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cpCmd)
+
+	cpCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"namespace of release to connect to",
+	)
+
+	cpCmd.PersistentFlags().BoolVarP(
+		&existingPod,
+		"existing_pod",
+		"e",
+		false,
+		"whether to prompt for the pod to copy to or from",
+	)
+
+	cpCmd.PersistentFlags().StringVarP(
+		&containerName,
+		"container",
+		"c",
+		"",
+		"name of the container to copy to or from, for pods with more than one container",
+	)
+}
+
+func cp(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	srcRelease, srcPath, srcIsRemote := parseCopyPath(args[0])
+	dstRelease, dstPath, dstIsRemote := parseCopyPath(args[1])
+
+	if srcIsRemote == dstIsRemote {
+		return fmt.Errorf("exactly one of the paths must be in the form [release]:[path]")
+	}
+
+	releaseName := srcRelease
+
+	if dstIsRemote {
+		releaseName = dstRelease
+	}
+
+	podsSimple, err := getPods(client, namespace, releaseName)
+
+	if err != nil {
+		return fmt.Errorf("Could not retrieve list of pods: %s", err.Error())
+	}
+
+	selectedPod, selectedContainerName, err := selectPodAndContainer(podsSimple, existingPod, containerName)
+
+	if err != nil {
+		return err
+	}
+
+	config := &PorterRunSharedConfig{
+		Client: client,
+	}
+
+	err = config.setSharedConfig()
+
+	if err != nil {
+		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
+	}
+
+	if srcIsRemote {
+		return copyFromPod(config, namespace, selectedPod.Name, selectedContainerName, srcPath, dstPath)
+	}
+
+	return copyToPod(config, namespace, selectedPod.Name, selectedContainerName, srcPath, dstPath)
+}
+
+// parseCopyPath splits a path of the form [release]:[path]. Local paths that contain a
+// colon can be passed with a leading ./ or as an absolute path.
+func parseCopyPath(arg string) (string, string, bool) {
+	if strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "/") || filepath.IsAbs(arg) {
+		return "", arg, false
+	}
+
+	idx := strings.Index(arg, ":")
+
+	if idx <= 0 {
+		return "", arg, false
+	}
+
+	return arg[:idx], arg[idx+1:], true
+}
+
+// copyFromPod streams a tar archive of the remote path out of the container and extracts it
+// to the local path. If the local path is an existing directory, the remote file or directory
+// is copied into it.
+func copyFromPod(config *PorterRunSharedConfig, namespace, name, container, remotePath, localPath string) error {
+	remotePath = path.Clean(remotePath)
+	remoteDir, remoteBase := path.Split(remotePath)
+
+	if remoteBase == "" || remoteBase == "." {
+		return fmt.Errorf("%s is not a file or directory that can be copied", remotePath)
+	}
+
+	if remoteDir == "" {
+		remoteDir = "."
+	}
+
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		localPath = filepath.Join(localPath, remoteBase)
+	}
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	var stderr bytes.Buffer
+
+	go func() {
+		err := execInPod(
+			config, namespace, name, container,
+			[]string{"tar", "cf", "-", "-C", remoteDir, remoteBase},
+			nil, writer, &stderr,
+		)
+
+		if err != nil && stderr.Len() > 0 {
+			err = fmt.Errorf("%s: %s", err.Error(), strings.TrimSpace(stderr.String()))
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return untarToLocal(reader, remoteBase, localPath)
+}
+
+// copyToPod streams a tar archive of the local path into the container and extracts it at the
+// remote path. If the remote path ends with a slash, the local file or directory is copied
+// into that directory.
+func copyToPod(config *PorterRunSharedConfig, namespace, name, container, localPath, remotePath string) error {
+	if _, err := os.Stat(localPath); err != nil {
+		return err
+	}
+
+	remoteDir, remoteBase := path.Split(remotePath)
+
+	if remoteBase == "" {
+		remoteBase = filepath.Base(localPath)
+	}
+
+	if remoteDir == "" {
+		remoteDir = "."
+	}
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	go func() {
+		writer.CloseWithError(tarFromLocal(writer, localPath, remoteBase))
+	}()
+
+	var stderr bytes.Buffer
+
+	err := execInPod(
+		config, namespace, name, container,
+		[]string{"tar", "xf", "-", "-C", remoteDir},
+		reader, os.Stdout, &stderr,
+	)
+
+	if err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("%s: %s", err.Error(), strings.TrimSpace(stderr.String()))
+	}
+
+	return err
+}
+
+// execInPod runs a command in a container without a TTY
+func execInPod(
+	config *PorterRunSharedConfig,
+	namespace, name, container string,
+	command []string,
+	stdin io.Reader,
+	stdout, stderr io.Writer,
+) error {
+	req := config.RestClient.Post().
+		Resource("pods").
+		Name(name).
+		Namespace(namespace).
+		SubResource("exec")
+
+	for _, arg := range command {
+		req.Param("command", arg)
+	}
+
+	req.Param("stdin", fmt.Sprintf("%t", stdin != nil))
+	req.Param("stdout", "true")
+	req.Param("stderr", "true")
+	req.Param("tty", "false")
+	req.Param("container", container)
+
+	exec, err := remotecommand.NewSPDYExecutor(config.RestConf, "POST", req.URL())
+
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// tarFromLocal writes the local file or directory to a tar archive, with its root renamed
+// to rootName
+func tarFromLocal(w io.Writer, localPath, rootName string) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.Walk(localPath, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(localPath, file)
+
+		if err != nil {
+			return err
+		}
+
+		link := ""
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+
+		if err != nil {
+			return err
+		}
+
+		header.Name = path.Join(rootName, filepath.ToSlash(rel))
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// untarToLocal extracts a tar archive whose root is rootName to localPath. Entries that
+// would be written outside of localPath are rejected.
+func untarToLocal(r io.Reader, rootName, localPath string) error {
+	tr := tar.NewReader(r)
+	extracted := false
+
+	for {
+		header, err := tr.Next()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		rel := strings.TrimPrefix(path.Clean(header.Name), rootName)
+
+		if rel != "" && !strings.HasPrefix(rel, "/") {
+			return fmt.Errorf("unexpected file %s in archive", header.Name)
+		}
+
+		target := filepath.Join(localPath, filepath.FromSlash(rel))
+
+		if target != filepath.Clean(localPath) &&
+			!strings.HasPrefix(target, filepath.Clean(localPath)+string(os.PathSeparator)) {
+			return fmt.Errorf("file %s would be written outside of %s", header.Name, localPath)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode)&os.ModePerm)
+
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(f, tr)
+			f.Close()
+
+			if err != nil {
+				return err
+			}
+		default:
+			// symlinks and special files are skipped, since they may point outside of
+			// the destination
+			color.New(color.FgYellow).Fprintf(os.Stderr, "Skipping %s: only files and directories are copied\n", header.Name)
+			continue
+		}
+
+		extracted = true
+	}
+
+	if !extracted {
+		return fmt.Errorf("nothing was copied")
+	}
+
+	return nil
+}
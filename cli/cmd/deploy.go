@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
@@ -13,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/cli/cmd/deploy"
 	"github.com/porter-dev/porter/cli/cmd/utils"
 	templaterUtils "github.com/porter-dev/porter/internal/templater/utils"
+	"github.com/porter-dev/porter/internal/webhook"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/util/homedir"
 )
@@ -203,6 +206,76 @@ the image that the application uses if no --values file is specified:
 	},
 }
 
+var updateAutoRollbackCmd = &cobra.Command{
+	Use:   "auto-rollback",
+	Short: "Enables automatic rollback for an application specified by the --app flag.",
+	Long: fmt.Sprintf(`
+%s
+
+Enables automatic rollback for an application specified by the --app flag. When enabled, Porter
+waits for the application to become ready after each upgrade, and rolls it back to the previous
+version if it is not ready within the timeout given by the --timeout flag. For example:
+
+  %s
+
+To disable automatic rollback, pass the --disable flag:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update auto-rollback\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update auto-rollback --app example-app --timeout 10m"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update auto-rollback --app example-app --disable"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, updateAutoRollback)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var updateWebhookSecretCmd = &cobra.Command{
+	Use:   "webhook-secret",
+	Short: "Configures the signing secret of the deploy webhook for an application specified by the --app flag.",
+	Long: fmt.Sprintf(`
+%s
+
+Configures the signing secret of the deploy webhook for an application specified by the --app
+flag, and prints the secret. Requests to the webhook can be signed by sending an %s header
+of the form "t=<unix timestamp>,v1=<signature>", where the signature is the hex-encoded
+HMAC-SHA256 of "<unix timestamp>.<request body>" keyed by the secret. Signed requests must
+send the commit in the request body, and are rejected if they are older than five minutes.
+
+To generate a new secret, pass the --rotate flag. The previous secret stops working immediately:
+
+  %s
+
+To reject requests which are not signed, pass the --require flag:
+
+  %s
+
+To accept unsigned requests again, pass --require=false:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update webhook-secret\":"),
+		webhook.SignatureHeader,
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update webhook-secret --app example-app --rotate"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update webhook-secret --app example-app --require"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update webhook-secret --app example-app --require=false"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		webhookSecretRequireSet = cmd.Flags().Changed("require")
+
+		err := checkLoginAndRun(args, updateWebhookSecret)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
 var app string
 var getEnvFileDest string
 var localPath string
@@ -213,6 +286,11 @@ var stream bool
 var buildFlagsEnv []string
 var forcePush bool
 var useCache bool
+var autoRollbackDisable bool
+var autoRollbackTimeout time.Duration
+var webhookSecretRotate bool
+var webhookSecretRequire bool
+var webhookSecretRequireSet bool
 
 func init() {
 	buildFlagsEnv = []string{}
@@ -332,6 +410,37 @@ func init() {
 	updateCmd.AddCommand(updateBuildCmd)
 	updateCmd.AddCommand(updatePushCmd)
 	updateCmd.AddCommand(updateConfigCmd)
+	updateCmd.AddCommand(updateAutoRollbackCmd)
+
+	updateAutoRollbackCmd.PersistentFlags().BoolVar(
+		&autoRollbackDisable,
+		"disable",
+		false,
+		"disable automatic rollback",
+	)
+
+	updateAutoRollbackCmd.PersistentFlags().DurationVar(
+		&autoRollbackTimeout,
+		"timeout",
+		time.Duration(types.DefaultAutoRollbackTimeoutSeconds)*time.Second,
+		"the time that the application is given to become ready after an upgrade, between 30s and 1h",
+	)
+
+	updateCmd.AddCommand(updateWebhookSecretCmd)
+
+	updateWebhookSecretCmd.PersistentFlags().BoolVar(
+		&webhookSecretRotate,
+		"rotate",
+		false,
+		"generate a new signing secret",
+	)
+
+	updateWebhookSecretCmd.PersistentFlags().BoolVar(
+		&webhookSecretRequire,
+		"require",
+		false,
+		"reject deploy webhook requests which are not signed",
+	)
 }
 
 func updateFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -438,6 +547,84 @@ func updateUpgrade(_ *types.GetAuthenticatedUserResponse, client *api.Client, ar
 	return updateUpgradeWithAgent(updateAgent)
 }
 
+func updateAutoRollback(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.UpdateAutoRollback(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		app,
+		namespace,
+		&types.UpdateAutoRollbackRequest{
+			Enabled:        !autoRollbackDisable,
+			TimeoutSeconds: uint(autoRollbackTimeout.Seconds()),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.Enabled {
+		color.New(color.FgGreen).Printf(
+			"Enabled automatic rollback for %s, with a timeout of %s\n",
+			app,
+			time.Duration(resp.TimeoutSeconds)*time.Second,
+		)
+	} else {
+		color.New(color.FgGreen).Printf("Disabled automatic rollback for %s\n", app)
+	}
+
+	return nil
+}
+
+func updateWebhookSecret(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req := &types.CreateWebhookRequest{
+		RotateSigningSecret: webhookSecretRotate,
+	}
+
+	if webhookSecretRequireSet {
+		req.RequireSignature = &webhookSecretRequire
+	} else if !webhookSecretRotate {
+		// generate a secret if the webhook does not have one yet, without changing
+		// whether signatures are required
+		existing, err := client.GetReleaseWebhook(context.Background(), cliConf.Project, cliConf.Cluster, app, namespace)
+
+		if err == nil && existing.WebhookSigningSecret != "" {
+			printWebhookSecret(existing)
+			return nil
+		}
+
+		req.RequireSignature = &webhookSecretRequire
+	}
+
+	resp, err := client.CreateReleaseWebhook(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		app,
+		namespace,
+		req,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	printWebhookSecret(resp)
+
+	return nil
+}
+
+func printWebhookSecret(release *types.PorterRelease) {
+	fmt.Printf("Signing secret: %s\n", release.WebhookSigningSecret)
+
+	if release.WebhookSignatureRequired {
+		color.New(color.FgGreen).Printf("Unsigned deploy webhook requests for %s are rejected\n", app)
+	} else {
+		color.New(color.FgYellow).Printf("Unsigned deploy webhook requests for %s are accepted\n", app)
+	}
+}
+
 // HELPER METHODS
 func updateGetAgent(client *api.Client) (*deploy.DeployAgent, error) {
 	var buildMethod deploy.DeployBuildType
@@ -345,24 +345,77 @@ func (d *DeployAgent) Push() error {
 // reuses the configuration set for the application. If overrideValues is not nil,
 // it will merge the overriding values with the existing configuration.
 func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}) error {
+	mergedValues, err := mergeReleaseValues(d.Release, d.tag, overrideValues)
+
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(mergedValues)
+
+	if err != nil {
+		return err
+	}
+
+	return d.Client.UpgradeRelease(
+		context.Background(),
+		d.Opts.ProjectID,
+		d.Opts.ClusterID,
+		d.Release.Namespace,
+		d.Release.Name,
+		&types.UpgradeReleaseRequest{
+			Values: string(bytes),
+		},
+	)
+}
+
+// GetUpdatedValues returns the values that a DeployAgent created with the given options would
+// upgrade the release with in UpdateImageAndValues, without building or pushing an image. Note
+// that the config of the release is modified in place.
+func GetUpdatedValues(
+	client *client.Client,
+	release *types.GetReleaseResponse,
+	opts *SharedOpts,
+	overrideValues map[string]interface{},
+) (map[string]interface{}, error) {
+	err := coalesceEnvGroups(client, opts.ProjectID, opts.ClusterID, opts.Namespace, opts.EnvGroups, release.Config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeReleaseValues(release, opts.OverrideTag, overrideValues)
+}
+
+// mergeReleaseValues merges the overriding values into the config of a release and sets the
+// image tag of the release
+func mergeReleaseValues(
+	release *types.GetReleaseResponse,
+	tag string,
+	overrideValues map[string]interface{},
+) (map[string]interface{}, error) {
+	if overrideValues == nil {
+		overrideValues = make(map[string]interface{})
+	}
+
 	// if this is a job chart, set "paused" to false so that the job doesn't run, unless
 	// the user has explicitly overriden the "paused" field
-	if _, exists := overrideValues["paused"]; d.Release.Chart.Name() == "job" && !exists {
+	if _, exists := overrideValues["paused"]; release.Chart.Name() == "job" && !exists {
 		overrideValues["paused"] = true
 	}
 
-	mergedValues := utils.CoalesceValues(d.Release.Config, overrideValues)
+	mergedValues := utils.CoalesceValues(release.Config, overrideValues)
 
 	activeBlueGreenTagVal := GetCurrActiveBlueGreenImage(mergedValues)
 
 	// only overwrite if the active tag value is not the same as the target tag. otherwise
 	// this has been modified already and inserted into overrideValues.
-	if activeBlueGreenTagVal != "" && activeBlueGreenTagVal != d.tag {
+	if activeBlueGreenTagVal != "" && activeBlueGreenTagVal != tag {
 		mergedValues["bluegreen"] = map[string]interface{}{
 			"enabled":                  true,
 			"disablePrimaryDeployment": true,
 			"activeImageTag":           activeBlueGreenTagVal,
-			"imageTags":                []string{activeBlueGreenTagVal, d.tag},
+			"imageTags":                []string{activeBlueGreenTagVal, tag},
 		}
 	}
 
@@ -372,39 +425,23 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 	// if the current image section is hello-porter, the image must be overriden
 	if currImageSection["repository"] == "public.ecr.aws/o1j4x7p4/hello-porter" ||
 		currImageSection["repository"] == "public.ecr.aws/o1j4x7p4/hello-porter-job" {
-		newImage, err := d.getReleaseImage()
+		newImage, err := getReleaseImage(release)
 
 		if err != nil {
-			return fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
+			return nil, fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
 		}
 
 		currImageSection["repository"] = newImage
 
-		// set to latest just to be safe -- this will be overriden if "d.tag" is set in
-		// the agent
+		// set to latest just to be safe -- this will be overriden if "tag" is set
 		currImageSection["tag"] = "latest"
 	}
 
-	if d.tag != "" && currImageSection["tag"] != d.tag {
-		currImageSection["tag"] = d.tag
-	}
-
-	bytes, err := json.Marshal(mergedValues)
-
-	if err != nil {
-		return err
+	if tag != "" && currImageSection["tag"] != tag {
+		currImageSection["tag"] = tag
 	}
 
-	return d.Client.UpgradeRelease(
-		context.Background(),
-		d.Opts.ProjectID,
-		d.Opts.ClusterID,
-		d.Release.Namespace,
-		d.Release.Name,
-		&types.UpgradeReleaseRequest{
-			Values: string(bytes),
-		},
-	)
+	return mergedValues, nil
 }
 
 type SyncedEnvSection struct {
@@ -618,12 +655,16 @@ func GetSyncedEnv(
 }
 
 func (d *DeployAgent) getReleaseImage() (string, error) {
-	if d.Release.ImageRepoURI != "" {
-		return d.Release.ImageRepoURI, nil
+	return getReleaseImage(d.Release)
+}
+
+func getReleaseImage(release *types.GetReleaseResponse) (string, error) {
+	if release.ImageRepoURI != "" {
+		return release.ImageRepoURI, nil
 	}
 
 	// get the image from the conig
-	imageConfig, err := GetNestedMap(d.Release.Config, "image")
+	imageConfig, err := GetNestedMap(release.Config, "image")
 
 	if err != nil {
 		return "", fmt.Errorf("could not get image config from release: %s", err.Error())
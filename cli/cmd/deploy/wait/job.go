@@ -39,7 +39,7 @@ func WaitForJob(client *api.Client, opts *WaitOpts) error {
 
 	// attempt to parse out the timeout value for the job, given by `sidecar.timeout`
 	// if it does not exist, we set the default to 30 minutes
-	timeoutVal := getJobTimeoutValue(jobRelease.Release.Config)
+	timeoutVal := GetJobTimeoutValue(jobRelease.Release.Config)
 
 	color.New(color.FgYellow).Printf("Waiting for timeout seconds %.1f\n", timeoutVal.Seconds())
 
@@ -54,7 +54,7 @@ func WaitForJob(client *api.Client, opts *WaitOpts) error {
 			return err
 		}
 
-		job := getJobMatchingRevision(uint(jobRelease.Release.Version), jobs)
+		job := GetJobMatchingRevision(uint(jobRelease.Release.Version), jobs)
 
 		if job == nil {
 			time.Sleep(10 * time.Second)
@@ -78,7 +78,9 @@ func WaitForJob(client *api.Client, opts *WaitOpts) error {
 	return fmt.Errorf("timed out waiting for job")
 }
 
-func getJobMatchingRevision(revision uint, jobs []v1.Job) *v1.Job {
+// GetJobMatchingRevision returns the job that was created by the given revision of a job
+// release, or nil if the job has not been created yet
+func GetJobMatchingRevision(revision uint, jobs []v1.Job) *v1.Job {
 	for _, job := range jobs {
 		revisionLabel, revisionLabelExists := job.Labels["helm.sh/revision"]
 
@@ -100,7 +102,9 @@ func getJobMatchingRevision(revision uint, jobs []v1.Job) *v1.Job {
 	return nil
 }
 
-func getJobTimeoutValue(values map[string]interface{}) time.Duration {
+// GetJobTimeoutValue returns the timeout of a job release given by `sidecar.timeout`,
+// defaulting to 60 minutes
+func GetJobTimeoutValue(values map[string]interface{}) time.Duration {
 	defaultTimeout := time.Minute * 60
 	sidecarInter, ok := values["sidecar"]
 
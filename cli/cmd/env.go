@@ -0,0 +1,961 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// envCmd represents the "porter env" base command when called
+// without any subcommands
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Commands that manage env groups",
+}
+
+var envListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the env groups in a namespace.",
+	Long: fmt.Sprintf(`
+%s
+
+Lists the env groups in a namespace with their latest version:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env list\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env list --namespace staging"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envList)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envGetCmd = &cobra.Command{
+	Use:   "get [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Prints the variables of an env group.",
+	Long: fmt.Sprintf(`
+%s
+
+Prints the variables of the latest version of an env group, along with the applications that
+are synced with it. The values of secret variables are not shown. To print an older version,
+use the --version flag:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env get\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env get my-env-group --version 3"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envGet)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envSetCmd = &cobra.Command{
+	Use:   "set [name] [KEY=value]...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Sets variables of an env group.",
+	Long: fmt.Sprintf(`
+%s
+
+Sets one or more variables of an env group, creating a new version of the env group. The env
+group is created if it does not exist yet:
+
+  %s
+
+Pass --secret to store the variables as secret variables. Variables that are already secret
+stay secret. By default, the applications that are synced with the env group keep using the
+previous version until they are redeployed. Pass --sync to redeploy them with the new version:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env set\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env set my-env-group LOG_LEVEL=debug WORKERS=4"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env set my-env-group DB_PASSWORD=hunter2 --secret --sync"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envSet)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envUnsetCmd = &cobra.Command{
+	Use:   "unset [name] [KEY]...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Removes variables from an env group.",
+	Long: fmt.Sprintf(`
+%s
+
+Removes one or more variables from an env group, creating a new version of the env group.
+Pass --sync to redeploy the applications that are synced with the env group:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env unset\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env unset my-env-group LOG_LEVEL --sync"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envUnset)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envImportCmd = &cobra.Command{
+	Use:   "import [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Imports variables into an env group from a .env, JSON or YAML file.",
+	Long: fmt.Sprintf(`
+%s
+
+Sets the variables from a file in an env group, creating a new version of the env group.
+Variables that are not in the file are kept. The env group is created if it does not exist yet:
+
+  %s
+
+The format of the file is inferred from its extension, and files without a .json, .yaml or .yml
+extension are read as .env files. Use the --format flag to set the format explicitly. JSON and
+YAML files should contain a single object of variables.
+
+Pass --secret to store the imported variables as secret variables, and --sync to redeploy the
+applications that are synced with the env group:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env import\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env import my-env-group -f .env"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env import my-env-group -f secrets.json --secret --sync"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envImport)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envExportCmd = &cobra.Command{
+	Use:   "export [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Exports the variables of an env group as a .env, JSON or YAML file.",
+	Long: fmt.Sprintf(`
+%s
+
+Writes the variables of an env group to stdout, or to a file with the --file flag. The format
+is set by the --format flag, or inferred from the extension of the file, and defaults to .env:
+
+  %s
+
+The values of secret variables cannot be read from the API, so secret variables are left out
+of the export. Their names are printed to stderr.
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env export\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env export my-env-group --format yaml > env.yaml"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envExport)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envDiffCmd = &cobra.Command{
+	Use:   "diff [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Shows the variables that changed between two versions of an env group.",
+	Long: fmt.Sprintf(`
+%s
+
+Shows the variables that were added, removed or changed between two versions of an env group.
+The values of secret variables are never shown. If --to is not set, the version is compared
+against the latest version of the env group:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env diff\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env diff my-env-group --from 3 --to 4"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envDiff)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envRollbackCmd = &cobra.Command{
+	Use:   "rollback [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Restores the variables of an older version of an env group.",
+	Long: fmt.Sprintf(`
+%s
+
+Creates a new version of an env group with the variables of an older version, including its
+secret variables. The applications that are synced with the env group are redeployed with the
+new version:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env rollback\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env rollback my-env-group --version 3"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envRollback)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envSourcesCmd = &cobra.Command{
+	Use:   "sources [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Lists or replaces the external secret manager sources of an env group.",
+	Long: fmt.Sprintf(`
+%s
+
+Lists the secrets in external secret managers that are synced into the secret variables of an
+env group, with the external version and the result of the last sync:
+
+  %s
+
+To replace the sources of an env group, pass a file with the list of sources. The sources are
+synced right away, and the applications that are synced with the env group are redeployed if
+a value changed:
+
+  %s
+
+Each source sets the variable "key" from a secret read with the project integration
+"integration_id". The "kind" of a source is one of aws_secrets_manager, gcp_secret_manager
+or vault, and "path" is the name or ARN of an AWS secret, the resource name of a GCP secret
+or the path of a Vault KV secret, like secret/data/db. Set "field" to read a key of a secret
+that stores a JSON object, which is required for Vault secrets:
+
+  - key: DB_PASSWORD
+    kind: aws_secrets_manager
+    integration_id: 2
+    path: prod/db
+    field: password
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env sources\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env sources my-env-group"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env sources my-env-group -f sources.yaml"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envSources)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envSyncCmd = &cobra.Command{
+	Use:   "sync [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Syncs an env group with its external secret manager sources.",
+	Long: fmt.Sprintf(`
+%s
+
+Reads the secrets that an env group is sourced from and writes their values to the env group.
+Env groups are also synced on a schedule, so this command is only needed to pick up a change
+right away. If a value changed, a new version of the env group is created and the applications
+that are synced with the env group are redeployed:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env sync\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env sync my-env-group"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envSync)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envSourcesFile string
+
+var envVarOpts struct {
+	version uint
+	file    string
+	format  string
+	secret  bool
+	sync    bool
+}
+
+var envVersionOpts struct {
+	from    uint
+	to      uint
+	version uint
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+
+	envCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"namespace of the env group",
+	)
+
+	envCmd.AddCommand(envListCmd)
+	envCmd.AddCommand(envGetCmd)
+
+	envGetCmd.Flags().UintVar(
+		&envVarOpts.version,
+		"version",
+		0,
+		"the version to print, which defaults to the latest version",
+	)
+
+	envCmd.AddCommand(envSetCmd)
+
+	envSetCmd.Flags().BoolVar(
+		&envVarOpts.secret,
+		"secret",
+		false,
+		"store the variables as secret variables",
+	)
+
+	envCmd.AddCommand(envUnsetCmd)
+	envCmd.AddCommand(envImportCmd)
+
+	envImportCmd.Flags().StringVarP(
+		&envVarOpts.file,
+		"file",
+		"f",
+		"",
+		"path to the file to import",
+	)
+
+	envImportCmd.MarkFlagRequired("file")
+
+	envImportCmd.Flags().BoolVar(
+		&envVarOpts.secret,
+		"secret",
+		false,
+		"store the imported variables as secret variables",
+	)
+
+	for _, cmd := range []*cobra.Command{envSetCmd, envUnsetCmd, envImportCmd} {
+		cmd.Flags().BoolVar(
+			&envVarOpts.sync,
+			"sync",
+			false,
+			"redeploy the applications that are synced with the env group",
+		)
+	}
+
+	envCmd.AddCommand(envExportCmd)
+
+	envExportCmd.Flags().StringVarP(
+		&envVarOpts.file,
+		"file",
+		"f",
+		"",
+		"path to the file to write, which defaults to stdout",
+	)
+
+	for _, cmd := range []*cobra.Command{envImportCmd, envExportCmd} {
+		cmd.Flags().StringVar(
+			&envVarOpts.format,
+			"format",
+			"",
+			"the format of the file: env, json or yaml",
+		)
+	}
+
+	envCmd.AddCommand(envDiffCmd)
+
+	envDiffCmd.Flags().UintVar(
+		&envVersionOpts.from,
+		"from",
+		0,
+		"the version to compare from",
+	)
+
+	envDiffCmd.Flags().UintVar(
+		&envVersionOpts.to,
+		"to",
+		0,
+		"the version to compare to, which defaults to the latest version",
+	)
+
+	envDiffCmd.MarkFlagRequired("from")
+
+	envCmd.AddCommand(envRollbackCmd)
+
+	envRollbackCmd.Flags().UintVar(
+		&envVersionOpts.version,
+		"version",
+		0,
+		"the version whose variables are restored",
+	)
+
+	envRollbackCmd.MarkFlagRequired("version")
+
+	envCmd.AddCommand(envSourcesCmd)
+
+	envSourcesCmd.Flags().StringVarP(
+		&envSourcesFile,
+		"file",
+		"f",
+		"",
+		"path to a YAML or JSON file with the sources that replace the current sources",
+	)
+
+	envCmd.AddCommand(envSyncCmd)
+}
+
+func envList(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	envGroups, err := client.ListEnvGroups(context.Background(), cliConf.Project, cliConf.Cluster, namespace)
+
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(envGroups, func(i, j int) bool {
+		return envGroups[i].Name < envGroups[j].Name
+	})
+
+	return writeOutput(envGroups, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", "NAME", "VERSION", "CREATED")
+
+		for _, envGroup := range envGroups {
+			fmt.Fprintf(
+				w, "%s\t%d\t%s\n",
+				envGroup.Name, envGroup.Version, envGroup.CreatedAt.Local().Format(time.RFC822),
+			)
+		}
+
+		return w.Flush()
+	})
+}
+
+func envGet(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	envGroup, err := client.GetEnvGroup(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.GetEnvGroupRequest{
+			Name:    args[0],
+			Version: envVarOpts.version,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(envGroup, func() error {
+		fmt.Printf("Env group %s, version %d\n", envGroup.Name, envGroup.Version)
+
+		if len(envGroup.Applications) > 0 {
+			fmt.Printf("Synced applications: %s\n", strings.Join(envGroup.Applications, ", "))
+		}
+
+		fmt.Println()
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
+
+		fmt.Fprintf(w, "%s\t%s\n", "KEY", "VALUE")
+
+		for _, key := range sortedKeys(envGroup.Variables) {
+			val := envGroup.Variables[key]
+
+			if isSecretReference(val) {
+				val = "******** (secret)"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\n", key, val)
+		}
+
+		return w.Flush()
+	})
+}
+
+func envSet(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	variables := make(map[string]string)
+
+	for _, arg := range args[1:] {
+		spl := strings.SplitN(arg, "=", 2)
+
+		if len(spl) != 2 || spl[0] == "" {
+			return fmt.Errorf("variable %s should be in the form KEY=value", arg)
+		}
+
+		variables[spl[0]] = spl[1]
+	}
+
+	return updateEnvGroupVariables(client, args[0], variables, nil)
+}
+
+func envUnset(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	return updateEnvGroupVariables(client, args[0], nil, args[1:])
+}
+
+func envImport(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	format, err := envFileFormat(envVarOpts.format, envVarOpts.file)
+
+	if err != nil {
+		return err
+	}
+
+	fileBytes, err := ioutil.ReadFile(envVarOpts.file)
+
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+
+	variables, err := parseEnvFile(fileBytes, format)
+
+	if err != nil {
+		return err
+	}
+
+	if len(variables) == 0 {
+		return fmt.Errorf("no variables found in %s", envVarOpts.file)
+	}
+
+	return updateEnvGroupVariables(client, args[0], variables, nil)
+}
+
+func envExport(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	format, err := envFileFormat(envVarOpts.format, envVarOpts.file)
+
+	if err != nil {
+		return err
+	}
+
+	envGroup, err := client.GetEnvGroup(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.GetEnvGroupRequest{
+			Name: args[0],
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	variables := make(map[string]string)
+	secretKeys := make([]string, 0)
+
+	for _, key := range sortedKeys(envGroup.Variables) {
+		if val := envGroup.Variables[key]; isSecretReference(val) {
+			secretKeys = append(secretKeys, key)
+		} else {
+			variables[key] = val
+		}
+	}
+
+	if len(secretKeys) > 0 {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "Skipping secret variables: %s\n", strings.Join(secretKeys, ", "))
+	}
+
+	output, err := formatEnvFile(variables, format)
+
+	if err != nil {
+		return err
+	}
+
+	if envVarOpts.file == "" {
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+
+	return ioutil.WriteFile(envVarOpts.file, output, 0600)
+}
+
+// updateEnvGroupVariables creates a new version of an env group with variables set and keys
+// removed. Secret variables are sent as references to their current values, which the server
+// copies to the new version.
+func updateEnvGroupVariables(client *api.Client, name string, set map[string]string, unset []string) error {
+	current := make(map[string]string)
+
+	envGroup, err := client.GetEnvGroup(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.GetEnvGroupRequest{
+			Name: name,
+		},
+	)
+
+	if err != nil && err.Error() == "env group not found" && len(unset) == 0 {
+		envGroup = nil
+	} else if err != nil {
+		return err
+	} else {
+		current = envGroup.Variables
+	}
+
+	for _, key := range unset {
+		if _, ok := current[key]; !ok {
+			return fmt.Errorf("variable %s not found in env group %s", key, name)
+		}
+	}
+
+	removed := make(map[string]bool)
+	secretVariables := make(map[string]string)
+	changed := len(unset) > 0
+
+	for _, key := range unset {
+		removed[key] = true
+	}
+
+	for key, val := range set {
+		oldVal, exists := current[key]
+
+		if envVarOpts.secret || (exists && isSecretReference(oldVal)) {
+			// secret variables are moved out of the variables, which only hold references
+			secretVariables[key] = val
+			removed[key] = true
+			changed = true
+		} else if !exists || oldVal != val {
+			changed = true
+		}
+	}
+
+	variables := make(map[string]string)
+
+	for key, val := range current {
+		if !removed[key] {
+			variables[key] = val
+		}
+	}
+
+	for key, val := range set {
+		if _, ok := secretVariables[key]; !ok {
+			variables[key] = val
+		}
+	}
+
+	if envGroup != nil && !changed {
+		fmt.Printf("Env group %s is already up to date at version %d\n", name, envGroup.Version)
+		return nil
+	}
+
+	newEnvGroup, err := client.CreateEnvGroup(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.CreateEnvGroupRequest{
+			Name:            name,
+			Variables:       variables,
+			SecretVariables: secretVariables,
+			SkipRollout:     !envVarOpts.sync,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if envGroup == nil {
+		color.New(color.FgGreen).Printf("Created env group %s\n", newEnvGroup.Name)
+	} else {
+		color.New(color.FgGreen).Printf("Updated env group %s to version %d\n", newEnvGroup.Name, newEnvGroup.Version)
+	}
+
+	if len(newEnvGroup.Applications) > 0 {
+		if envVarOpts.sync {
+			fmt.Printf("Redeploying synced applications: %s\n", strings.Join(newEnvGroup.Applications, ", "))
+		} else {
+			fmt.Printf(
+				"Synced applications were not redeployed, run with --sync to redeploy them: %s\n",
+				strings.Join(newEnvGroup.Applications, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// isSecretReference returns true if a variable of an env group is a secret variable, whose
+// value is a reference to the secret that stores it
+func isSecretReference(val string) bool {
+	return strings.HasPrefix(val, "PORTERSECRET_")
+}
+
+func sortedKeys(variables map[string]string) []string {
+	keys := make([]string, 0, len(variables))
+
+	for key := range variables {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func envDiff(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	diff, err := client.GetEnvGroupDiff(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.GetEnvGroupDiffRequest{
+			Name: args[0],
+			From: envVersionOpts.from,
+			To:   envVersionOpts.to,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(diff, func() error {
+		fmt.Printf("Changes to env group %s from version %d to version %d:\n\n", diff.Name, diff.FromVersion, diff.ToVersion)
+
+		if len(diff.Added)+len(diff.Removed)+len(diff.Changed) == 0 {
+			fmt.Println("No variables changed")
+			return nil
+		}
+
+		for _, change := range diff.Added {
+			color.New(color.FgGreen).Printf("+ %s=%s%s\n", change.Key, change.NewValue, getSecretSuffix(change))
+		}
+
+		for _, change := range diff.Removed {
+			color.New(color.FgRed).Printf("- %s=%s%s\n", change.Key, change.OldValue, getSecretSuffix(change))
+		}
+
+		for _, change := range diff.Changed {
+			color.New(color.FgYellow).Printf("~ %s: %s => %s%s\n", change.Key, change.OldValue, change.NewValue, getSecretSuffix(change))
+		}
+
+		return nil
+	})
+}
+
+func getSecretSuffix(change *types.EnvGroupVariableChange) string {
+	if change.Secret {
+		return " (secret)"
+	}
+
+	return ""
+}
+
+func envRollback(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	envGroup, err := client.RollbackEnvGroup(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.RollbackEnvGroupRequest{
+			Name:    args[0],
+			Version: envVersionOpts.version,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Restored the variables of version %d of env group %s as version %d\n",
+		envVersionOpts.version, envGroup.Name, envGroup.Version)
+
+	if len(envGroup.Applications) > 0 {
+		fmt.Printf("Redeploying synced applications: %v\n", envGroup.Applications)
+	}
+
+	return nil
+}
+
+func envSources(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if envSourcesFile != "" {
+		fileBytes, err := ioutil.ReadFile(envSourcesFile)
+
+		if err != nil {
+			return fmt.Errorf("error reading sources file: %w", err)
+		}
+
+		sources := make([]*types.EnvGroupSourceInput, 0)
+
+		if err := yaml.Unmarshal(fileBytes, &sources); err != nil {
+			return fmt.Errorf("error parsing sources file: %w", err)
+		}
+
+		resp, err := client.UpdateEnvGroupSources(
+			context.Background(),
+			cliConf.Project,
+			cliConf.Cluster,
+			namespace,
+			&types.UpdateEnvGroupSourcesRequest{
+				Name:    args[0],
+				Sources: sources,
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		return writeOutput(resp, func() error {
+			printSyncResult(resp)
+			return nil
+		})
+	}
+
+	sources, err := client.ListEnvGroupSources(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.ListEnvGroupSourcesRequest{
+			Name: args[0],
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(sources, func() error {
+		return printEnvGroupSources(sources)
+	})
+}
+
+func envSync(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.SyncEnvGroup(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		&types.SyncEnvGroupRequest{
+			Name: args[0],
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(resp, func() error {
+		printSyncResult(resp)
+		return nil
+	})
+}
+
+func printSyncResult(resp *types.SyncEnvGroupResponse) {
+	if resp.Updated {
+		color.New(color.FgGreen).Printf("Synced env group %s as version %d\n", resp.EnvGroup.Name, resp.EnvGroup.Version)
+
+		if len(resp.EnvGroup.Applications) > 0 {
+			fmt.Printf("Redeploying synced applications: %v\n", resp.EnvGroup.Applications)
+		}
+	} else {
+		fmt.Printf("Env group %s is up to date at version %d\n", resp.EnvGroup.Name, resp.EnvGroup.Version)
+	}
+
+	for _, source := range resp.Sources {
+		if source.LastSyncError != "" {
+			color.New(color.FgRed).Fprintf(os.Stderr, "Could not sync %s: %s\n", source.Key, source.LastSyncError)
+		}
+	}
+}
+
+func printEnvGroupSources(sources types.ListEnvGroupSourcesResponse) error {
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "KEY", "KIND", "PATH", "VERSION", "LAST SYNCED", "ERROR")
+
+	for _, source := range sources {
+		path := source.Path
+
+		if source.Field != "" {
+			path = fmt.Sprintf("%s#%s", source.Path, source.Field)
+		}
+
+		lastSynced := ""
+
+		if source.LastSyncedAt != nil {
+			lastSynced = source.LastSyncedAt.Local().Format(time.RFC822)
+		}
+
+		fmt.Fprintf(
+			w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			source.Key, source.Kind, path, source.ExternalVersion, lastSynced, source.LastSyncError,
+		)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// envFileFormat returns the format of an env file, which is set by the --format flag or
+// inferred from the extension of the file. Files without a known extension are .env files.
+func envFileFormat(format, path string) (string, error) {
+	if format != "" {
+		switch format {
+		case "env", "json", "yaml":
+			return format, nil
+		default:
+			return "", fmt.Errorf("format should be \"env\", \"json\" or \"yaml\"")
+		}
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json", nil
+	case ".yaml", ".yml":
+		return "yaml", nil
+	default:
+		return "env", nil
+	}
+}
+
+// parseEnvFile parses the variables of an env file in the given format
+func parseEnvFile(data []byte, format string) (map[string]string, error) {
+	if format == "env" {
+		return parseDotEnv(data)
+	}
+
+	// JSON is valid YAML, so both formats are read as a YAML object
+	raw := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing %s file: %w", format, err)
+	}
+
+	res := make(map[string]string)
+
+	for key, val := range raw {
+		switch v := val.(type) {
+		case string:
+			res[key] = v
+		case nil:
+			res[key] = ""
+		case float64:
+			// numbers are decoded as floats, which should not be written in exponent form
+			res[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("value of %s should be a string, number or boolean", key)
+		default:
+			res[key] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return res, nil
+}
+
+// parseDotEnv parses the KEY=value lines of a .env file. Blank lines, comments and an
+// "export " prefix are ignored. Values may be wrapped in single quotes, which are read
+// literally, or in double quotes, which support \n, \", and \\ escapes.
+func parseDotEnv(data []byte) (map[string]string, error) {
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		spl := strings.SplitN(line, "=", 2)
+
+		if len(spl) != 2 || strings.TrimSpace(spl[0]) == "" {
+			return nil, fmt.Errorf("line %d of .env file should be in the form KEY=value", lineNum)
+		}
+
+		key := strings.TrimSpace(spl[0])
+		val, err := parseDotEnvValue(strings.TrimSpace(spl[1]))
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d of .env file: %w", lineNum, err)
+		}
+
+		res[key] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func parseDotEnvValue(val string) (string, error) {
+	if len(val) == 0 {
+		return val, nil
+	}
+
+	switch val[0] {
+	case '\'':
+		end := strings.Index(val[1:], "'")
+
+		if end == -1 {
+			return "", fmt.Errorf("unterminated single quote")
+		}
+
+		return val[1 : end+1], nil
+	case '"':
+		var sb strings.Builder
+
+		for i := 1; i < len(val); i++ {
+			switch val[i] {
+			case '"':
+				return sb.String(), nil
+			case '\\':
+				if i+1 < len(val) {
+					i++
+
+					switch val[i] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 'r':
+						sb.WriteByte('\r')
+					case 't':
+						sb.WriteByte('\t')
+					default:
+						sb.WriteByte(val[i])
+					}
+
+					continue
+				}
+
+				sb.WriteByte(val[i])
+			default:
+				sb.WriteByte(val[i])
+			}
+		}
+
+		return "", fmt.Errorf("unterminated double quote")
+	}
+
+	// unquoted values end at an inline comment
+	if idx := strings.Index(val, " #"); idx != -1 {
+		val = strings.TrimSpace(val[:idx])
+	}
+
+	return val, nil
+}
+
+// formatEnvFile writes variables in the given format, sorted by key
+func formatEnvFile(variables map[string]string, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		res, err := json.MarshalIndent(variables, "", "  ")
+
+		if err != nil {
+			return nil, err
+		}
+
+		return append(res, '\n'), nil
+	case "yaml":
+		return yaml.Marshal(variables)
+	}
+
+	var buf bytes.Buffer
+
+	for _, key := range sortedKeys(variables) {
+		fmt.Fprintf(&buf, "%s=%s\n", key, formatDotEnvValue(variables[key]))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// formatDotEnvValue quotes a value if it would not be read back as the same value
+func formatDotEnvValue(val string) string {
+	if val != "" && !strings.ContainsAny(val, " \t\n\r\"'\\#=$`") {
+		return val
+	}
+
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		"\"", "\\\"",
+		"\n", "\\n",
+		"\r", "\\r",
+		"\t", "\\t",
+	)
+
+	return fmt.Sprintf("\"%s\"", replacer.Replace(val))
+}
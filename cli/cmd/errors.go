@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"os"
 	"strings"
 
 	"github.com/fatih/color"
@@ -23,16 +24,16 @@ func checkLoginAndRun(args []string, runner func(user *types.GetAuthenticatedUse
 		red := color.New(color.FgRed)
 
 		if strings.Contains(err.Error(), "Forbidden") {
-			red.Print("You are not logged in. Log in using \"porter auth login\"\n")
+			red.Fprint(os.Stderr, "You are not logged in. Log in using \"porter auth login\"\n")
 			return ErrNotLoggedIn
 		} else if strings.Contains(err.Error(), "connection refused") {
-			red.Printf("Unable to connect to the Porter server at %s\n", cliConf.Host)
-			red.Print("To set a different host, run \"porter config set-host [HOST]\"\n")
-			red.Print("To start a local server, run \"porter server start\"\n")
+			red.Fprintf(os.Stderr, "Unable to connect to the Porter server at %s\n", cliConf.Host)
+			red.Fprint(os.Stderr, "To set a different host, run \"porter config set-host [HOST]\"\n")
+			red.Fprint(os.Stderr, "To start a local server, run \"porter server start\"\n")
 			return ErrCannotConnect
 		}
 
-		red.Printf("Error: %v\n", err.Error())
+		red.Fprintf(os.Stderr, "Error: %v\n", err.Error())
 		return err
 	}
 
@@ -42,16 +43,16 @@ func checkLoginAndRun(args []string, runner func(user *types.GetAuthenticatedUse
 		red := color.New(color.FgRed)
 
 		if strings.Contains(err.Error(), "403") {
-			red.Print("You do not have the necessary permissions to view this resource")
-			return nil
+			red.Fprint(os.Stderr, "You do not have the necessary permissions to view this resource\n")
+			return err
 		} else if strings.Contains(err.Error(), "connection refused") {
-			red.Printf("Unable to connect to the Porter server at %s\n", cliConf.Host)
-			red.Print("To set a different host, run \"porter config set-host [HOST]\"")
-			red.Print("To start a local server, run \"porter server start\"")
-			return nil
+			red.Fprintf(os.Stderr, "Unable to connect to the Porter server at %s\n", cliConf.Host)
+			red.Fprint(os.Stderr, "To set a different host, run \"porter config set-host [HOST]\"\n")
+			red.Fprint(os.Stderr, "To start a local server, run \"porter server start\"\n")
+			return ErrCannotConnect
 		}
 
-		red.Printf("Error: %v\n", err.Error())
+		red.Fprintf(os.Stderr, "Error: %v\n", err.Error())
 		return err
 	}
 
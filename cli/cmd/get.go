@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 
@@ -42,8 +41,6 @@ var getValuesCmd = &cobra.Command{
 	},
 }
 
-var output string
-
 func init() {
 	getCmd.PersistentFlags().StringVar(
 		&namespace,
@@ -52,23 +49,22 @@ func init() {
 		"the namespace of the release",
 	)
 
-	getCmd.PersistentFlags().StringVar(
-		&output,
-		"output",
-		"",
-		"the output format to use (\"yaml\" or \"json\")",
-	)
-
 	getCmd.AddCommand(getValuesCmd)
 
 	rootCmd.AddCommand(getCmd)
 }
 
+// getReleaseInfo is the output of "porter get". Scripts depend on its field names, so new
+// fields are added instead of changing existing ones. Name and Namespace have always been
+// capitalized in the json output and lowercase in the yaml output.
 type getReleaseInfo struct {
-	Name         string
-	Namespace    string
+	Name         string    `json:"Name" yaml:"name"`
+	Namespace    string    `json:"Namespace" yaml:"namespace"`
 	LastDeployed time.Time `json:"last_deployed" yaml:"last_deployed"`
 	ReleaseType  string    `json:"release_type" yaml:"release_type"`
+	Revision     int       `json:"revision" yaml:"revision"`
+	Status       string    `json:"status" yaml:"status"`
+	ChartVersion string    `json:"chart_version" yaml:"chart_version"`
 }
 
 func get(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -83,32 +79,33 @@ func get(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []strin
 		Namespace:    rel.Namespace,
 		LastDeployed: rel.Info.LastDeployed,
 		ReleaseType:  rel.Chart.Metadata.Name,
+		Revision:     rel.Version,
+		Status:       rel.Info.Status.String(),
+		ChartVersion: rel.Chart.Metadata.Version,
 	}
 
-	if output == "yaml" {
+	// the yaml output uses the yaml tags rather than the json field names used by
+	// writeOutput, so that its field names stay the same
+	if outputFormat == "yaml" {
 		bytes, err := yaml.Marshal(relInfo)
 
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(string(bytes))
-	} else if output == "json" {
-		bytes, err := json.Marshal(relInfo)
+		fmt.Print(string(bytes))
 
-		if err != nil {
-			return err
-		}
+		return nil
+	}
 
-		fmt.Println(string(bytes))
-	} else {
+	return writeOutput(relInfo, func() error {
 		fmt.Printf("Name:          %s\n", relInfo.Name)
 		fmt.Printf("Namespace:     %s\n", relInfo.Namespace)
 		fmt.Printf("Last deployed: %s\n", relInfo.LastDeployed)
 		fmt.Printf("Release type:  %s\n", relInfo.ReleaseType)
-	}
 
-	return nil
+		return nil
+	})
 }
 
 func getValues(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -120,15 +117,8 @@ func getValues(_ *types.GetAuthenticatedUserResponse, client *api.Client, args [
 
 	values := rel.Config
 
-	if output == "json" {
-		bytes, err := json.Marshal(values)
-
-		if err != nil {
-			return err
-		}
-
-		fmt.Println(string(bytes))
-	} else { // yaml is the default
+	// the values are printed as yaml by default
+	return writeOutput(values, func() error {
 		bytes, err := yaml.Marshal(values)
 
 		if err != nil {
@@ -136,7 +126,7 @@ func getValues(_ *types.GetAuthenticatedUserResponse, client *api.Client, args [
 		}
 
 		fmt.Println(string(bytes))
-	}
 
-	return nil
+		return nil
+	})
 }
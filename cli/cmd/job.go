@@ -2,14 +2,24 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/deploy/wait"
 	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/strvals"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 )
 
 var jobCmd = &cobra.Command{
@@ -79,8 +89,80 @@ use the --namespace flag:
 	},
 }
 
+var jobRunCmd = &cobra.Command{
+	Use:   "run [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Triggers a manual run of a job.",
+	Long: fmt.Sprintf(`
+%s
+
+Triggers a manual run of a job, in the same way as the "Run Job" button in the dashboard.
+Values can be overridden for the run with --set. Once the run has been triggered, the
+previous values of the job are restored, so the overrides don't apply to later runs:
+
+  %s
+
+Pass --wait to print the logs of the job as it runs and wait for it to finish. The command
+then exits with the exit code of the job:
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter job run\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job run job-example --set container.command=\"./migrate.sh\""),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job run job-example --wait"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, runJob)
+
+		if err != nil {
+			var exitErr *jobExitError
+
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.code)
+			}
+
+			os.Exit(1)
+		}
+	},
+}
+
+var jobListRunsCmd = &cobra.Command{
+	Use:   "list-runs [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Lists the runs of a job.",
+	Long: fmt.Sprintf(`
+%s
+
+Lists the runs of a job that are still stored in the cluster, most recent first, along with
+their status and duration.
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace,
+use the --namespace flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter job list-runs\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job list-runs job-example"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listJobRuns)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
 var imageRepoURI string
 
+var jobRunOpts struct {
+	values []string
+	wait   bool
+}
+
 func init() {
 	rootCmd.AddCommand(jobCmd)
 	jobCmd.AddCommand(batchImageUpdateCmd)
@@ -126,6 +208,38 @@ func init() {
 	)
 
 	waitCmd.MarkPersistentFlagRequired("name")
+
+	jobCmd.AddCommand(jobRunCmd)
+
+	jobRunCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"The namespace of the job.",
+	)
+
+	jobRunCmd.PersistentFlags().StringArrayVar(
+		&jobRunOpts.values,
+		"set",
+		[]string{},
+		"Set a value of the job before the run, such as container.command=./migrate.sh. The value only applies to this run. Can be passed more than once.",
+	)
+
+	jobRunCmd.PersistentFlags().BoolVar(
+		&jobRunOpts.wait,
+		"wait",
+		false,
+		"Print the logs of the job and wait for it to finish, exiting with the exit code of the job.",
+	)
+
+	jobCmd.AddCommand(jobListRunsCmd)
+
+	jobListRunsCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"The namespace of the job.",
+	)
 }
 
 func batchImageUpdate(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -152,3 +266,333 @@ func waitForJob(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 		Name:      name,
 	})
 }
+
+// jobExitError is returned when a job run exits with a non-zero exit code, so that
+// "porter job run --wait" can exit with the same code
+type jobExitError struct {
+	code int
+}
+
+func (e *jobExitError) Error() string {
+	return fmt.Sprintf("job exited with code %d", e.code)
+}
+
+// runJob triggers a manual run of a job by upgrading the job release with paused set to
+// false, which is what the dashboard does when a job is run. Values passed with --set are
+// part of that upgrade, and the previous values are restored by a second upgrade once the
+// run has been triggered.
+func runJob(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	jobRelease, err := client.GetRelease(context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if jobRelease.Chart == nil || jobRelease.Chart.Name() != "job" {
+		return fmt.Errorf("%s is not a job", args[0])
+	}
+
+	if jobRelease.Config == nil {
+		jobRelease.Config = make(map[string]interface{})
+	}
+
+	// the previous values are kept as YAML, since parsing the --set values modifies the
+	// nested maps of the config in place
+	prevValuesYAML, err := yaml.Marshal(jobRelease.Config)
+
+	if err != nil {
+		return err
+	}
+
+	values := jobRelease.Config
+
+	for _, value := range jobRunOpts.values {
+		if err := strvals.ParseInto(value, values); err != nil {
+			return fmt.Errorf("invalid --set value %s: %w", value, err)
+		}
+	}
+
+	values["paused"] = false
+
+	valuesYAML, err := yaml.Marshal(values)
+
+	if err != nil {
+		return err
+	}
+
+	err = client.UpgradeRelease(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		args[0],
+		&types.UpgradeReleaseRequest{
+			Values: string(valuesYAML),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	// the revision of the upgraded release is used to find the job that it created
+	jobRelease, err = client.GetRelease(context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Triggered a run of job %s (revision %d)\n", args[0], jobRelease.Version)
+
+	if len(jobRunOpts.values) > 0 {
+		err = restoreJobValues(client, args[0], prevValuesYAML)
+
+		if err != nil {
+			return fmt.Errorf("the run was triggered, but the previous values of job %s could not be restored: %w", args[0], err)
+		}
+	}
+
+	if !jobRunOpts.wait {
+		fmt.Printf("To view the status of the run, run \"porter job list-runs %s --namespace %s\"\n", args[0], namespace)
+		return nil
+	}
+
+	return followJobRun(client, args[0], uint(jobRelease.Version), wait.GetJobTimeoutValue(jobRelease.Config))
+}
+
+// restoreJobValues upgrades a job release back to its values before the run, with paused
+// set to true so that the upgrade doesn't trigger another run
+func restoreJobValues(client *api.Client, name string, prevValuesYAML []byte) error {
+	values := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(prevValuesYAML, &values); err != nil {
+		return err
+	}
+
+	values["paused"] = true
+
+	valuesYAML, err := yaml.Marshal(values)
+
+	if err != nil {
+		return err
+	}
+
+	return client.UpgradeRelease(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		name,
+		&types.UpgradeReleaseRequest{
+			Values: string(valuesYAML),
+		},
+	)
+}
+
+// followJobRun prints the logs of the job created by the given revision of a job release
+// and waits for its first container to exit
+func followJobRun(client *api.Client, name string, revision uint, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	var job *batchv1.Job
+
+	for job == nil {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for job")
+		}
+
+		jobs, err := client.GetJobs(context.Background(), cliConf.Project, cliConf.Cluster, namespace, name)
+
+		if err != nil {
+			return err
+		}
+
+		job = wait.GetJobMatchingRevision(revision, jobs)
+
+		if job == nil {
+			time.Sleep(2 * time.Second)
+		}
+	}
+
+	if len(job.Spec.Template.Spec.Containers) == 0 {
+		return fmt.Errorf("job %s has no containers", job.Name)
+	}
+
+	// the first container runs the job, the second is the sidecar that stops the job
+	container := job.Spec.Template.Spec.Containers[0].Name
+
+	config := &PorterRunSharedConfig{
+		Client: client,
+	}
+
+	err := config.setSharedConfig()
+
+	if err != nil {
+		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
+	}
+
+	pod, err := waitForJobPod(config, job.Name, container, deadline, func(status v1.ContainerStatus) bool {
+		return status.State.Running != nil || status.State.Terminated != nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	podLogs, err := config.Clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: container,
+		Follow:    true,
+	}).Stream(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	err = printLogLines(podLogs, "", &sync.Mutex{})
+	podLogs.Close()
+
+	if err != nil {
+		return err
+	}
+
+	pod, err = waitForJobPod(config, job.Name, container, deadline, func(status v1.ContainerStatus) bool {
+		return status.State.Terminated != nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && status.State.Terminated.ExitCode != 0 {
+			return &jobExitError{
+				code: int(status.State.Terminated.ExitCode),
+			}
+		}
+	}
+
+	color.New(color.FgGreen).Println("Job completed successfully")
+
+	return nil
+}
+
+// waitForJobPod waits for the most recent pod of a job whose container matches the given
+// condition. Errors that stop the container from starting are returned immediately.
+func waitForJobPod(
+	config *PorterRunSharedConfig,
+	jobName, container string,
+	deadline time.Time,
+	isReady func(status v1.ContainerStatus) bool,
+) (*v1.Pod, error) {
+	for time.Now().Before(deadline) {
+		pods, err := config.Clientset.CoreV1().Pods(namespace).List(
+			context.Background(),
+			metav1.ListOptions{
+				LabelSelector: "job-name=" + jobName,
+			},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var pod *v1.Pod
+
+		for i := range pods.Items {
+			if pod == nil || pod.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+				pod = &pods.Items[i]
+			}
+		}
+
+		if pod != nil {
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.Name != container {
+					continue
+				}
+
+				if isReady(status) {
+					return pod, nil
+				}
+
+				if waiting := status.State.Waiting; waiting != nil {
+					switch waiting.Reason {
+					case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+						return nil, fmt.Errorf("job could not start: %s: %s", waiting.Reason, waiting.Message)
+					}
+				}
+			}
+		}
+
+		time.Sleep(2 * time.Second)
+	}
+
+	return nil, fmt.Errorf("timed out waiting for job")
+}
+
+func listJobRuns(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	jobs, err := client.GetJobs(context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if len(jobs) == 0 {
+		fmt.Printf("Job %s has no runs\n", args[0])
+		return nil
+	}
+
+	status, err := client.GetJobsStatus(context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if status.Status != "" {
+		fmt.Printf("Latest run: %s\n\n", status.Status)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+	})
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "NAME", "REVISION", "STATUS", "STARTED", "DURATION")
+
+	for _, job := range jobs {
+		started := "-"
+		duration := "-"
+
+		if job.Status.StartTime != nil {
+			started = job.Status.StartTime.Format(time.RFC3339)
+
+			if job.Status.CompletionTime != nil {
+				duration = job.Status.CompletionTime.Sub(job.Status.StartTime.Time).String()
+			} else if job.Status.Active > 0 {
+				duration = time.Since(job.Status.StartTime.Time).Round(time.Second).String()
+			}
+		}
+
+		revision := job.Labels["helm.sh/revision"]
+
+		if revision == "" {
+			revision = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", job.Name, revision, getJobRunStatus(job), started, duration)
+	}
+
+	return w.Flush()
+}
+
+// getJobRunStatus matches the statuses shown for job runs in the dashboard
+func getJobRunStatus(job batchv1.Job) string {
+	if job.Status.Succeeded >= 1 {
+		return "succeeded"
+	} else if job.Status.Failed >= 1 {
+		return "failed"
+	}
+
+	return "running"
+}
@@ -10,6 +10,7 @@ import (
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/release"
 )
 
 // listCmd represents the "porter list" base command and "porter list all" subcommand
@@ -144,26 +145,29 @@ func writeReleases(client *api.Client, kind string) error {
 		return err
 	}
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "NAMESPACE", "STATUS", "KIND")
+	filtered := make([]*release.Release, 0)
 
 	for _, rel := range releases {
 		chartName := rel.Chart.Name()
 
-		if kind == "all" {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
-		} else if kind == "application" && (chartName == "web" || chartName == "worker") {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
-		} else if kind == "job" && chartName == "job" {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
-		} else if kind == "addon" && chartName != "web" && chartName != "worker" && chartName != "job" {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
+		if kind == "all" ||
+			(kind == "application" && (chartName == "web" || chartName == "worker")) ||
+			(kind == "job" && chartName == "job") ||
+			(kind == "addon" && chartName != "web" && chartName != "worker" && chartName != "job") {
+			filtered = append(filtered, rel)
 		}
 	}
 
-	w.Flush()
+	return writeOutput(filtered, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
 
-	return nil
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "NAMESPACE", "STATUS", "KIND")
+
+		for _, rel := range filtered {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, rel.Chart.Name())
+		}
+
+		return w.Flush()
+	})
 }
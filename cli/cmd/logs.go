@@ -1,13 +1,20 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"sync"
+	"time"
 
+	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
-	"github.com/porter-dev/porter/cli/cmd/utils"
 	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // logsCmd represents the "porter logs" base command when called
@@ -16,6 +23,36 @@ var logsCmd = &cobra.Command{
 	Use:   "logs [release]",
 	Args:  cobra.ExactArgs(1),
 	Short: "Logs the output from a given application.",
+	Long: fmt.Sprintf(`
+%s
+
+Prints the logs of every running pod in a release. When the release has more than one pod
+or container, each line is prefixed with the name of the pod and container it came from.
+
+  %s
+
+Use --selector to only print the logs of pods matching a label selector, and --container to
+only print the logs of a single container:
+
+  %s
+
+Use --previous to print the logs of the previous instance of each container, for example
+after a crash:
+
+  %s
+
+Use --search, --from and --to to search the logs that porter-agent captured for the release,
+including the logs of pods that have crashed or been deleted. --from and --to accept an
+RFC3339 timestamp, a date, or a duration before now:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter logs\":"),
+		color.GreenString("porter logs example-app --follow"),
+		color.GreenString("porter logs example-app --selector app=web --container web --since 1h"),
+		color.GreenString("porter logs example-app --previous"),
+		color.GreenString("porter logs example-app --search \"connection refused\" --from 24h"),
+	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, logs)
 
@@ -27,6 +64,17 @@ var logsCmd = &cobra.Command{
 
 var follow bool
 
+var logsOpts struct {
+	since     time.Duration
+	tail      int64
+	container string
+	previous  bool
+	selector  string
+	search    string
+	from      string
+	to        string
+}
+
 func init() {
 	rootCmd.AddCommand(logsCmd)
 
@@ -44,71 +92,414 @@ func init() {
 		false,
 		"specify if the logs should be streamed",
 	)
+
+	logsCmd.PersistentFlags().DurationVar(
+		&logsOpts.since,
+		"since",
+		0,
+		"only return logs newer than a relative duration like 5s, 2m, or 3h",
+	)
+
+	logsCmd.PersistentFlags().Int64Var(
+		&logsOpts.tail,
+		"tail",
+		-1,
+		"number of lines to show from the end of the logs of each container, -1 shows all lines",
+	)
+
+	logsCmd.PersistentFlags().StringVarP(
+		&logsOpts.container,
+		"container",
+		"c",
+		"",
+		"only print the logs of this container",
+	)
+
+	logsCmd.PersistentFlags().BoolVarP(
+		&logsOpts.previous,
+		"previous",
+		"p",
+		false,
+		"print the logs of the previous instance of each container",
+	)
+
+	logsCmd.PersistentFlags().StringVarP(
+		&logsOpts.selector,
+		"selector",
+		"l",
+		"",
+		"only print the logs of pods matching this label selector, such as app=web",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsOpts.search,
+		"search",
+		"",
+		"search the logs captured by porter-agent for lines containing this string, ignoring case",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsOpts.from,
+		"from",
+		"",
+		"only search logs captured after this time, as an RFC3339 timestamp, a date or a duration like 24h",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsOpts.to,
+		"to",
+		"",
+		"only search logs captured before this time, as an RFC3339 timestamp, a date or a duration like 1h",
+	)
+}
+
+// logStream is a single container of a pod whose logs are printed
+type logStream struct {
+	pod       string
+	container string
+	prefix    string
+}
+
+var logPrefixColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgMagenta,
+	color.FgYellow,
+	color.FgBlue,
 }
 
 func logs(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
-	podsSimple, err := getPods(client, namespace, args[0])
+	if logsOpts.search != "" || logsOpts.from != "" || logsOpts.to != "" {
+		return searchLogs(client, args[0])
+	}
+
+	if logsOpts.previous && follow {
+		return fmt.Errorf("--previous cannot be used with --follow")
+	}
+
+	config := &PorterRunSharedConfig{
+		Client: client,
+	}
+
+	err := config.setSharedConfig()
 
 	if err != nil {
-		return fmt.Errorf("Could not retrieve list of pods: %s", err.Error())
+		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
 	}
 
-	// if length of pods is 0, throw error
-	var selectedPod podSimple
+	streams, err := getLogStreams(config, client, args[0])
 
-	if len(podsSimple) == 0 {
-		return fmt.Errorf("At least one pod must exist in this deployment.")
-	} else if len(podsSimple) == 1 {
-		selectedPod = podsSimple[0]
-	} else {
-		podNames := make([]string, 0)
-
-		for _, podSimple := range podsSimple {
-			podNames = append(podNames, podSimple.Name)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	errs := make([]error, len(streams))
+
+	for i, stream := range streams {
+		wg.Add(1)
+
+		go func(i int, stream *logStream) {
+			defer wg.Done()
+
+			if logsOpts.previous {
+				errs[i] = printPreviousLogs(client, stream, &mu)
+			} else {
+				errs[i] = streamLogs(config, stream, &mu)
+			}
+		}(i, stream)
+	}
+
+	wg.Wait()
+
+	var failed int
+
+	for i, err := range errs {
+		if err != nil {
+			failed++
+			color.New(color.FgRed).Fprintf(os.Stderr, "error getting logs for %s/%s: %s\n",
+				streams[i].pod, streams[i].container, err.Error())
 		}
+	}
+
+	if failed == len(streams) {
+		return fmt.Errorf("could not get logs for any container")
+	}
+
+	return nil
+}
 
-		selectedPodName, err := utils.PromptSelect("Select the pod:", podNames)
+// getLogStreams lists the containers to print the logs of, after applying the selector and
+// container filters. Pods and containers are never prompted for, so that the command can run
+// in CI.
+func getLogStreams(config *PorterRunSharedConfig, client *api.Client, releaseName string) ([]*logStream, error) {
+	podsSimple, err := getPods(client, namespace, releaseName)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not retrieve list of pods: %s", err.Error())
+	}
+
+	if logsOpts.selector != "" {
+		selected, err := config.Clientset.CoreV1().Pods(namespace).List(
+			context.Background(),
+			metav1.ListOptions{
+				LabelSelector: logsOpts.selector,
+			},
+		)
 
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("Could not list pods matching selector: %s", err.Error())
+		}
+
+		selectedNames := make(map[string]bool)
+
+		for _, pod := range selected.Items {
+			selectedNames[pod.Name] = true
+		}
+
+		filtered := make([]podSimple, 0)
+
+		for _, pod := range podsSimple {
+			if selectedNames[pod.Name] {
+				filtered = append(filtered, pod)
+			}
+		}
+
+		podsSimple = filtered
+	}
+
+	if len(podsSimple) == 0 {
+		return nil, fmt.Errorf("At least one pod must exist in this deployment.")
+	}
+
+	streams := make([]*logStream, 0)
+
+	for _, pod := range podsSimple {
+		for _, container := range pod.ContainerNames {
+			if logsOpts.container != "" && container != logsOpts.container {
+				continue
+			}
+
+			streams = append(streams, &logStream{
+				pod:       pod.Name,
+				container: container,
+			})
+		}
+	}
+
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("No pods in this deployment have a container named %s.", logsOpts.container)
+	}
+
+	// only prefix lines when there is more than one source of logs
+	if len(streams) > 1 {
+		for i, stream := range streams {
+			stream.prefix = color.New(logPrefixColors[i%len(logPrefixColors)]).Sprintf(
+				"[%s/%s] ", stream.pod, stream.container,
+			)
 		}
+	}
+
+	return streams, nil
+}
+
+func streamLogs(config *PorterRunSharedConfig, stream *logStream, mu *sync.Mutex) error {
+	podLogOpts := v1.PodLogOptions{
+		Container: stream.container,
+		Follow:    follow,
+	}
+
+	if logsOpts.since > 0 {
+		sinceSeconds := int64(logsOpts.since.Seconds())
+		podLogOpts.SinceSeconds = &sinceSeconds
+	}
+
+	if logsOpts.tail >= 0 {
+		podLogOpts.TailLines = &logsOpts.tail
+	}
+
+	req := config.Clientset.CoreV1().Pods(namespace).GetLogs(stream.pod, &podLogOpts)
+
+	podLogs, err := req.Stream(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	defer podLogs.Close()
 
-		// find selected pod
-		for _, podSimple := range podsSimple {
-			if selectedPodName == podSimple.Name {
-				selectedPod = podSimple
+	return printLogLines(podLogs, stream.prefix, mu)
+}
+
+func printPreviousLogs(client *api.Client, stream *logStream, mu *sync.Mutex) error {
+	resp, err := client.GetPreviousPodLogs(
+		context.Background(),
+		cliConf.Project,
+		cliConf.Cluster,
+		namespace,
+		stream.pod,
+		&types.GetPreviousPodLogsRequest{
+			Container: stream.container,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	lines := resp.PrevLogs
+
+	if logsOpts.tail >= 0 && int64(len(lines)) > logsOpts.tail {
+		lines = lines[int64(len(lines))-logsOpts.tail:]
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, line := range lines {
+		fmt.Println(stream.prefix + line)
+	}
+
+	return nil
+}
+
+// printLogLines copies logs to stdout line by line, so that lines from different
+// containers are never interleaved
+func printLogLines(r io.Reader, prefix string, mu *sync.Mutex) error {
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if len(line) > 0 {
+			if line[len(line)-1] != '\n' {
+				line += "\n"
 			}
+
+			mu.Lock()
+			fmt.Print(prefix + line)
+			mu.Unlock()
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
 		}
 	}
+}
+
+const searchLogsPageSize = 1000
+
+// searchLogs pages through the historical logs of a release that were captured by
+// porter-agent
+func searchLogs(client *api.Client, releaseName string) error {
+	if follow || logsOpts.previous || logsOpts.selector != "" {
+		return fmt.Errorf("--follow, --previous and --selector cannot be used with --search, --from or --to")
+	}
+
+	req := &types.SearchLogsRequest{
+		ReleaseName: releaseName,
+		Namespace:   namespace,
+		Search:      logsOpts.search,
+		Limit:       searchLogsPageSize,
+	}
 
-	var selectedContainerName string
+	now := time.Now()
 
-	// if the selected pod has multiple container, spawn selector
-	if len(selectedPod.ContainerNames) == 0 {
-		return fmt.Errorf("At least one pod must exist in this deployment.")
-	} else if len(selectedPod.ContainerNames) == 1 {
-		selectedContainerName = selectedPod.ContainerNames[0]
-	} else {
-		selectedContainer, err := utils.PromptSelect("Select the container:", selectedPod.ContainerNames)
+	if logsOpts.from != "" {
+		from, err := parseLogTime(logsOpts.from, now)
+
+		if err != nil {
+			return fmt.Errorf("invalid --from: %w", err)
+		}
+
+		req.Since = from.Unix()
+	}
+
+	if logsOpts.to != "" {
+		to, err := parseLogTime(logsOpts.to, now)
+
+		if err != nil {
+			return fmt.Errorf("invalid --to: %w", err)
+		}
+
+		req.Until = to.Unix()
+	}
+
+	// without --tail, each page is printed as soon as it arrives rather than holding every
+	// matching line in memory
+	lines := make([]*types.LogLine, 0)
+	found := false
+
+	for {
+		resp, err := client.SearchLogs(context.Background(), cliConf.Project, cliConf.Cluster, req)
 
 		if err != nil {
 			return err
 		}
 
-		selectedContainerName = selectedContainer
+		for _, line := range resp.Lines {
+			if logsOpts.container != "" && line.ContainerName != logsOpts.container {
+				continue
+			}
+
+			found = true
+
+			if logsOpts.tail < 0 {
+				printSearchLogLine(line)
+				continue
+			}
+
+			lines = append(lines, line)
+
+			if int64(len(lines)) > logsOpts.tail {
+				lines = lines[1:]
+			}
+		}
+
+		if !resp.HasMore || resp.Next == nil {
+			break
+		}
+
+		req.AfterTimestamp = resp.Next.AfterTimestamp
+		req.AfterLogID = resp.Next.AfterLogID
+		req.AfterLine = resp.Next.AfterLine
 	}
 
-	config := &PorterRunSharedConfig{
-		Client: client,
+	if !found {
+		color.New(color.FgYellow).Fprintln(os.Stderr, "No matching logs were found.")
+		return nil
 	}
 
-	err = config.setSharedConfig()
+	for _, line := range lines {
+		printSearchLogLine(line)
+	}
 
-	if err != nil {
-		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
+	return nil
+}
+
+func printSearchLogLine(line *types.LogLine) {
+	fmt.Printf("%s %s\n", color.New(color.FgCyan).Sprintf(
+		"[%s %s/%s]", time.Unix(line.Timestamp, 0).Format(time.RFC3339), line.PodName, line.ContainerName,
+	), line.Line)
+}
+
+// parseLogTime parses an RFC3339 timestamp, a date, or a duration before now
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
 	}
 
-	_, err = pipePodLogsToStdout(config, namespace, selectedPod.Name, selectedContainerName, follow)
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
 
-	return err
+	return time.Time{}, fmt.Errorf("%s is not an RFC3339 timestamp, a date or a duration", value)
 }
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// outputFormat is the value of the global --output flag. When it is empty, read
+// commands print a human-readable table.
+var outputFormat string
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(
+		&outputFormat,
+		"output",
+		"o",
+		"",
+		"the output format of read commands: json, yaml, go-template=<template>, go-template-file=<path> or jsonpath=<expression>",
+	)
+}
+
+// writeOutput writes the response of a read command in the format selected with the
+// --output flag, or calls writeTable if no format was selected. The response is
+// serialised through its json tags, so the field names are the same in every format.
+func writeOutput(resp interface{}, writeTable func() error) error {
+	format, arg := outputFormat, ""
+
+	if idx := strings.Index(outputFormat, "="); idx != -1 {
+		format, arg = outputFormat[:idx], outputFormat[idx+1:]
+	}
+
+	switch format {
+	case "":
+		return writeTable()
+	case "json":
+		bytes, err := json.MarshalIndent(resp, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(resp)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(bytes))
+	case "go-template", "template":
+		return writeTemplate(resp, arg)
+	case "go-template-file", "template-file":
+		tmpl, err := ioutil.ReadFile(arg)
+
+		if err != nil {
+			return fmt.Errorf("could not read template file: %w", err)
+		}
+
+		return writeTemplate(resp, string(tmpl))
+	case "jsonpath":
+		return writeJSONPath(resp, arg)
+	default:
+		return fmt.Errorf("unsupported output format %q: must be one of json, yaml, go-template, go-template-file or jsonpath", outputFormat)
+	}
+
+	return nil
+}
+
+// toGeneric converts a response to the generic form produced by decoding its JSON
+// representation, so that templates and JSONPath expressions refer to the json field
+// names rather than the Go field names
+func toGeneric(resp interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var res interface{}
+
+	if err := json.Unmarshal(bytes, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func writeTemplate(resp interface{}, tmplStr string) error {
+	if tmplStr == "" {
+		return fmt.Errorf("a template must be passed as --output go-template=<template>")
+	}
+
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(tmplStr)
+
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	data, err := toGeneric(resp)
+
+	if err != nil {
+		return err
+	}
+
+	out := &bytes.Buffer{}
+
+	if err := tmpl.Execute(out, data); err != nil {
+		return fmt.Errorf("could not execute template: %w", err)
+	}
+
+	_, err = os.Stdout.Write(out.Bytes())
+
+	return err
+}
+
+func writeJSONPath(resp interface{}, expr string) error {
+	if expr == "" {
+		return fmt.Errorf("an expression must be passed as --output jsonpath=<expression>")
+	}
+
+	// like kubectl, allow the surrounding braces to be omitted
+	if !strings.Contains(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+
+	parser := jsonpath.New("output")
+
+	if err := parser.Parse(expr); err != nil {
+		return fmt.Errorf("could not parse jsonpath expression: %w", err)
+	}
+
+	data, err := toGeneric(resp)
+
+	if err != nil {
+		return err
+	}
+
+	out := &bytes.Buffer{}
+
+	if err := parser.Execute(out, data); err != nil {
+		return fmt.Errorf("could not execute jsonpath expression: %w", err)
+	}
+
+	_, err = os.Stdout.Write(out.Bytes())
+
+	return err
+}
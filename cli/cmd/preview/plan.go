@@ -0,0 +1,551 @@
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cli/cli/git"
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/deploy"
+	"github.com/porter-dev/porter/internal/templater/utils"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+)
+
+// The plan drivers are registered in place of the other drivers when porter apply is run with
+// --dry-run. They resolve the same configuration and produce the same outputs, so that the
+// resources depending on them can be planned, but only print the changes that would be made.
+
+// BuildPlanDriver prints the image that the build-image driver would build
+type BuildPlanDriver struct {
+	*BuildDriver
+}
+
+func NewBuildPlanDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver, err := NewBuildDriver(resource, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &BuildPlanDriver{driver.(*BuildDriver)}, nil
+}
+
+func (d *BuildPlanDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	buildDriverConfig, err := d.getConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.config = buildDriverConfig
+
+	tag, err := GetImageTag(d.config.Build.Method, d.config.Build.Image)
+
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL, err := GetImageRepoURL(config.GetAPIClient(), d.target, d.target.AppName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	method := d.config.Build.Method
+
+	if method == "" {
+		method = string(deploy.DeployBuildTypePack)
+
+		if (&deploy.CreateAgent{}).HasDefaultDockerfile(d.config.Build.Context) {
+			method = string(deploy.DeployBuildTypeDocker)
+		}
+	}
+
+	PrintPlanHeader(resource.Name, "build-image")
+	fmt.Printf("  would build image %s:%s with %s from %s\n", imageURL, tag, method, getBuildContext(d.config.Build.Context))
+
+	domain, imageRepo := splitImageRepoURL(imageURL)
+
+	d.output["registry_url"] = domain
+	d.output["image_repo"] = imageRepo
+	d.output["image_tag"] = tag
+	d.output["image"] = fmt.Sprintf("%s:%s", imageURL, tag)
+
+	return resource, nil
+}
+
+// PushPlanDriver prints the image that the push-image driver would push
+type PushPlanDriver struct {
+	*PushDriver
+}
+
+func NewPushPlanDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver, err := NewPushDriver(resource, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PushPlanDriver{driver.(*PushDriver)}, nil
+}
+
+func (d *PushPlanDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	pushDriverConfig, err := d.getConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.config = pushDriverConfig
+
+	PrintPlanHeader(resource.Name, "push-image")
+
+	if d.config.Push.UsePackCache {
+		fmt.Printf("  image %s is pushed by the build, nothing to push\n", d.config.Push.Image)
+	} else {
+		fmt.Printf("  would push image %s\n", d.config.Push.Image)
+	}
+
+	d.output["image"] = d.config.Push.Image
+
+	return resource, nil
+}
+
+// UpdateConfigPlanDriver prints the values that the update-config driver would create or
+// upgrade a release with
+type UpdateConfigPlanDriver struct {
+	*UpdateConfigDriver
+}
+
+func NewUpdateConfigPlanDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver, err := NewUpdateConfigDriver(resource, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateConfigPlanDriver{driver.(*UpdateConfigDriver)}, nil
+}
+
+func (d *UpdateConfigPlanDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	updateConfigDriverConfig, err := d.getConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.config = updateConfigDriverConfig
+
+	client := config.GetAPIClient()
+
+	tag := os.Getenv("PORTER_TAG")
+
+	if tag == "" {
+		tag = d.config.UpdateConfig.Tag
+	}
+
+	if tag == "" {
+		commit, err := git.LastCommit()
+
+		if err != nil {
+			return nil, err
+		}
+
+		tag = commit.Sha[:7]
+	}
+
+	PrintPlanHeader(resource.Name, "update-config")
+
+	release, err := client.GetRelease(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		d.target.AppName,
+	)
+
+	var values map[string]interface{}
+
+	if err != nil {
+		color.New(color.FgGreen).Printf("  + would create %s release %s in namespace %s from image %s\n",
+			d.source.Name, d.target.AppName, d.target.Namespace, d.config.UpdateConfig.Image)
+
+		values = PlanReleaseCreate(d.config.Values)
+	} else if d.config.OnlyCreate {
+		fmt.Printf("  release %s already exists and onlyCreate is set, nothing to update\n", d.target.AppName)
+
+		values = release.Config
+	} else {
+		color.New(color.FgYellow).Printf("  ~ would upgrade %s release %s in namespace %s to tag %s\n",
+			d.source.Name, d.target.AppName, d.target.Namespace, tag)
+
+		values, err = PlanReleaseUpdate(client, release, &deploy.SharedOpts{
+			ProjectID:   d.target.Project,
+			ClusterID:   d.target.Cluster,
+			OverrideTag: tag,
+			Namespace:   d.target.Namespace,
+			Method:      "registry",
+			EnvGroups:   d.config.EnvGroups,
+		}, d.config.Values)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if d.source.Name == "job" && d.config.WaitForJob {
+		fmt.Println("  would wait for the job to finish")
+	}
+
+	d.output = utils.CoalesceValues(d.source.SourceValues, values)
+
+	return resource, nil
+}
+
+// EnvGroupPlanDriver prints the env groups that the env-group driver would create
+type EnvGroupPlanDriver struct {
+	*EnvGroupDriver
+}
+
+func NewEnvGroupPlanDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver, err := NewEnvGroupDriver(resource, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnvGroupPlanDriver{driver.(*EnvGroupDriver)}, nil
+}
+
+func (d *EnvGroupPlanDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	driverConfig, err := d.getConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.config = driverConfig
+
+	client := config.GetAPIClient()
+
+	PrintPlanHeader(resource.Name, "env-group")
+
+	for _, group := range d.config.EnvGroups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("env group name cannot be empty")
+		}
+
+		if group.Namespace == "" {
+			group.Namespace = d.target.Namespace
+		}
+
+		envGroup, err := client.GetEnvGroup(
+			context.Background(),
+			d.target.Project,
+			d.target.Cluster,
+			group.Namespace,
+			&types.GetEnvGroupRequest{
+				Name: group.Name,
+			},
+		)
+
+		if err != nil && err.Error() == "env group not found" {
+			color.New(color.FgGreen).Printf("  + would create env group %s in namespace %s\n", group.Name, group.Namespace)
+			PrintValuesDiff(map[string]interface{}{}, stringMapToValues(group.Variables))
+
+			envGroup = group
+		} else if err != nil {
+			return nil, err
+		} else {
+			// porter apply only creates env groups, so existing env groups are left as they are
+			fmt.Printf("  env group %s already exists in namespace %s and is not updated\n", group.Name, group.Namespace)
+
+			if changed := getChangedVariables(envGroup.Variables, group.Variables); len(changed) > 0 {
+				color.New(color.FgYellow).Printf("  the variables %s in porter.yaml differ from the env group and are ignored\n",
+					strings.Join(changed, ", "))
+			}
+		}
+
+		d.output[envGroup.Name] = map[string]interface{}{
+			"variables": envGroup.Variables,
+		}
+	}
+
+	return resource, nil
+}
+
+// PrintPlanHeader prints the name and driver of a resource before its planned changes
+func PrintPlanHeader(name, driver string) {
+	color.New(color.FgBlue, color.Bold).Printf("\n%s (%s):\n", name, driver)
+}
+
+// PlanReleaseCreate prints the values that a new release would be created with, and returns them
+func PlanReleaseCreate(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+
+	PrintValuesDiff(map[string]interface{}{}, values)
+
+	return values
+}
+
+// PlanReleaseUpdate prints the changes between the currently deployed values of a release and
+// the values that porter apply would upgrade it with, and returns the upgraded values. Env groups
+// that do not exist yet, because they are created or cloned during the apply, are left out.
+func PlanReleaseUpdate(
+	client *api.Client,
+	release *types.GetReleaseResponse,
+	opts *deploy.SharedOpts,
+	overrideValues map[string]interface{},
+) (map[string]interface{}, error) {
+	current, err := copyValues(release.Config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	envGroups := make([]types.EnvGroupMeta, 0)
+
+	for _, group := range opts.EnvGroups {
+		_, err := client.GetEnvGroup(
+			context.Background(),
+			opts.ProjectID,
+			opts.ClusterID,
+			opts.Namespace,
+			&types.GetEnvGroupRequest{
+				Name:    group.Name,
+				Version: group.Version,
+			},
+		)
+
+		if err != nil && err.Error() == "env group not found" {
+			color.New(color.FgYellow).Printf("  env group %s does not exist yet, so its variables are not included below\n", group.Name)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		envGroups = append(envGroups, group)
+	}
+
+	planOpts := *opts
+	planOpts.EnvGroups = envGroups
+
+	updated, err := deploy.GetUpdatedValues(client, release, &planOpts, overrideValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	PrintValuesDiff(current, updated)
+
+	return updated, nil
+}
+
+// GetImageTag returns the tag that porter apply builds an image with: PORTER_TAG or the short
+// SHA of the last commit, or the tag of the image for the registry method
+func GetImageTag(method, image string) (string, error) {
+	tag := os.Getenv("PORTER_TAG")
+
+	if tag == "" {
+		commit, err := git.LastCommit()
+
+		if err != nil {
+			return "", err
+		}
+
+		tag = commit.Sha[:7]
+	}
+
+	// if the method is registry and a tag is defined, we use the provided tag
+	if method == "registry" {
+		imageSpl := strings.Split(image, ":")
+
+		if len(imageSpl) == 2 {
+			tag = imageSpl[1]
+		}
+
+		if tag == "" {
+			tag = "latest"
+		}
+	}
+
+	return tag, nil
+}
+
+// GetImageRepoURL returns the image repository that porter apply pushes the image of an
+// application to
+func GetImageRepoURL(client *api.Client, target *Target, appName string) (string, error) {
+	regList, err := client.ListRegistries(context.Background(), target.Project)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(*regList) == 0 {
+		return "", fmt.Errorf("no registry found")
+	}
+
+	var repoSuffix string
+
+	if repoName := os.Getenv("PORTER_REPO_NAME"); repoName != "" {
+		if repoOwner := os.Getenv("PORTER_REPO_OWNER"); repoOwner != "" {
+			repoSuffix = strings.ToLower(strings.ReplaceAll(fmt.Sprintf("%s-%s", repoOwner, repoName), "_", "-"))
+		}
+	}
+
+	createAgent := &deploy.CreateAgent{
+		Client: client,
+		CreateOpts: &deploy.CreateOpts{
+			SharedOpts: &deploy.SharedOpts{
+				ProjectID: target.Project,
+				ClusterID: target.Cluster,
+				Namespace: target.Namespace,
+			},
+			ReleaseName: appName,
+			RegistryURL: (*regList)[0].URL,
+			RepoSuffix:  repoSuffix,
+		},
+	}
+
+	_, imageURL, err := createAgent.GetImageRepoURL(appName, target.Namespace)
+
+	return imageURL, err
+}
+
+func getBuildContext(context string) string {
+	if context == "" {
+		return "."
+	}
+
+	return context
+}
+
+func splitImageRepoURL(imageURL string) (string, string) {
+	spl := strings.SplitN(imageURL, "/", 2)
+
+	if len(spl) != 2 {
+		return "", imageURL
+	}
+
+	return spl[0], spl[1]
+}
+
+// PrintValuesDiff prints one line for every key that is added, removed or changed between
+// two sets of values, with nested keys joined by dots
+func PrintValuesDiff(current, updated map[string]interface{}) {
+	currentFlat := make(map[string]string)
+	updatedFlat := make(map[string]string)
+
+	flattenValues("", current, currentFlat)
+	flattenValues("", updated, updatedFlat)
+
+	keys := make([]string, 0)
+
+	for key := range currentFlat {
+		keys = append(keys, key)
+	}
+
+	for key := range updatedFlat {
+		if _, ok := currentFlat[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	changes := 0
+
+	for _, key := range keys {
+		currentVal, inCurrent := currentFlat[key]
+		updatedVal, inUpdated := updatedFlat[key]
+
+		if !inCurrent {
+			color.New(color.FgGreen).Printf("    + %s: %s\n", key, updatedVal)
+		} else if !inUpdated {
+			color.New(color.FgRed).Printf("    - %s: %s\n", key, currentVal)
+		} else if currentVal != updatedVal {
+			color.New(color.FgYellow).Printf("    ~ %s: %s => %s\n", key, currentVal, updatedVal)
+		} else {
+			continue
+		}
+
+		changes++
+	}
+
+	if changes == 0 {
+		fmt.Println("    no changes to values")
+	}
+}
+
+func flattenValues(prefix string, value interface{}, res map[string]string) {
+	if values, ok := value.(map[string]interface{}); ok && (len(values) > 0 || prefix == "") {
+		for key, child := range values {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+
+			flattenValues(key, child, res)
+		}
+
+		return
+	}
+
+	// values are compared by their JSON encoding, so that numbers parsed from porter.yaml and
+	// from the API compare equal
+	bytes, err := json.Marshal(value)
+
+	if err != nil {
+		res[prefix] = fmt.Sprintf("%v", value)
+		return
+	}
+
+	res[prefix] = string(bytes)
+}
+
+func copyValues(values map[string]interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+
+	bytes, err := json.Marshal(values)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(bytes, &res)
+
+	return res, err
+}
+
+func stringMapToValues(variables map[string]string) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	for key, val := range variables {
+		res[key] = val
+	}
+
+	return res
+}
+
+func getChangedVariables(current, desired map[string]string) []string {
+	res := make([]string, 0)
+
+	for key, val := range desired {
+		if currVal, ok := current[key]; !ok || currVal != val {
+			res = append(res, key)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
@@ -187,6 +188,12 @@ func GetTarget(input map[string]interface{}) (*Target, error) {
 }
 
 func existsInRepo(name, version, url string) (map[string]interface{}, error) {
+	// OCI repos require registry credentials, so the chart is loaded through the
+	// project helm repo with that url
+	if strings.HasPrefix(url, "oci://") {
+		return existsInOCIRepo(name, version, url)
+	}
+
 	chart, err := config.GetAPIClient().GetTemplate(
 		context.Background(),
 		name, version,
@@ -201,3 +208,30 @@ func existsInRepo(name, version, url string) (map[string]interface{}, error) {
 	}
 	return chart.Values, nil
 }
+
+func existsInOCIRepo(name, version, url string) (map[string]interface{}, error) {
+	client := config.GetAPIClient()
+	projectID := config.GetCLIConfig().Project
+
+	helmRepos, err := client.ListHelmRepos(context.Background(), projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, helmRepo := range helmRepos {
+		if strings.TrimSuffix(helmRepo.RepoURL, "/") != strings.TrimSuffix(url, "/") {
+			continue
+		}
+
+		chart, err := client.GetHelmRepoChart(context.Background(), projectID, helmRepo.ID, name, version)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return chart.Values, nil
+	}
+
+	return nil, fmt.Errorf("no helm repo with url %s found in project %d", url, projectID)
+}
@@ -61,12 +61,136 @@ var listProjectCmd = &cobra.Command{
 	},
 }
 
+var listCollaboratorsCmd = &cobra.Command{
+	Use:   "collaborators",
+	Short: "Lists the collaborators and their roles in the current project",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listCollaborators)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var listPoliciesCmd = &cobra.Command{
+	Use:   "policies",
+	Short: "Lists the custom policies in the current project",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listPolicies)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var updateRoleCmd = &cobra.Command{
+	Use:   "update-role [user-id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Updates the role of a collaborator in the current project",
+	Long: fmt.Sprintf(`
+%s
+
+Updates the role of a collaborator in the current project. The role kind can be one of
+"admin", "developer", "viewer" or "custom". Custom roles must be bound to a policy in the
+project, which can be listed with "porter project policies":
+
+  %s
+
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter project update-role\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter project update-role 2 --kind custom --policy [policy-uid]"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, updateRole)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var requireMFACmd = &cobra.Command{
+	Use:   "require-mfa",
+	Short: "Requires multi-factor authentication for all collaborators of the current project",
+	Long: fmt.Sprintf(`
+%s
+
+Requires multi-factor authentication for all collaborators of the current project. Collaborators
+who have not enabled multi-factor authentication with "porter auth mfa enable" are denied
+access to the project. API tokens issued for the project are not affected:
+
+  %s
+
+To stop requiring multi-factor authentication, pass the --disable flag:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter project require-mfa\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter project require-mfa"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter project require-mfa --disable"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, requireMFA)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var resetMFACmd = &cobra.Command{
+	Use:   "reset-mfa [user-id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Disables multi-factor authentication for a collaborator of the current project who has lost their second factor. Only the admin user of the instance can reset multi-factor authentication.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, resetCollaboratorMFA)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var roleKind string
+var requireMFADisable bool
+var rolePolicyUID string
+
 func init() {
 	rootCmd.AddCommand(projectCmd)
 
 	projectCmd.AddCommand(createProjectCmd)
 	projectCmd.AddCommand(deleteProjectCmd)
 	projectCmd.AddCommand(listProjectCmd)
+	projectCmd.AddCommand(listCollaboratorsCmd)
+	projectCmd.AddCommand(listPoliciesCmd)
+	projectCmd.AddCommand(updateRoleCmd)
+	projectCmd.AddCommand(requireMFACmd)
+	projectCmd.AddCommand(resetMFACmd)
+
+	requireMFACmd.PersistentFlags().BoolVar(
+		&requireMFADisable,
+		"disable",
+		false,
+		"stop requiring multi-factor authentication",
+	)
+
+	updateRoleCmd.PersistentFlags().StringVar(
+		&roleKind,
+		"kind",
+		"",
+		"the role kind: admin, developer, viewer or custom",
+	)
+
+	updateRoleCmd.PersistentFlags().StringVar(
+		&rolePolicyUID,
+		"policy",
+		"",
+		"the uid of the policy to bind to a custom role",
+	)
+
+	updateRoleCmd.MarkPersistentFlagRequired("kind")
 }
 
 func createProject(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -92,24 +216,24 @@ func listProjects(user *types.GetAuthenticatedUserResponse, client *api.Client,
 
 	projects := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+	return writeOutput(projects, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
 
-	fmt.Fprintf(w, "%s\t%s\n", "ID", "NAME")
+		fmt.Fprintf(w, "%s\t%s\n", "ID", "NAME")
 
-	currProjectID := cliConf.Project
+		currProjectID := cliConf.Project
 
-	for _, project := range projects {
-		if currProjectID == project.ID {
-			color.New(color.FgGreen).Fprintf(w, "%d\t%s (current project)\n", project.ID, project.Name)
-		} else {
-			fmt.Fprintf(w, "%d\t%s\n", project.ID, project.Name)
+		for _, project := range projects {
+			if currProjectID == project.ID {
+				color.New(color.FgGreen).Fprintf(w, "%d\t%s (current project)\n", project.ID, project.Name)
+			} else {
+				fmt.Fprintf(w, "%d\t%s\n", project.ID, project.Name)
+			}
 		}
-	}
-
-	w.Flush()
 
-	return nil
+		return w.Flush()
+	})
 }
 
 func deleteProject(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -144,6 +268,86 @@ func deleteProject(_ *types.GetAuthenticatedUserResponse, client *api.Client, ar
 	return nil
 }
 
+func listCollaborators(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListProjectCollaborators(context.Background(), cliConf.Project)
+
+	if err != nil {
+		return err
+	}
+
+	collaborators := *resp
+
+	return writeOutput(collaborators, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "USER ID", "EMAIL", "ROLE", "POLICY", "MFA")
+
+		for _, collaborator := range collaborators {
+			policy := ""
+
+			if collaborator.PolicyUID != "" {
+				policy = fmt.Sprintf("%s (%s)", collaborator.PolicyName, collaborator.PolicyUID)
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%t\n", collaborator.UserID, collaborator.Email, collaborator.Kind, policy, collaborator.MFAEnabled)
+		}
+
+		return w.Flush()
+	})
+}
+
+func listPolicies(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	policies, err := client.ListProjectPolicies(context.Background(), cliConf.Project)
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(policies, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+		fmt.Fprintf(w, "%s\t%s\n", "UID", "NAME")
+
+		for _, policy := range policies {
+			fmt.Fprintf(w, "%s\t%s\n", policy.UID, policy.Name)
+		}
+
+		return w.Flush()
+	})
+}
+
+func updateRole(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	userID, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	if types.RoleKind(roleKind) == types.RoleCustom && rolePolicyUID == "" {
+		return fmt.Errorf("--policy must be set for custom roles")
+	}
+
+	resp, err := client.UpdateProjectRole(context.Background(), cliConf.Project, &types.UpdateRoleRequest{
+		UserID:    uint(userID),
+		Kind:      roleKind,
+		PolicyUID: rolePolicyUID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if resp.PolicyUID != "" {
+		color.New(color.FgGreen).Printf("Updated role of user %d to %s with policy %s\n", userID, resp.Kind, resp.PolicyUID)
+	} else {
+		color.New(color.FgGreen).Printf("Updated role of user %d to %s\n", userID, resp.Kind)
+	}
+
+	return nil
+}
+
 func setProjectCluster(client *api.Client, projectID uint) error {
 	resp, err := client.ListProjectClusters(context.Background(), projectID)
 
@@ -159,3 +363,41 @@ func setProjectCluster(client *api.Client, projectID uint) error {
 
 	return nil
 }
+
+func requireMFA(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.UpdateProjectMFA(context.Background(), cliConf.Project, &types.UpdateProjectMFARequest{
+		Required: !requireMFADisable,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if resp.MFARequired {
+		color.New(color.FgGreen).Printf("Multi-factor authentication is now required for project %d\n", resp.ID)
+	} else {
+		color.New(color.FgGreen).Printf("Multi-factor authentication is no longer required for project %d\n", resp.ID)
+	}
+
+	return nil
+}
+
+func resetCollaboratorMFA(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	userID, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ResetCollaboratorMFA(context.Background(), cliConf.Project, &types.ResetCollaboratorMFARequest{
+		UserID: uint(userID),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Reset multi-factor authentication for %s\n", resp.Email)
+
+	return nil
+}
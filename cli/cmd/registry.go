@@ -115,24 +115,24 @@ func listRegistries(user *types.GetAuthenticatedUserResponse, client *api.Client
 
 	registries := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+	return writeOutput(registries, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
 
-	fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "URL", "SERVICE")
+		fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "URL", "SERVICE")
 
-	currRegistryID := cliConf.Registry
+		currRegistryID := cliConf.Registry
 
-	for _, registry := range registries {
-		if currRegistryID == registry.ID {
-			color.New(color.FgGreen).Fprintf(w, "%d\t%s\t%s (current registry)\n", registry.ID, registry.URL, registry.Service)
-		} else {
-			fmt.Fprintf(w, "%d\t%s\t%s\n", registry.ID, registry.URL, registry.Service)
+		for _, registry := range registries {
+			if currRegistryID == registry.ID {
+				color.New(color.FgGreen).Fprintf(w, "%d\t%s\t%s (current registry)\n", registry.ID, registry.URL, registry.Service)
+			} else {
+				fmt.Fprintf(w, "%d\t%s\t%s\n", registry.ID, registry.URL, registry.Service)
+			}
 		}
-	}
-
-	w.Flush()
 
-	return nil
+		return w.Flush()
+	})
 }
 
 func deleteRegistry(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -184,18 +184,18 @@ func listRepos(user *types.GetAuthenticatedUserResponse, client *api.Client, arg
 
 	repos := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+	return writeOutput(repos, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
 
-	fmt.Fprintf(w, "%s\t%s\n", "NAME", "CREATED_AT")
+		fmt.Fprintf(w, "%s\t%s\n", "NAME", "CREATED_AT")
 
-	for _, repo := range repos {
-		fmt.Fprintf(w, "%s\t%s\n", repo.Name, repo.CreatedAt.String())
-	}
-
-	w.Flush()
+		for _, repo := range repos {
+			fmt.Fprintf(w, "%s\t%s\n", repo.Name, repo.CreatedAt.String())
+		}
 
-	return nil
+		return w.Flush()
+	})
 }
 
 func listImages(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -217,16 +217,16 @@ func listImages(user *types.GetAuthenticatedUserResponse, client *api.Client, ar
 
 	imgs := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\n", "IMAGE", "DIGEST")
+	return writeOutput(imgs, func() error {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
 
-	for _, img := range imgs {
-		fmt.Fprintf(w, "%s\t%s\n", repoName+":"+img.Tag, img.Digest)
-	}
+		fmt.Fprintf(w, "%s\t%s\n", "IMAGE", "DIGEST")
 
-	w.Flush()
+		for _, img := range imgs {
+			fmt.Fprintf(w, "%s\t%s\n", repoName+":"+img.Tag, img.Digest)
+		}
 
-	return nil
+		return w.Flush()
+	})
 }
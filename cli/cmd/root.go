@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"runtime"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/porter-dev/porter/cli/cmd/utils"
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 	"k8s.io/client-go/util/homedir"
 )
 
@@ -32,6 +34,7 @@ func Execute() {
 	Setup()
 
 	rootCmd.PersistentFlags().AddFlagSet(utils.DefaultFlagSet)
+	rootCmd.PersistentFlags().AddFlagSet(utils.ContextFlagSet)
 
 	if config.Version != "dev" {
 		ghClient := github.NewClient(nil)
@@ -66,5 +69,35 @@ func Execute() {
 }
 
 func Setup() {
-	config.InitAndLoadConfig()
+	config.InitAndLoadConfig(getContextFlag(os.Args[1:]))
+}
+
+// getContextFlag returns the value of the global --context flag. The config is
+// loaded before cobra parses the command line, so the flag is parsed separately.
+// Commands which define their own --context flag, such as porter connect kubeconfig,
+// do not select a config context.
+func getContextFlag(args []string) string {
+	cmd, flagArgs, err := rootCmd.Find(args)
+
+	if err != nil {
+		return ""
+	}
+
+	for c := cmd; c != nil && c != rootCmd; c = c.Parent() {
+		if c.Flags().Lookup("context") != nil || c.PersistentFlags().Lookup("context") != nil {
+			return ""
+		}
+	}
+
+	contextFlagSet := flag.NewFlagSet("context", flag.ContinueOnError)
+	contextFlagSet.ParseErrorsWhitelist.UnknownFlags = true
+	contextFlagSet.SetOutput(ioutil.Discard)
+	contextFlagSet.Usage = func() {}
+
+	contextName := contextFlagSet.String("context", "", "")
+
+	// errors are ignored, since the full command line is validated by cobra
+	contextFlagSet.Parse(flagArgs)
+
+	return *contextName
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -64,6 +65,7 @@ var cleanupCmd = &cobra.Command{
 }
 
 var existingPod bool
+var containerName string
 
 func init() {
 	rootCmd.AddCommand(runCmd)
@@ -83,6 +85,14 @@ func init() {
 		"whether to connect to an existing pod",
 	)
 
+	runCmd.PersistentFlags().StringVarP(
+		&containerName,
+		"container",
+		"c",
+		"",
+		"name of the container to connect to, for pods with more than one container",
+	)
+
 	runCmd.PersistentFlags().BoolVarP(
 		&verbose,
 		"verbose",
@@ -103,12 +113,39 @@ func run(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []strin
 		return fmt.Errorf("Could not retrieve list of pods: %s", err.Error())
 	}
 
+	selectedPod, selectedContainerName, err := selectPodAndContainer(podsSimple, existingPod, containerName)
+
+	if err != nil {
+		return err
+	}
+
+	config := &PorterRunSharedConfig{
+		Client: client,
+	}
+
+	err = config.setSharedConfig()
+
+	if err != nil {
+		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
+	}
+
+	if existingPod {
+		return executeRun(config, namespace, selectedPod.Name, selectedContainerName, args[1:])
+	}
+
+	return executeRunEphemeral(config, namespace, selectedPod.Name, selectedContainerName, args[1:])
+}
+
+// selectPodAndContainer picks the pod and container to connect to. The user is prompted for
+// the pod if promptPod is set and there is more than one, and for the container if the pod
+// has more than one and no container was passed.
+func selectPodAndContainer(podsSimple []podSimple, promptPod bool, container string) (podSimple, string, error) {
 	// if length of pods is 0, throw error
 	var selectedPod podSimple
 
 	if len(podsSimple) == 0 {
-		return fmt.Errorf("At least one pod must exist in this deployment.")
-	} else if len(podsSimple) == 1 || !existingPod {
+		return selectedPod, "", fmt.Errorf("At least one pod must exist in this deployment.")
+	} else if len(podsSimple) == 1 || !promptPod {
 		selectedPod = podsSimple[0]
 	} else {
 		podNames := make([]string, 0)
@@ -120,7 +157,7 @@ func run(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []strin
 		selectedPodName, err := utils.PromptSelect("Select the pod:", podNames)
 
 		if err != nil {
-			return err
+			return selectedPod, "", err
 		}
 
 		// find selected pod
@@ -131,38 +168,32 @@ func run(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []strin
 		}
 	}
 
-	var selectedContainerName string
-
-	// if the selected pod has multiple container, spawn selector
-	if len(selectedPod.ContainerNames) == 0 {
-		return fmt.Errorf("At least one pod must exist in this deployment.")
-	} else if len(selectedPod.ContainerNames) == 1 {
-		selectedContainerName = selectedPod.ContainerNames[0]
-	} else {
-		selectedContainer, err := utils.PromptSelect("Select the container:", selectedPod.ContainerNames)
-
-		if err != nil {
-			return err
+	// if a container was passed, make sure it exists in the selected pod
+	if container != "" {
+		for _, containerName := range selectedPod.ContainerNames {
+			if containerName == container {
+				return selectedPod, container, nil
+			}
 		}
 
-		selectedContainerName = selectedContainer
+		return selectedPod, "", fmt.Errorf("Pod %s has no container named %s. Available containers: %s",
+			selectedPod.Name, container, strings.Join(selectedPod.ContainerNames, ", "))
 	}
 
-	config := &PorterRunSharedConfig{
-		Client: client,
+	// if the selected pod has multiple container, spawn selector
+	if len(selectedPod.ContainerNames) == 0 {
+		return selectedPod, "", fmt.Errorf("At least one pod must exist in this deployment.")
+	} else if len(selectedPod.ContainerNames) == 1 {
+		return selectedPod, selectedPod.ContainerNames[0], nil
 	}
 
-	err = config.setSharedConfig()
+	selectedContainer, err := utils.PromptSelect("Select the container:", selectedPod.ContainerNames)
 
 	if err != nil {
-		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
+		return selectedPod, "", err
 	}
 
-	if existingPod {
-		return executeRun(config, namespace, selectedPod.Name, selectedContainerName, args[1:])
-	}
-
-	return executeRunEphemeral(config, namespace, selectedPod.Name, selectedContainerName, args[1:])
+	return selectedPod, selectedContainer, nil
 }
 
 func cleanup(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string) error {
@@ -359,18 +390,34 @@ func executeRun(config *PorterRunSharedConfig, namespace, name, container string
 	for _, arg := range args {
 		req.Param("command", arg)
 	}
-	req.Param("stdin", "true")
-	req.Param("stdout", "true")
-	req.Param("tty", "true")
-	req.Param("container", container)
 
+	return streamToTerminal(config, req, container)
+}
+
+// streamToTerminal connects the local terminal to an exec or attach request. When stdin and
+// stdout are a terminal, the terminal is put in raw mode and resizes are forwarded to the
+// container. Otherwise, the streams are connected without a TTY so that the output can be
+// piped or redirected.
+func streamToTerminal(config *PorterRunSharedConfig, req *rest.Request, container string) error {
 	t := term.TTY{
 		In:  os.Stdin,
 		Out: os.Stdout,
 		Raw: true,
 	}
-	size := t.GetSize()
-	sizeQueue := t.MonitorSize(size)
+
+	tty := t.IsTerminalIn() && t.IsTerminalOut()
+
+	req.Param("stdin", "true")
+	req.Param("stdout", "true")
+	req.Param("stderr", strconv.FormatBool(!tty))
+	req.Param("tty", strconv.FormatBool(tty))
+	req.Param("container", container)
+
+	var sizeQueue remotecommand.TerminalSizeQueue
+
+	if tty {
+		sizeQueue = t.MonitorSize(t.GetSize())
+	}
 
 	return t.Safe(func() error {
 		exec, err := remotecommand.NewSPDYExecutor(config.RestConf, "POST", req.URL())
@@ -379,14 +426,19 @@ func executeRun(config *PorterRunSharedConfig, namespace, name, container string
 			return err
 		}
 
-		return exec.Stream(remotecommand.StreamOptions{
+		opts := remotecommand.StreamOptions{
 			Stdin:  os.Stdin,
 			Stdout: os.Stdout,
-			Stderr: os.Stderr,
-			Tty:    true,
+			Tty:    tty,
 
 			TerminalSizeQueue: sizeQueue,
-		})
+		}
+
+		if !tty {
+			opts.Stderr = os.Stderr
+		}
+
+		return exec.Stream(opts)
 	})
 }
 
@@ -445,33 +497,7 @@ func executeRunEphemeral(config *PorterRunSharedConfig, namespace, name, contain
 		Namespace(namespace).
 		SubResource("attach")
 
-	req.Param("stdin", "true")
-	req.Param("stdout", "true")
-	req.Param("tty", "true")
-	req.Param("container", container)
-
-	t := term.TTY{
-		In:  os.Stdin,
-		Out: os.Stdout,
-		Raw: true,
-	}
-	size := t.GetSize()
-	sizeQueue := t.MonitorSize(size)
-
-	if err = t.Safe(func() error {
-		exec, err := remotecommand.NewSPDYExecutor(config.RestConf, "POST", req.URL())
-		if err != nil {
-			return err
-		}
-		return exec.Stream(remotecommand.StreamOptions{
-			Stdin:  os.Stdin,
-			Stdout: os.Stdout,
-			Stderr: os.Stderr,
-			Tty:    true,
-
-			TerminalSizeQueue: sizeQueue,
-		})
-	}); err != nil {
+	if err = streamToTerminal(config, req, container); err != nil {
 		// ugly way to catch no TTY errors, such as when running command "echo \"hello\""
 		return handlePodAttachError(err, config, namespace, podName, container)
 	}
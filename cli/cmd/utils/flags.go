@@ -4,6 +4,7 @@ import flag "github.com/spf13/pflag"
 
 // shared sets of flags used by multiple commands
 var DriverFlagSet = flag.NewFlagSet("driver", flag.ExitOnError)
-var DefaultFlagSet = flag.NewFlagSet("shared", flag.ExitOnError) // used by all commands
+var DefaultFlagSet = flag.NewFlagSet("shared", flag.ExitOnError)  // used by all commands
+var ContextFlagSet = flag.NewFlagSet("context", flag.ExitOnError) // used by all commands, not bound to the config file
 var RegistryFlagSet = flag.NewFlagSet("registry", flag.ExitOnError)
 var HelmRepoFlagSet = flag.NewFlagSet("helmrepo", flag.ExitOnError)
@@ -8,6 +8,9 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/porter-dev/porter/api/server/handlers/environment"
+	"github.com/porter-dev/porter/api/server/handlers/namespace"
+	"github.com/porter-dev/porter/api/server/handlers/registry"
 	"github.com/porter-dev/porter/api/server/router"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/config/loader"
@@ -45,6 +48,11 @@ func main() {
 
 	appRouter := router.NewAPIRouter(config)
 
+	environment.StartPreviewReaper(config)
+	namespace.StartEnvGroupSourceSyncer(config)
+	namespace.StartEnvGroupRetentionJob(config)
+	registry.StartImageRetentionJob(config)
+
 	address := fmt.Sprintf(":%d", config.ServerConf.Port)
 
 	config.Logger.Info().Msgf("Starting server %v", address)
@@ -27,7 +27,7 @@ func NewPorterHelper(debug bool) *PorterHelper {
 	if token := cliConfig.Token; token != "" {
 		client = api.NewClientWithToken(cliConfig.Host+"/api", token)
 	} else {
-		client = api.NewClient(cliConfig.Host+"/api", "cookie.json")
+		client = api.NewClient(cliConfig.Host+"/api", cliConfig.CookieFileName())
 	}
 
 	return &PorterHelper{
@@ -1,6 +1,7 @@
 package keyrotate_test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -38,6 +39,10 @@ type tester struct {
 	initOAuths   []*ints.OAuthIntegration
 	initGCPs     []*ints.GCPIntegration
 	initAWSs     []*ints.AWSIntegration
+	initWebhooks []*ints.WebhookIntegration
+	initTeams    []*ints.TeamsIntegration
+	initDiscords []*ints.DiscordIntegration
+	initVaults   []*ints.VaultIntegration
 }
 
 func setupTestEnv(tester *tester, t *testing.T) {
@@ -76,6 +81,10 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&ints.OAuthIntegration{},
 		&ints.GCPIntegration{},
 		&ints.AWSIntegration{},
+		&ints.WebhookIntegration{},
+		&ints.TeamsIntegration{},
+		&ints.DiscordIntegration{},
+		&ints.VaultIntegration{},
 		&ints.ClusterTokenCache{},
 		&ints.RegTokenCache{},
 		&ints.HelmRepoTokenCache{},
@@ -122,6 +131,28 @@ func initUser(tester *tester, t *testing.T) {
 	tester.initUsers = append(tester.initUsers, user)
 }
 
+func initMFAUser(tester *tester, t *testing.T, enabled bool) {
+	t.Helper()
+
+	user := &models.User{
+		Email:    fmt.Sprintf("example-%d@example.com", len(tester.initUsers)),
+		Password: "hello1234",
+	}
+
+	if enabled {
+		user.TOTPSecret = []byte("JBSWY3DPEHPK3PXP")
+		user.TOTPEnabled = true
+	}
+
+	user, err := tester.repo.User().CreateUser(user)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tester.initUsers = append(tester.initUsers, user)
+}
+
 func initProject(tester *tester, t *testing.T) {
 	t.Helper()
 
@@ -335,6 +366,116 @@ func initAWSIntegration(tester *tester, t *testing.T) {
 	tester.initAWSs = append(tester.initAWSs, aws)
 }
 
+func initWebhookIntegration(tester *tester, t *testing.T) {
+	t.Helper()
+
+	if len(tester.initProjects) == 0 {
+		initProject(tester, t)
+	}
+
+	if len(tester.initUsers) == 0 {
+		initUser(tester, t)
+	}
+
+	webhookInt := &ints.WebhookIntegration{
+		ProjectID:     tester.initProjects[0].ID,
+		UserID:        tester.initUsers[0].ID,
+		Name:          "on-call",
+		URL:           []byte("https://alerts.example.com/porter"),
+		SigningSecret: []byte("secret"),
+	}
+
+	webhookInt, err := tester.repo.WebhookIntegration().CreateWebhookIntegration(webhookInt)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tester.initWebhooks = append(tester.initWebhooks, webhookInt)
+}
+
+func initTeamsIntegration(tester *tester, t *testing.T) {
+	t.Helper()
+
+	if len(tester.initProjects) == 0 {
+		initProject(tester, t)
+	}
+
+	if len(tester.initUsers) == 0 {
+		initUser(tester, t)
+	}
+
+	teamsInt := &ints.TeamsIntegration{
+		ProjectID: tester.initProjects[0].ID,
+		UserID:    tester.initUsers[0].ID,
+		Name:      "deployments",
+		Webhook:   []byte("https://example.webhook.office.com/webhookb2/abc"),
+	}
+
+	teamsInt, err := tester.repo.TeamsIntegration().CreateTeamsIntegration(teamsInt)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tester.initTeams = append(tester.initTeams, teamsInt)
+}
+
+func initDiscordIntegration(tester *tester, t *testing.T) {
+	t.Helper()
+
+	if len(tester.initProjects) == 0 {
+		initProject(tester, t)
+	}
+
+	if len(tester.initUsers) == 0 {
+		initUser(tester, t)
+	}
+
+	discordInt := &ints.DiscordIntegration{
+		ProjectID: tester.initProjects[0].ID,
+		UserID:    tester.initUsers[0].ID,
+		Name:      "deployments",
+		Webhook:   []byte("https://discord.com/api/webhooks/123/abc"),
+	}
+
+	discordInt, err := tester.repo.DiscordIntegration().CreateDiscordIntegration(discordInt)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tester.initDiscords = append(tester.initDiscords, discordInt)
+}
+
+func initVaultIntegration(tester *tester, t *testing.T) {
+	t.Helper()
+
+	if len(tester.initProjects) == 0 {
+		initProject(tester, t)
+	}
+
+	if len(tester.initUsers) == 0 {
+		initUser(tester, t)
+	}
+
+	vaultInt := &ints.VaultIntegration{
+		ProjectID: tester.initProjects[0].ID,
+		UserID:    tester.initUsers[0].ID,
+		Name:      "vault",
+		Address:   "https://vault.example.com:8200",
+		Token:     []byte("hvs.token"),
+	}
+
+	vaultInt, err := tester.repo.VaultIntegration().CreateVaultIntegration(vaultInt)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tester.initVaults = append(tester.initVaults, vaultInt)
+}
+
 func initClusterCandidate(tester *tester, t *testing.T) {
 	t.Helper()
 
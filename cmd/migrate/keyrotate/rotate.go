@@ -109,6 +109,46 @@ func Rotate(db *_gorm.DB, oldKey, newKey *[32]byte) error {
 		return err
 	}
 
+	err = rotateWebhookIntegrationModel(db, oldKey, newKey)
+
+	if err != nil {
+		fmt.Printf("failed on webhook rotation: %v\n", err)
+
+		return err
+	}
+
+	err = rotateTeamsIntegrationModel(db, oldKey, newKey)
+
+	if err != nil {
+		fmt.Printf("failed on teams rotation: %v\n", err)
+
+		return err
+	}
+
+	err = rotateDiscordIntegrationModel(db, oldKey, newKey)
+
+	if err != nil {
+		fmt.Printf("failed on discord rotation: %v\n", err)
+
+		return err
+	}
+
+	err = rotateVaultIntegrationModel(db, oldKey, newKey)
+
+	if err != nil {
+		fmt.Printf("failed on vault rotation: %v\n", err)
+
+		return err
+	}
+
+	err = rotateUserModel(db, oldKey, newKey)
+
+	if err != nil {
+		fmt.Printf("failed on user rotation: %v\n", err)
+
+		return err
+	}
+
 	return nil
 }
 
@@ -728,3 +768,266 @@ func rotateAWSIntegrationModel(db *_gorm.DB, oldKey, newKey *[32]byte) error {
 
 	return nil
 }
+
+func rotateUserModel(db *_gorm.DB, oldKey, newKey *[32]byte) error {
+	// only users who have enrolled in MFA have encrypted data
+	query := db.Model(&models.User{}).Where("totp_secret IS NOT NULL")
+
+	// get count of model
+	var count int64
+
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+
+	repo := gorm.NewUserRepository(db, oldKey).(*gorm.UserRepository)
+
+	// iterate (count / stepSize) + 1 times using Limit and Offset
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		users := []*models.User{}
+
+		if err := db.Where("totp_secret IS NOT NULL").Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&users).Error; err != nil {
+			return err
+		}
+
+		// decrypt with the old key. Unlike credentials, the TOTP secret is not wiped if it
+		// can't be decrypted, since that would turn off MFA for the user.
+		for _, user := range users {
+			err := repo.DecryptUserData(user, oldKey)
+
+			if err != nil {
+				fmt.Printf("error decrypting user %d\n", user.ID)
+
+				return err
+			}
+		}
+
+		// encrypt with the new key and re-insert
+		for _, user := range users {
+			err := repo.EncryptUserData(user, newKey)
+
+			if err != nil {
+				fmt.Printf("error encrypting user %d\n", user.ID)
+
+				return err
+			}
+
+			if err := db.Save(user).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("rotated %d users\n", count)
+
+	return nil
+}
+
+func rotateWebhookIntegrationModel(db *_gorm.DB, oldKey, newKey *[32]byte) error {
+	// get count of model
+	var count int64
+
+	if err := db.Model(&ints.WebhookIntegration{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	repo := gorm.NewWebhookIntegrationRepository(db, oldKey).(*gorm.WebhookIntegrationRepository)
+
+	// iterate (count / stepSize) + 1 times using Limit and Offset
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		webhookInts := []*ints.WebhookIntegration{}
+
+		if err := db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&webhookInts).Error; err != nil {
+			return err
+		}
+
+		// decrypt with the old key
+		for _, webhookInt := range webhookInts {
+			err := repo.DecryptWebhookIntegrationData(webhookInt, oldKey)
+
+			if err != nil {
+				fmt.Printf("error decrypting webhook integration %d\n", webhookInt.ID)
+
+				// in these cases we'll wipe the data -- if it can't be decrypted, we can't
+				// recover it
+				webhookInt.URL = []byte{}
+				webhookInt.SigningSecret = []byte{}
+			}
+		}
+
+		// encrypt with the new key and re-insert
+		for _, webhookInt := range webhookInts {
+			err := repo.EncryptWebhookIntegrationData(webhookInt, newKey)
+
+			if err != nil {
+				fmt.Printf("error encrypting webhook integration %d\n", webhookInt.ID)
+
+				return err
+			}
+
+			if err := db.Save(webhookInt).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("rotated %d webhook integrations\n", count)
+
+	return nil
+}
+
+func rotateTeamsIntegrationModel(db *_gorm.DB, oldKey, newKey *[32]byte) error {
+	// get count of model
+	var count int64
+
+	if err := db.Model(&ints.TeamsIntegration{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	repo := gorm.NewTeamsIntegrationRepository(db, oldKey).(*gorm.TeamsIntegrationRepository)
+
+	// iterate (count / stepSize) + 1 times using Limit and Offset
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		teamsInts := []*ints.TeamsIntegration{}
+
+		if err := db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&teamsInts).Error; err != nil {
+			return err
+		}
+
+		// decrypt with the old key
+		for _, teamsInt := range teamsInts {
+			err := repo.DecryptTeamsIntegrationData(teamsInt, oldKey)
+
+			if err != nil {
+				fmt.Printf("error decrypting teams integration %d\n", teamsInt.ID)
+
+				// in these cases we'll wipe the data -- if it can't be decrypted, we can't
+				// recover it
+				teamsInt.Webhook = []byte{}
+			}
+		}
+
+		// encrypt with the new key and re-insert
+		for _, teamsInt := range teamsInts {
+			err := repo.EncryptTeamsIntegrationData(teamsInt, newKey)
+
+			if err != nil {
+				fmt.Printf("error encrypting teams integration %d\n", teamsInt.ID)
+
+				return err
+			}
+
+			if err := db.Save(teamsInt).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("rotated %d teams integrations\n", count)
+
+	return nil
+}
+
+func rotateDiscordIntegrationModel(db *_gorm.DB, oldKey, newKey *[32]byte) error {
+	// get count of model
+	var count int64
+
+	if err := db.Model(&ints.DiscordIntegration{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	repo := gorm.NewDiscordIntegrationRepository(db, oldKey).(*gorm.DiscordIntegrationRepository)
+
+	// iterate (count / stepSize) + 1 times using Limit and Offset
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		discordInts := []*ints.DiscordIntegration{}
+
+		if err := db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&discordInts).Error; err != nil {
+			return err
+		}
+
+		// decrypt with the old key
+		for _, discordInt := range discordInts {
+			err := repo.DecryptDiscordIntegrationData(discordInt, oldKey)
+
+			if err != nil {
+				fmt.Printf("error decrypting discord integration %d\n", discordInt.ID)
+
+				// in these cases we'll wipe the data -- if it can't be decrypted, we can't
+				// recover it
+				discordInt.Webhook = []byte{}
+			}
+		}
+
+		// encrypt with the new key and re-insert
+		for _, discordInt := range discordInts {
+			err := repo.EncryptDiscordIntegrationData(discordInt, newKey)
+
+			if err != nil {
+				fmt.Printf("error encrypting discord integration %d\n", discordInt.ID)
+
+				return err
+			}
+
+			if err := db.Save(discordInt).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("rotated %d discord integrations\n", count)
+
+	return nil
+}
+
+func rotateVaultIntegrationModel(db *_gorm.DB, oldKey, newKey *[32]byte) error {
+	// get count of model
+	var count int64
+
+	if err := db.Model(&ints.VaultIntegration{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	repo := gorm.NewVaultIntegrationRepository(db, oldKey).(*gorm.VaultIntegrationRepository)
+
+	// iterate (count / stepSize) + 1 times using Limit and Offset
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		vaultInts := []*ints.VaultIntegration{}
+
+		if err := db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&vaultInts).Error; err != nil {
+			return err
+		}
+
+		// decrypt with the old key
+		for _, vaultInt := range vaultInts {
+			err := repo.DecryptVaultIntegrationData(vaultInt, oldKey)
+
+			if err != nil {
+				fmt.Printf("error decrypting vault integration %d\n", vaultInt.ID)
+
+				// in these cases we'll wipe the data -- if it can't be decrypted, we can't
+				// recover it
+				vaultInt.Token = []byte{}
+			}
+		}
+
+		// encrypt with the new key and re-insert
+		for _, vaultInt := range vaultInts {
+			err := repo.EncryptVaultIntegrationData(vaultInt, newKey)
+
+			if err != nil {
+				fmt.Printf("error encrypting vault integration %d\n", vaultInt.ID)
+
+				return err
+			}
+
+			if err := db.Save(vaultInt).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("rotated %d vault integrations\n", count)
+
+	return nil
+}
@@ -608,3 +608,239 @@ func TestAWSIntegrationModelRotation(t *testing.T) {
 		}
 	}
 }
+
+func TestUserModelRotation(t *testing.T) {
+	var newKey [32]byte
+
+	for i, b := range []byte("__r3n3o3_s3r3n3_3n3r3p3i3n_k3y__") {
+		newKey[i] = b
+	}
+
+	tester := &tester{
+		dbFileName: "./porter_user_rotate.db",
+	}
+
+	setupTestEnv(tester, t)
+
+	// users who have not enrolled in MFA don't have a secret to rotate
+	for i := 0; i < 128; i++ {
+		initMFAUser(tester, t, i%2 == 0)
+	}
+
+	defer cleanup(tester, t)
+
+	err := keyrotate.Rotate(tester.DB, tester.Key, &newKey)
+
+	if err != nil {
+		t.Fatalf("error rotating: %v\n", err)
+	}
+
+	// very all users decoded properly
+	repo := gorm.NewUserRepository(tester.DB, &newKey)
+
+	for _, initUser := range tester.initUsers {
+		user, err := repo.ReadUser(initUser.ID)
+
+		if err != nil {
+			t.Fatalf("error reading user: %v\n", err)
+		}
+
+		if user.TOTPEnabled && string(user.TOTPSecret) != "JBSWY3DPEHPK3PXP" {
+			t.Errorf("%s\n", string(user.TOTPSecret))
+		} else if !user.TOTPEnabled && len(user.TOTPSecret) != 0 {
+			t.Errorf("expected no totp secret for user %d, got %s\n", user.ID, string(user.TOTPSecret))
+		}
+	}
+}
+
+func TestWebhookIntegrationModelRotation(t *testing.T) {
+	var newKey [32]byte
+
+	for i, b := range []byte("__r3n3o3_s3r3n3_3n3r3p3i3n_k3y__") {
+		newKey[i] = b
+	}
+
+	tester := &tester{
+		dbFileName: "./porter_webhook_rotate.db",
+	}
+
+	setupTestEnv(tester, t)
+
+	for i := 0; i < 128; i++ {
+		initWebhookIntegration(tester, t)
+	}
+
+	defer cleanup(tester, t)
+
+	err := keyrotate.Rotate(tester.DB, tester.Key, &newKey)
+
+	if err != nil {
+		t.Fatalf("error rotating: %v\n", err)
+	}
+
+	// very all webhook integrations decoded properly
+	repo := gorm.NewWebhookIntegrationRepository(tester.DB, &newKey)
+
+	webhookInts := []*ints.WebhookIntegration{}
+
+	if err := tester.DB.Find(&webhookInts).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	for _, k := range webhookInts {
+		webhookInt, err := repo.ReadWebhookIntegration(k.ProjectID, k.ID)
+
+		if err != nil {
+			t.Fatalf("error reading webhook integration: %v\n", err)
+		}
+
+		if string(webhookInt.URL) != "https://alerts.example.com/porter" {
+			t.Errorf("%s\n", string(webhookInt.URL))
+		}
+
+		if string(webhookInt.SigningSecret) != "secret" {
+			t.Errorf("%s\n", string(webhookInt.SigningSecret))
+		}
+	}
+}
+
+func TestTeamsIntegrationModelRotation(t *testing.T) {
+	var newKey [32]byte
+
+	for i, b := range []byte("__r3n3o3_s3r3n3_3n3r3p3i3n_k3y__") {
+		newKey[i] = b
+	}
+
+	tester := &tester{
+		dbFileName: "./porter_teams_rotate.db",
+	}
+
+	setupTestEnv(tester, t)
+
+	for i := 0; i < 128; i++ {
+		initTeamsIntegration(tester, t)
+	}
+
+	defer cleanup(tester, t)
+
+	err := keyrotate.Rotate(tester.DB, tester.Key, &newKey)
+
+	if err != nil {
+		t.Fatalf("error rotating: %v\n", err)
+	}
+
+	// very all teams integrations decoded properly
+	repo := gorm.NewTeamsIntegrationRepository(tester.DB, &newKey)
+
+	teamsInts := []*ints.TeamsIntegration{}
+
+	if err := tester.DB.Find(&teamsInts).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	for _, k := range teamsInts {
+		teamsInt, err := repo.ReadTeamsIntegration(k.ProjectID, k.ID)
+
+		if err != nil {
+			t.Fatalf("error reading teams integration: %v\n", err)
+		}
+
+		if string(teamsInt.Webhook) != "https://example.webhook.office.com/webhookb2/abc" {
+			t.Errorf("%s\n", string(teamsInt.Webhook))
+		}
+	}
+}
+
+func TestDiscordIntegrationModelRotation(t *testing.T) {
+	var newKey [32]byte
+
+	for i, b := range []byte("__r3n3o3_s3r3n3_3n3r3p3i3n_k3y__") {
+		newKey[i] = b
+	}
+
+	tester := &tester{
+		dbFileName: "./porter_discord_rotate.db",
+	}
+
+	setupTestEnv(tester, t)
+
+	for i := 0; i < 128; i++ {
+		initDiscordIntegration(tester, t)
+	}
+
+	defer cleanup(tester, t)
+
+	err := keyrotate.Rotate(tester.DB, tester.Key, &newKey)
+
+	if err != nil {
+		t.Fatalf("error rotating: %v\n", err)
+	}
+
+	// very all discord integrations decoded properly
+	repo := gorm.NewDiscordIntegrationRepository(tester.DB, &newKey)
+
+	discordInts := []*ints.DiscordIntegration{}
+
+	if err := tester.DB.Find(&discordInts).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	for _, k := range discordInts {
+		discordInt, err := repo.ReadDiscordIntegration(k.ProjectID, k.ID)
+
+		if err != nil {
+			t.Fatalf("error reading discord integration: %v\n", err)
+		}
+
+		if string(discordInt.Webhook) != "https://discord.com/api/webhooks/123/abc" {
+			t.Errorf("%s\n", string(discordInt.Webhook))
+		}
+	}
+}
+
+func TestVaultIntegrationModelRotation(t *testing.T) {
+	var newKey [32]byte
+
+	for i, b := range []byte("__r3n3o3_s3r3n3_3n3r3p3i3n_k3y__") {
+		newKey[i] = b
+	}
+
+	tester := &tester{
+		dbFileName: "./porter_vault_rotate.db",
+	}
+
+	setupTestEnv(tester, t)
+
+	for i := 0; i < 128; i++ {
+		initVaultIntegration(tester, t)
+	}
+
+	defer cleanup(tester, t)
+
+	err := keyrotate.Rotate(tester.DB, tester.Key, &newKey)
+
+	if err != nil {
+		t.Fatalf("error rotating: %v\n", err)
+	}
+
+	// very all vault integrations decoded properly
+	repo := gorm.NewVaultIntegrationRepository(tester.DB, &newKey)
+
+	vaultInts := []*ints.VaultIntegration{}
+
+	if err := tester.DB.Find(&vaultInts).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	for _, k := range vaultInts {
+		vaultInt, err := repo.ReadVaultIntegration(k.ProjectID, k.ID)
+
+		if err != nil {
+			t.Fatalf("error reading vault integration: %v\n", err)
+		}
+
+		if string(vaultInt.Token) != "hvs.token" {
+			t.Errorf("%s\n", string(vaultInt.Token))
+		}
+	}
+}
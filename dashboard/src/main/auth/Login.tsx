@@ -20,7 +20,10 @@ type StateType = {
   hasBasic: boolean;
   hasGithub: boolean;
   hasGoogle: boolean;
+  hasOIDC: boolean;
   hasResetPassword: boolean;
+  mfaPending: boolean;
+  code: string;
 };
 
 export default class Login extends Component<PropsType, StateType> {
@@ -32,15 +35,27 @@ export default class Login extends Component<PropsType, StateType> {
     hasBasic: true,
     hasGithub: true,
     hasGoogle: false,
+    hasOIDC: false,
     hasResetPassword: true,
+    mfaPending: false,
+    code: "",
   };
 
   handleKeyDown = (e: any) => {
-    e.key === "Enter" ? this.handleLogin() : null;
+    if (e.key === "Enter") {
+      this.state.mfaPending ? this.handleMFALogin() : this.handleLogin();
+    }
   };
 
   componentDidMount() {
     let urlParams = new URLSearchParams(window.location.search);
+
+    // users with a second factor who log in with an OAuth provider are redirected back
+    // here to enter a one-time password
+    if (urlParams.get("mfa") === "true") {
+      this.setState({ mfaPending: true });
+    }
+
     let emailFromCLI = urlParams.get("email");
     emailFromCLI
       ? this.setState({ email: emailFromCLI })
@@ -54,6 +69,7 @@ export default class Login extends Component<PropsType, StateType> {
           hasBasic: res.data?.basic_login,
           hasGithub: res.data?.github_login,
           hasGoogle: res.data?.google_login,
+          hasOIDC: res.data?.oidc_login,
           hasResetPassword: res.data?.email,
         });
       })
@@ -85,7 +101,9 @@ export default class Login extends Component<PropsType, StateType> {
         )
         .then((res) => {
           // TODO: case and set credential error
-          if (res?.data?.redirect) {
+          if (res?.data?.mfa_required) {
+            this.setState({ mfaPending: true, code: "" });
+          } else if (res?.data?.redirect) {
             window.location.href = res.data.redirect;
           } else {
             setUser(res?.data?.id, res?.data?.email);
@@ -96,6 +114,66 @@ export default class Login extends Component<PropsType, StateType> {
     }
   };
 
+  handleMFALogin = (): void => {
+    let { code } = this.state;
+    let { authenticate } = this.props;
+    let { setUser } = this.context;
+
+    api
+      .logInUserMFA("", { code: code.trim() }, {})
+      .then((res) => {
+        if (res?.data?.redirect) {
+          window.location.href = res.data.redirect;
+        } else {
+          setUser(res?.data?.id, res?.data?.email);
+          authenticate();
+        }
+      })
+      .catch((err) => {
+        // an expired login has to start again with the password
+        if (
+          err.response?.status === 401 &&
+          err.response?.data?.error?.includes("expired")
+        ) {
+          this.setState({ mfaPending: false, code: "", password: "" });
+        } else {
+          this.setState({ code: "" });
+        }
+
+        this.context.setCurrentError(err.response?.data?.error);
+      });
+  };
+
+  renderMFASection = () => {
+    return (
+      <div>
+        <MFAHint>
+          Enter the code from your authenticator app or a recovery code
+        </MFAHint>
+        <InputWrapper>
+          <Input
+            type="text"
+            autoComplete="one-time-code"
+            placeholder="Code"
+            value={this.state.code}
+            onChange={(e: ChangeEvent<HTMLInputElement>) =>
+              this.setState({ code: e.target.value })
+            }
+            valid={true}
+          />
+        </InputWrapper>
+        <Button onClick={this.handleMFALogin}>Continue</Button>
+        <Helper>
+          <Link
+            onClick={() => this.setState({ mfaPending: false, code: "" })}
+          >
+            Back to login
+          </Link>
+        </Helper>
+      </div>
+    );
+  };
+
   renderEmailError = () => {
     let { emailError } = this.state;
     if (emailError) {
@@ -130,6 +208,11 @@ export default class Login extends Component<PropsType, StateType> {
     window.location.href = redirectUrl;
   };
 
+  oidcRedirect = () => {
+    let redirectUrl = `/api/oauth/login/oidc`;
+    window.location.href = redirectUrl;
+  };
+
   renderGithubSection = () => {
     if (this.state.hasGithub) {
       return (
@@ -156,6 +239,16 @@ export default class Login extends Component<PropsType, StateType> {
     }
   };
 
+  renderOIDCSection = () => {
+    if (this.state.hasOIDC) {
+      return (
+        <OAuthButton onClick={this.oidcRedirect}>
+          <IconWrapper>Log in with SSO</IconWrapper>
+        </OAuthButton>
+      );
+    }
+  };
+
   renderBasicSection = () => {
     if (this.state.hasBasic) {
       let { email, password, credentialError, emailError } = this.state;
@@ -199,6 +292,28 @@ export default class Login extends Component<PropsType, StateType> {
     }
   };
 
+  renderLoginSection = () => {
+    return (
+      <>
+        {this.renderGithubSection()}
+        {this.renderGoogleSection()}
+        {this.renderOIDCSection()}
+        {(this.state.hasGithub ||
+          this.state.hasGoogle ||
+          this.state.hasOIDC) &&
+        this.state.hasBasic ? (
+          <OrWrapper>
+            <Line />
+            <Or>or</Or>
+          </OrWrapper>
+        ) : null}
+        <DarkMatter />
+        {this.renderBasicSection()}
+        {this.renderHelper()}
+      </>
+    );
+  };
+
   renderHelper() {
     if (this.state.hasResetPassword) {
       return (
@@ -221,7 +336,9 @@ export default class Login extends Component<PropsType, StateType> {
       <StyledLogin>
         <LoginPanel
           hasBasic={this.state.hasBasic}
-          numOAuth={+this.state.hasGithub + +this.state.hasGoogle}
+          numOAuth={
+            +this.state.hasGithub + +this.state.hasGoogle + +this.state.hasOIDC
+          }
         >
           <OverflowWrapper>
             <GradientBg />
@@ -229,18 +346,9 @@ export default class Login extends Component<PropsType, StateType> {
           <FormWrapper>
             <Logo src={logo} />
             <Prompt>Log in to Porter</Prompt>
-            {this.renderGithubSection()}
-            {this.renderGoogleSection()}
-            {(this.state.hasGithub || this.state.hasGoogle) &&
-            this.state.hasBasic ? (
-              <OrWrapper>
-                <Line />
-                <Or>or</Or>
-              </OrWrapper>
-            ) : null}
-            <DarkMatter />
-            {this.renderBasicSection()}
-            {this.renderHelper()}
+            {this.state.mfaPending
+              ? this.renderMFASection()
+              : this.renderLoginSection()}
           </FormWrapper>
         </LoginPanel>
         <Footer>
@@ -428,6 +536,13 @@ const Input = styled.input`
   font-size: 14px;
 `;
 
+const MFAHint = styled.div`
+  font-family: "Work Sans", sans-serif;
+  font-size: 13px;
+  color: #aaaabb;
+  margin-bottom: 15px;
+`;
+
 const Prompt = styled.div`
   font-family: "Work Sans", sans-serif;
   font-weight: 500;
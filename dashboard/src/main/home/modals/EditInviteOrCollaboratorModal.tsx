@@ -28,10 +28,13 @@ const EditCollaboratorModal = () => {
     api
       .getAvailableRoles("<token>", {}, { project_id })
       .then(({ data }: { data: string[] }) => {
-        const availableRoleList = data?.map((role) => ({
-          value: role,
-          label: capitalizeFirstLetter(role),
-        }));
+        // custom roles need a policy, which can't be picked here yet
+        const availableRoleList = data
+          ?.filter((role) => role !== "custom")
+          .map((role) => ({
+            value: role,
+            label: capitalizeFirstLetter(role),
+          }));
         setRoleList(availableRoleList);
         setSelectedRole(user?.kind || "developer");
       });
@@ -46,10 +46,13 @@ const InvitePage: React.FunctionComponent<Props> = ({}) => {
     api
       .getAvailableRoles("<token>", {}, { project_id: currentProject?.id })
       .then(({ data }: { data: string[] }) => {
-        const availableRoleList = data?.map((role) => ({
-          value: role,
-          label: capitalizeFirstLetter(role),
-        }));
+        // custom roles need a policy, which can't be picked here yet
+        const availableRoleList = data
+          ?.filter((role) => role !== "custom")
+          .map((role) => ({
+            value: role,
+            label: capitalizeFirstLetter(role),
+          }));
         setRoleList(availableRoleList);
         setRole("developer");
       });
@@ -1212,6 +1212,10 @@ const logInUser = baseApi<{
   password: string;
 }>("POST", "/api/login");
 
+const logInUserMFA = baseApi<{
+  code: string;
+}>("POST", "/api/login/mfa");
+
 const logOutUser = baseApi("POST", "/api/logout");
 
 const registerUser = baseApi<{
@@ -2047,6 +2051,7 @@ export default {
   getGithubAccounts,
   listConfigMaps,
   logInUser,
+  logInUserMFA,
   logOutUser,
   registerUser,
   rollbackChart,
@@ -0,0 +1,154 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the number of seconds that a one-time password is valid for
+	Period = 30
+
+	// Digits is the number of digits of a one-time password
+	Digits = 6
+
+	// Skew is the number of periods before and after the current period for which
+	// one-time passwords are accepted, to allow for clock drift
+	Skew = 1
+
+	secretLength       = 20
+	recoveryCodeLength = 10
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a random base32-encoded secret
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return b32.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI that authenticator apps use to enrol the
+// secret, typically rendered as a QR code
+func ProvisioningURI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", Digits))
+	v.Set("period", fmt.Sprintf("%d", Period))
+
+	return fmt.Sprintf(
+		"otpauth://totp/%s:%s?%s",
+		url.PathEscape(issuer),
+		url.PathEscape(account),
+		v.Encode(),
+	)
+}
+
+// GenerateCode computes the one-time password for the secret at the given time
+func GenerateCode(secret string, t time.Time) (string, error) {
+	return generateCodeForStep(secret, t.Unix()/Period)
+}
+
+// Validate checks a one-time password against the secret at the given time. Codes
+// from periods at or before lastStep are rejected, so that a code cannot be used twice.
+// On success, the period of the matched code is returned, and should be stored as the
+// lastStep of the next validation.
+func Validate(secret, code string, t time.Time, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	step := t.Unix() / Period
+
+	for i := -Skew; i <= Skew; i++ {
+		candidate := step + int64(i)
+
+		if candidate <= lastStep {
+			continue
+		}
+
+		expected, err := generateCodeForStep(secret, candidate)
+
+		if err != nil {
+			return 0, false
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return candidate, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes generates n random single-use recovery codes
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	res := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		raw := make([]byte, recoveryCodeLength)
+
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+
+		code := strings.ToLower(b32.EncodeToString(raw))[:recoveryCodeLength]
+		res = append(res, code[:5]+"-"+code[5:])
+	}
+
+	return res, nil
+}
+
+// HashRecoveryCode hashes a recovery code for storage. Since recovery codes are random,
+// a fast hash is sufficient.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func generateCodeForStep(secret string, step int64) (string, error) {
+	key, err := b32.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+
+	if err != nil {
+		return "", fmt.Errorf("invalid totp secret: %w", err)
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, as defined in RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
@@ -0,0 +1,94 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/auth/totp"
+)
+
+// secret from the test vectors of RFC 6238
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	tests := []struct {
+		unix    int64
+		expCode string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range tests {
+		code, err := totp.GenerateCode(rfcSecret, time.Unix(tc.unix, 0))
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if code != tc.expCode {
+			t.Errorf("at %d: expected code %s, got %s", tc.unix, tc.expCode, code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := now.Unix() / totp.Period
+
+	code, _ := totp.GenerateCode(rfcSecret, now)
+	prevCode, _ := totp.GenerateCode(rfcSecret, now.Add(-totp.Period*time.Second))
+	oldCode, _ := totp.GenerateCode(rfcSecret, now.Add(-5*totp.Period*time.Second))
+
+	tests := []struct {
+		name     string
+		code     string
+		lastStep int64
+		expStep  int64
+		expValid bool
+	}{
+		{"current code", code, 0, step, true},
+		{"previous code within skew", prevCode, 0, step - 1, true},
+		{"code outside of skew", oldCode, 0, 0, false},
+		{"reused code", code, step, 0, false},
+		{"wrong length", "12345", 0, 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotStep, valid := totp.Validate(rfcSecret, tc.code, now, tc.lastStep)
+
+			if valid != tc.expValid || gotStep != tc.expStep {
+				t.Errorf("expected (%d, %t), got (%d, %t)", tc.expStep, tc.expValid, gotStep, valid)
+			}
+		})
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := totp.GenerateRecoveryCodes(10)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, code := range codes {
+		hash := totp.HashRecoveryCode(code)
+
+		if seen[hash] {
+			t.Errorf("duplicate recovery code %s", code)
+		}
+
+		seen[hash] = true
+
+		if totp.HashRecoveryCode(" "+code[:5]+code[6:]+" ") != hash {
+			t.Errorf("expected recovery code %s to be normalized before hashing", code)
+		}
+	}
+}
@@ -93,6 +93,35 @@ func (a *Agent) ListReleases(
 	return res, nil
 }
 
+// ListDeployedReleases lists the deployed revision of each release in a namespace, or in all
+// namespaces if the namespace is empty, along with the revisions that are being installed,
+// upgraded or rolled back
+func (a *Agent) ListDeployedReleases(namespace string) ([]*release.Release, error) {
+	// the latest revision of a release may have failed while an older revision is still
+	// deployed, so deployed and pending revisions are listed separately
+	deployed, err := a.ListReleases(namespace, &types.ReleaseListFilter{
+		StatusFilter: []string{"deployed"},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := a.ListReleases(namespace, &types.ReleaseListFilter{
+		StatusFilter: []string{
+			"pending-install",
+			"pending-upgrade",
+			"pending-rollback",
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(deployed, pending...), nil
+}
+
 // GetRelease returns the info of a release.
 func (a *Agent) GetRelease(
 	name string,
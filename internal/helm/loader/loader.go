@@ -80,6 +80,10 @@ type BasicAuthClient struct {
 
 // LoadRepoIndex uses an http request to get the index file and loads it
 func LoadRepoIndex(client *BasicAuthClient, repoURL string) (*repo.IndexFile, error) {
+	if IsOCIRepoURL(repoURL) {
+		return nil, fmt.Errorf("OCI repo %s does not have an index file", repoURL)
+	}
+
 	trimmedRepoURL := strings.TrimSuffix(strings.TrimSpace(repoURL), "/")
 	indexURL := trimmedRepoURL + "/index.yaml"
 
@@ -125,8 +129,13 @@ func LoadRepoIndexPublic(repoURL string) (*repo.IndexFile, error) {
 	return LoadRepoIndex(&BasicAuthClient{}, repoURL)
 }
 
-// LoadChart uses an http request to fetch a chart from a remote Helm repo
+// LoadChart uses an http request to fetch a chart from a remote Helm repo, or pulls
+// the chart if the repo is an OCI registry
 func LoadChart(client *BasicAuthClient, repoURL, chartName, chartVersion string) (*chart.Chart, error) {
+	if IsOCIRepoURL(repoURL) {
+		return LoadOCIChart(client, repoURL, chartName, chartVersion)
+	}
+
 	repoIndex, err := LoadRepoIndex(client, repoURL)
 
 	if err != nil {
@@ -0,0 +1,185 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"helm.sh/helm/v3/pkg/chart"
+	chartloader "helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// IsOCIRepoURL returns true if the repo URL references an OCI registry, i.e. it is
+// prefixed with oci://
+func IsOCIRepoURL(repoURL string) bool {
+	return registry.IsOCI(strings.TrimSpace(repoURL))
+}
+
+// LoadOCIChart pulls a chart from an OCI registry. The chart is expected to be stored
+// at <repoURL>/<chartName>, tagged with the chart version. If chartVersion is an
+// empty string, the latest semver tag is used.
+func LoadOCIChart(client *BasicAuthClient, repoURL, chartName, chartVersion string) (*chart.Chart, error) {
+	regClient, cleanup, err := newOCIRegistryClient(client, repoURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	ref := getOCIChartRef(repoURL, chartName)
+
+	if chartVersion == "" {
+		tags, err := regClient.Tags(ref)
+
+		if err != nil {
+			return nil, err
+		} else if len(tags) == 0 {
+			return nil, fmt.Errorf("%s: no valid versions found", chartName)
+		}
+
+		chartVersion = tags[0]
+	}
+
+	// OCI tags do not support the plus sign, so Helm stores it as an underscore
+	res, err := regClient.Pull(fmt.Sprintf("%s:%s", ref, strings.ReplaceAll(chartVersion, "+", "_")))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return chartloader.LoadArchive(bytes.NewReader(res.Chart.Data))
+}
+
+// LoadOCIRepoCharts lists the given charts in an OCI registry as Porter charts. Since
+// OCI registries do not have an index file, the chart names must be found separately,
+// for example by listing the repositories of a registry integration.
+func LoadOCIRepoCharts(client *BasicAuthClient, repoURL string, chartNames []string) (types.ListTemplatesResponse, error) {
+	regClient, cleanup, err := newOCIRegistryClient(client, repoURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	porterCharts := make(types.ListTemplatesResponse, 0)
+
+	for _, chartName := range chartNames {
+		ref := getOCIChartRef(repoURL, chartName)
+
+		// repositories without any semver tags are not charts, so they are skipped
+		versions, err := regClient.Tags(ref)
+
+		if err != nil || len(versions) == 0 {
+			continue
+		}
+
+		porterChart := types.PorterTemplateSimple{
+			Name:     chartName,
+			Versions: versions,
+			RepoURL:  repoURL,
+		}
+
+		// the description and icon are only stored in the chart metadata, so the latest
+		// chart is pulled to populate them
+		res, err := regClient.Pull(fmt.Sprintf("%s:%s", ref, strings.ReplaceAll(versions[0], "+", "_")))
+
+		if err != nil {
+			continue
+		}
+
+		if res.Chart != nil && res.Chart.Meta != nil {
+			porterChart.Description = res.Chart.Meta.Description
+			porterChart.Icon = res.Chart.Meta.Icon
+		}
+
+		porterCharts = append(porterCharts, porterChart)
+	}
+
+	return porterCharts, nil
+}
+
+// GetOCIRepoHost returns the registry host for an OCI repo URL, such as
+// 123456789012.dkr.ecr.us-east-1.amazonaws.com for
+// oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts
+func GetOCIRepoHost(repoURL string) (string, error) {
+	parsedURL, err := url.Parse(strings.TrimSpace(repoURL))
+
+	if err != nil {
+		return "", err
+	}
+
+	if parsedURL.Host == "" {
+		return "", fmt.Errorf("invalid OCI repo url %s", repoURL)
+	}
+
+	return parsedURL.Host, nil
+}
+
+// GetOCIRepoPath returns the OCI repo URL without the oci:// prefix or a trailing
+// slash, which is the prefix of the repository of every chart stored in it
+func GetOCIRepoPath(repoURL string) string {
+	return strings.TrimSuffix(
+		strings.TrimPrefix(strings.TrimSpace(repoURL), fmt.Sprintf("%s://", registry.OCIScheme)),
+		"/",
+	)
+}
+
+func getOCIChartRef(repoURL, chartName string) string {
+	return fmt.Sprintf("%s/%s", GetOCIRepoPath(repoURL), chartName)
+}
+
+// newOCIRegistryClient creates a Helm registry client which is logged in to the
+// registry host of the repo URL if credentials are passed. The client stores its
+// credentials in a temporary file, which is removed by the returned cleanup function.
+func newOCIRegistryClient(client *BasicAuthClient, repoURL string) (*registry.Client, func(), error) {
+	host, err := GetOCIRepoHost(repoURL)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	credsFile, err := ioutil.TempFile("", "porter-oci-creds-*.json")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		os.Remove(credsFile.Name())
+	}
+
+	// each client gets its own credentials file, so that credentials for different
+	// projects are never shared between requests
+	if _, err := credsFile.Write([]byte("{}")); err != nil {
+		credsFile.Close()
+		cleanup()
+		return nil, nil, err
+	}
+
+	credsFile.Close()
+
+	regClient, err := registry.NewClient(registry.ClientOptCredentialsFile(credsFile.Name()))
+
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	if client != nil && client.Username != "" {
+		err = regClient.Login(host, registry.LoginOptBasicAuth(client.Username, client.Password))
+
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("could not log in to OCI registry %s: %w", host, err)
+		}
+	}
+
+	return regClient, cleanup, nil
+}
@@ -2,11 +2,15 @@ package repo
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm/loader"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"golang.org/x/oauth2"
 	"helm.sh/helm/v3/pkg/chart"
+	helmrepo "k8s.io/helm/pkg/repo"
 
 	"github.com/porter-dev/porter/internal/repository"
 )
@@ -15,71 +19,135 @@ import (
 type HelmRepo models.HelmRepo
 
 // ListCharts lists Porter charts for a given helm repo
-func (hr *HelmRepo) ListCharts(repo repository.Repository) (types.ListTemplatesResponse, error) {
-	if hr.BasicAuthIntegrationID != 0 {
-		return hr.listChartsBasic(repo)
+func (hr *HelmRepo) ListCharts(
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) (types.ListTemplatesResponse, error) {
+	client, err := hr.GetBasicAuthClient(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if loader.IsOCIRepoURL(hr.RepoURL) {
+		return hr.listChartsOCI(repo, doAuth, client)
+	}
+
+	var repoIndex *helmrepo.IndexFile
+
+	if client != nil {
+		repoIndex, err = loader.LoadRepoIndex(client, hr.RepoURL)
+	} else {
+		repoIndex, err = loader.LoadRepoIndexPublic(hr.RepoURL)
 	}
 
-	return nil, fmt.Errorf("error listing charts")
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.RepoIndexToPorterChartList(repoIndex, hr.RepoURL), nil
 }
 
 // GetChart retrieves a Porter chart for a given helm repo
 func (hr *HelmRepo) GetChart(
 	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
 	chartName, chartVersion string,
 ) (*chart.Chart, error) {
-	if hr.BasicAuthIntegrationID != 0 {
-		return hr.getChartBasic(repo, chartName, chartVersion)
+	client, err := hr.GetBasicAuthClient(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if client != nil {
+		return loader.LoadChart(client, hr.RepoURL, chartName, chartVersion)
 	}
 
-	return nil, fmt.Errorf("error listing charts")
+	return loader.LoadChartPublic(hr.RepoURL, chartName, chartVersion)
 }
 
-func (hr *HelmRepo) listChartsBasic(
+// GetBasicAuthClient returns the credentials used to authenticate with the helm
+// repo. OCI helm repos are authenticated with the credentials of the linked registry
+// integration, while other repos use their basic auth integration. If the helm repo
+// is public, a nil client is returned.
+func (hr *HelmRepo) GetBasicAuthClient(
 	repo repository.Repository,
-) (types.ListTemplatesResponse, error) {
-	// get the basic auth integration
-	basic, err := repo.BasicIntegration().ReadBasicIntegration(
-		hr.ProjectID,
-		hr.BasicAuthIntegrationID,
-	)
+	doAuth *oauth2.Config, // only required if using DOCR
+) (*loader.BasicAuthClient, error) {
+	if hr.RegistryID != 0 {
+		reg, err := repo.Registry().ReadRegistry(hr.ProjectID, hr.RegistryID)
 
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
+
+		username, password, err := (*registry.Registry)(reg).GetBasicAuthCredentials(repo, doAuth)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &loader.BasicAuthClient{
+			Username: username,
+			Password: password,
+		}, nil
 	}
 
-	client := &loader.BasicAuthClient{
-		Username: string(basic.Username),
-		Password: string(basic.Password),
+	if hr.BasicAuthIntegrationID != 0 {
+		basic, err := repo.BasicIntegration().ReadBasicIntegration(
+			hr.ProjectID,
+			hr.BasicAuthIntegrationID,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &loader.BasicAuthClient{
+			Username: string(basic.Username),
+			Password: string(basic.Password),
+		}, nil
 	}
 
-	repoIndex, err := loader.LoadRepoIndex(client, hr.RepoURL)
+	return nil, nil
+}
+
+// listChartsOCI lists the charts in an OCI helm repo. Since OCI registries do not
+// serve an index file, the charts are found by listing the repositories of the linked
+// registry which are nested under the helm repo URL.
+func (hr *HelmRepo) listChartsOCI(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	client *loader.BasicAuthClient,
+) (types.ListTemplatesResponse, error) {
+	if hr.RegistryID == 0 {
+		return nil, fmt.Errorf("charts can only be listed for OCI helm repos linked to a registry")
+	}
+
+	reg, err := repo.Registry().ReadRegistry(hr.ProjectID, hr.RegistryID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return loader.RepoIndexToPorterChartList(repoIndex, hr.RepoURL), nil
-}
-
-func (hr *HelmRepo) getChartBasic(
-	repo repository.Repository,
-	chartName, chartVersion string,
-) (*chart.Chart, error) {
-	// get the basic auth integration
-	basic, err := repo.BasicIntegration().ReadBasicIntegration(
-		hr.ProjectID,
-		hr.BasicAuthIntegrationID,
-	)
+	repos, err := (*registry.Registry)(reg).ListRepositories(repo, doAuth)
 
 	if err != nil {
 		return nil, err
 	}
 
-	client := &loader.BasicAuthClient{
-		Username: string(basic.Username),
-		Password: string(basic.Password),
+	prefix := loader.GetOCIRepoPath(hr.RepoURL) + "/"
+	chartNames := make([]string, 0)
+
+	for _, regRepo := range repos {
+		uri := strings.TrimPrefix(strings.TrimPrefix(regRepo.URI, "https://"), "http://")
+
+		// only direct children of the helm repo path are charts in this helm repo
+		if chartName := strings.TrimPrefix(uri, prefix); chartName != uri && chartName != "" && !strings.Contains(chartName, "/") {
+			chartNames = append(chartNames, chartName)
+		}
 	}
 
-	return loader.LoadChart(client, hr.RepoURL, chartName, chartVersion)
+	return loader.LoadOCIRepoCharts(client, hr.RepoURL, chartNames)
 }
@@ -25,6 +25,12 @@ func (c *ChartURLCache) Update() {
 	newCharts := make(map[string]string)
 
 	for _, chartRepo := range c.urls {
+		// OCI repos do not serve an index file, so their charts cannot be listed
+		// without registry credentials and are loaded through a project helm repo
+		if loader.IsOCIRepoURL(chartRepo) {
+			continue
+		}
+
 		indexFile, err := loader.LoadRepoIndexPublic(chartRepo)
 
 		if err != nil {
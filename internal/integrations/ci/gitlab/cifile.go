@@ -0,0 +1,160 @@
+package gitlab
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// addCIJob adds a job with its own stage to the contents of a .gitlab-ci.yml file,
+// replacing the job if it already exists. The order of the existing keys is preserved.
+func addCIJob(ciFile []byte, jobName string, job yaml.MapSlice) ([]byte, error) {
+	// to preserve the order of the YAML, we use a MapSlice
+	ciFileContentsMap := yaml.MapSlice{}
+
+	if err := yaml.Unmarshal(ciFile, &ciFileContentsMap); err != nil {
+		return nil, fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
+	}
+
+	stagesInt, stagesIdx, err := getCIStages(ciFileContentsMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// two cases can happen here:
+	// 1: "stages" exists
+	// 2: "stages" does not exist
+
+	if stagesIdx >= 0 { // 1: "stages" exists
+		stageExists := false
+
+		for _, stage := range stagesInt {
+			stageStr, ok := stage.(string)
+			if !ok {
+				return nil, fmt.Errorf("error converting from interface to string")
+			}
+
+			if stageStr == jobName {
+				stageExists = true
+				break
+			}
+		}
+
+		if !stageExists {
+			stagesInt = append(stagesInt, jobName)
+
+			ciFileContentsMap[stagesIdx] = yaml.MapItem{
+				Key:   "stages",
+				Value: stagesInt,
+			}
+		}
+	} else { // 2: "stages" does not exist
+		stagesInt = append(stagesInt, jobName)
+
+		ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
+			Key:   "stages",
+			Value: stagesInt,
+		})
+	}
+
+	jobExists := false
+
+	for idx, elem := range ciFileContentsMap {
+		if elem.Key == jobName {
+			ciFileContentsMap[idx].Value = job
+			jobExists = true
+			break
+		}
+	}
+
+	if !jobExists {
+		ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
+			Key:   jobName,
+			Value: job,
+		})
+	}
+
+	contentsYAML, err := yaml.Marshal(ciFileContentsMap)
+
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling contents of .gitlab-ci.yml while updating to add porter job")
+	}
+
+	return contentsYAML, nil
+}
+
+// removeCIJob removes a job and its stage from the contents of a .gitlab-ci.yml file
+func removeCIJob(ciFile []byte, jobName string) ([]byte, error) {
+	ciFileContentsMap := yaml.MapSlice{}
+
+	if err := yaml.Unmarshal(ciFile, &ciFileContentsMap); err != nil {
+		return nil, fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
+	}
+
+	stagesInt, stagesIdx, err := getCIStages(ciFileContentsMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stagesIdx >= 0 { // "stages" exists
+		var newStages []string
+
+		for _, stage := range stagesInt {
+			stageStr, ok := stage.(string)
+			if !ok {
+				return nil, fmt.Errorf("error converting from interface to string")
+			}
+
+			if stageStr != jobName {
+				newStages = append(newStages, stageStr)
+			}
+		}
+
+		ciFileContentsMap[stagesIdx] = yaml.MapItem{
+			Key:   "stages",
+			Value: newStages,
+		}
+	}
+
+	newCIFileContentsMap := yaml.MapSlice{}
+
+	for _, elem := range ciFileContentsMap {
+		if elem.Key != jobName {
+			newCIFileContentsMap = append(newCIFileContentsMap, elem)
+		}
+	}
+
+	contentsYAML, err := yaml.Marshal(newCIFileContentsMap)
+
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling contents of .gitlab-ci.yml while updating to remove porter job")
+	}
+
+	return contentsYAML, nil
+}
+
+// getCIStages returns the stages of a .gitlab-ci.yml file and the index of the "stages"
+// key, or -1 if the file does not declare stages
+func getCIStages(ciFileContentsMap yaml.MapSlice) ([]interface{}, int, error) {
+	for idx, elem := range ciFileContentsMap {
+		key, ok := elem.Key.(string)
+
+		if !ok {
+			return nil, -1, fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
+		}
+
+		if key == "stages" {
+			stages, ok := elem.Value.([]interface{})
+
+			if !ok {
+				return nil, -1, fmt.Errorf("error converting stages to interface slice")
+			}
+
+			return stages, idx, nil
+		}
+	}
+
+	return nil, -1, nil
+}
@@ -0,0 +1,120 @@
+package gitlab
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+const existingCIFile = `stages:
+- test
+test:
+  stage: test
+  script:
+  - make test
+`
+
+func TestAddCIJob(t *testing.T) {
+	job := yaml.MapSlice{{Key: "stage", Value: "porter-preview-dev"}}
+
+	contents, err := addCIJob([]byte(existingCIFile), "porter-preview-dev", job)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// adding the job again should replace it rather than duplicate it
+	contents, err = addCIJob(contents, "porter-preview-dev", job)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `stages:
+- test
+- porter-preview-dev
+test:
+  stage: test
+  script:
+  - make test
+porter-preview-dev:
+  stage: porter-preview-dev
+`
+
+	if string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, string(contents))
+	}
+}
+
+func TestAddCIJobEmptyFile(t *testing.T) {
+	job := yaml.MapSlice{{Key: "stage", Value: "porter-preview-dev"}}
+
+	contents, err := addCIJob(nil, "porter-preview-dev", job)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `stages:
+- porter-preview-dev
+porter-preview-dev:
+  stage: porter-preview-dev
+`
+
+	if string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, string(contents))
+	}
+}
+
+func TestRemoveCIJob(t *testing.T) {
+	job := yaml.MapSlice{{Key: "stage", Value: "porter-preview-dev"}}
+
+	contents, err := addCIJob([]byte(existingCIFile), "porter-preview-dev", job)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contents, err = removeCIJob(contents, "porter-preview-dev")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(contents) != existingCIFile {
+		t.Errorf("expected:\n%s\ngot:\n%s", existingCIFile, string(contents))
+	}
+}
+
+func TestPreviewCIJob(t *testing.T) {
+	opts := &PreviewEnvOpts{
+		InstanceURL:     "https://gitlab.example.com",
+		ServerURL:       "https://dashboard.example.com",
+		GitRepoName:     "my_app",
+		EnvironmentName: "preview",
+		ProjectID:       1,
+		ClusterID:       2,
+		EnvironmentID:   3,
+	}
+
+	contents, err := yaml.Marshal(getPreviewCIJob(opts, getPreviewJobName(opts.EnvironmentName)))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		`$CI_PIPELINE_SOURCE == "merge_request_event"`,
+		"name: preview/mr-$CI_MERGE_REQUEST_IID",
+		"PORTER_TOKEN: $PORTER_TOKEN_1",
+		"PORTER_NAMESPACE: pr-$CI_MERGE_REQUEST_IID-my-app",
+		`PORTER_GITLAB_ENVIRONMENT_ID: "3"`,
+		"porter apply -f porter.yaml",
+		"stage: porter-preview-preview",
+	} {
+		if !strings.Contains(string(contents), expected) {
+			t.Errorf("expected preview job to contain %q, got:\n%s", expected, string(contents))
+		}
+	}
+}
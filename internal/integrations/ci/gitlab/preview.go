@@ -0,0 +1,454 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
+	"gopkg.in/yaml.v2"
+)
+
+// PreviewCommitStatusName is the name of the commit status that reports the state of a
+// merge request's preview environment
+const PreviewCommitStatusName = "porter-preview"
+
+// PreviewEnvOpts are the options for setting up preview environments in a GitLab project
+type PreviewEnvOpts struct {
+	Client      *gitlab.Client
+	InstanceURL string
+
+	ServerURL     string
+	PorterToken   string
+	WebhookURL    string
+	WebhookSecret string
+
+	GitRepoOwner, GitRepoName string
+	EnvironmentName           string
+
+	// Mode is "auto" to deploy every merge request, or "manual" to only deploy merge
+	// requests where the preview job is started by hand
+	Mode string
+
+	ProjectID, ClusterID, EnvironmentID uint
+}
+
+// GetClientForEnvironment returns a client for the GitLab instance of a preview environment
+// backed by a GitLab repository
+func GetClientForEnvironment(conf *config.Config, env *models.Environment) (*gitlab.Client, error) {
+	client, _, err := GetClient(conf, conf.Repo, env.UserID, env.ProjectID, env.GitlabIntegrationID)
+
+	return client, err
+}
+
+// SetupPreviewEnv stores the Porter token as a CI/CD variable, creates a merge request
+// webhook and adds a preview job to the .gitlab-ci.yml file of the default branch
+func SetupPreviewEnv(opts *PreviewEnvOpts) error {
+	pID := fmt.Sprintf("%s/%s", opts.GitRepoOwner, opts.GitRepoName)
+
+	project, _, err := opts.Client.Projects.GetProject(pID, &gitlab.GetProjectOptions{})
+
+	if err != nil {
+		return fmt.Errorf("error fetching project: %w", err)
+	}
+
+	err = upsertProjectVariable(opts.Client, pID, getPreviewPorterTokenName(opts.ProjectID), opts.PorterToken)
+
+	if err != nil {
+		return err
+	}
+
+	_, _, err = opts.Client.Projects.AddProjectHook(pID, &gitlab.AddProjectHookOptions{
+		URL:                   gitlab.String(opts.WebhookURL),
+		Token:                 gitlab.String(opts.WebhookSecret),
+		MergeRequestsEvents:   gitlab.Bool(true),
+		PushEvents:            gitlab.Bool(false),
+		EnableSSLVerification: gitlab.Bool(true),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error creating merge request webhook: %w", err)
+	}
+
+	jobName := getPreviewJobName(opts.EnvironmentName)
+
+	ciFile, resp, err := opts.Client.RepositoryFiles.GetRawFile(pID, ".gitlab-ci.yml", &gitlab.GetRawFileOptions{
+		Ref: gitlab.String(project.DefaultBranch),
+	})
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		contentsYAML, err := addCIJob(nil, jobName, getPreviewCIJob(opts, jobName))
+
+		if err != nil {
+			return err
+		}
+
+		_, _, err = opts.Client.RepositoryFiles.CreateFile(pID, ".gitlab-ci.yml", &gitlab.CreateFileOptions{
+			Branch:        gitlab.String(project.DefaultBranch),
+			AuthorName:    gitlab.String("Porter Bot"),
+			AuthorEmail:   gitlab.String("contact@getporter.dev"),
+			Content:       gitlab.String(string(contentsYAML)),
+			CommitMessage: gitlab.String("Create .gitlab-ci.yml file"),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error creating .gitlab-ci.yml file: %w", err)
+		}
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
+	}
+
+	contentsYAML, err := addCIJob(ciFile, jobName, getPreviewCIJob(opts, jobName))
+
+	if err != nil {
+		return err
+	}
+
+	_, _, err = opts.Client.RepositoryFiles.UpdateFile(pID, ".gitlab-ci.yml", &gitlab.UpdateFileOptions{
+		Branch:        gitlab.String(project.DefaultBranch),
+		AuthorName:    gitlab.String("Porter Bot"),
+		AuthorEmail:   gitlab.String("contact@getporter.dev"),
+		Content:       gitlab.String(string(contentsYAML)),
+		CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating .gitlab-ci.yml file to add preview job: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePreviewEnv removes the merge request webhook and the preview job created by
+// SetupPreviewEnv
+func DeletePreviewEnv(opts *PreviewEnvOpts) error {
+	pID := fmt.Sprintf("%s/%s", opts.GitRepoOwner, opts.GitRepoName)
+
+	project, _, err := opts.Client.Projects.GetProject(pID, &gitlab.GetProjectOptions{})
+
+	if err != nil {
+		return fmt.Errorf("error fetching project: %w", err)
+	}
+
+	hooks, _, err := opts.Client.Projects.ListProjectHooks(pID, &gitlab.ListProjectHooksOptions{})
+
+	if err != nil {
+		return fmt.Errorf("error listing project webhooks: %w", err)
+	}
+
+	for _, hook := range hooks {
+		if hook.URL == opts.WebhookURL {
+			_, err = opts.Client.Projects.DeleteProjectHook(pID, hook.ID)
+
+			if err != nil {
+				return fmt.Errorf("error deleting merge request webhook: %w", err)
+			}
+		}
+	}
+
+	ciFile, resp, err := opts.Client.RepositoryFiles.GetRawFile(pID, ".gitlab-ci.yml", &gitlab.GetRawFileOptions{
+		Ref: gitlab.String(project.DefaultBranch),
+	})
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
+	}
+
+	contentsYAML, err := removeCIJob(ciFile, getPreviewJobName(opts.EnvironmentName))
+
+	if err != nil {
+		return err
+	}
+
+	_, _, err = opts.Client.RepositoryFiles.UpdateFile(pID, ".gitlab-ci.yml", &gitlab.UpdateFileOptions{
+		Branch:        gitlab.String(project.DefaultBranch),
+		AuthorName:    gitlab.String("Porter Bot"),
+		AuthorEmail:   gitlab.String("contact@getporter.dev"),
+		Content:       gitlab.String(string(contentsYAML)),
+		CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating .gitlab-ci.yml file to remove preview job: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreviewNamespace returns the namespace of the preview environment for a merge
+// request. The merge request IID may be a CI/CD variable reference.
+func GetPreviewNamespace(mergeRequestIID, repoName string) string {
+	return fmt.Sprintf("pr-%s-%s", mergeRequestIID, strings.ToLower(strings.ReplaceAll(repoName, "_", "-")))
+}
+
+// GetPreviewEnvironmentName returns the name of the GitLab environment that the preview
+// job of a merge request deploys to
+func GetPreviewEnvironmentName(envName, mergeRequestIID string) string {
+	return fmt.Sprintf("%s/mr-%s", envName, mergeRequestIID)
+}
+
+// SetPreviewCommitStatus reports the state of a preview environment on a commit, which
+// GitLab shows on the merge request
+func SetPreviewCommitStatus(
+	client *gitlab.Client,
+	owner, repo, sha string,
+	state gitlab.BuildStateValue,
+	targetURL, description string,
+) error {
+	opts := &gitlab.SetCommitStatusOptions{
+		State:       state,
+		Name:        gitlab.String(PreviewCommitStatusName),
+		Description: gitlab.String(description),
+	}
+
+	if targetURL != "" {
+		opts.TargetURL = gitlab.String(targetURL)
+	}
+
+	_, _, err := client.Commits.SetCommitStatus(fmt.Sprintf("%s/%s", owner, repo), sha, opts)
+
+	return err
+}
+
+// StopPreviewEnvironment stops the GitLab environment of a merge request's preview
+func StopPreviewEnvironment(client *gitlab.Client, owner, repo, envName string, mergeRequestIID uint) error {
+	pID := fmt.Sprintf("%s/%s", owner, repo)
+
+	envs, _, err := client.Environments.ListEnvironments(pID, &gitlab.ListEnvironmentsOptions{
+		Name: gitlab.String(GetPreviewEnvironmentName(envName, fmt.Sprintf("%d", mergeRequestIID))),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, env := range envs {
+		if env.State == "stopped" {
+			continue
+		}
+
+		if _, err := client.Environments.StopEnvironment(pID, env.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CommentOnMergeRequest leaves a comment on a merge request
+func CommentOnMergeRequest(client *gitlab.Client, owner, repo string, mergeRequestIID uint, body string) error {
+	_, _, err := client.Notes.CreateMergeRequestNote(
+		fmt.Sprintf("%s/%s", owner, repo),
+		int(mergeRequestIID),
+		&gitlab.CreateMergeRequestNoteOptions{
+			Body: gitlab.String(body),
+		},
+	)
+
+	return err
+}
+
+// RunPreviewPipeline starts a merge request pipeline, which rebuilds the preview environment
+func RunPreviewPipeline(client *gitlab.Client, owner, repo string, mergeRequestIID uint) error {
+	_, _, err := client.MergeRequests.CreateMergeRequestPipeline(
+		fmt.Sprintf("%s/%s", owner, repo),
+		int(mergeRequestIID),
+	)
+
+	return err
+}
+
+// ListOpenMergeRequests lists all open merge requests in a repository
+func ListOpenMergeRequests(client *gitlab.Client, owner, repo string) ([]*gitlab.MergeRequest, error) {
+	var res []*gitlab.MergeRequest
+
+	opts := &gitlab.ListProjectMergeRequestsOptions{
+		State: gitlab.String("opened"),
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	for {
+		mrs, resp, err := client.MergeRequests.ListProjectMergeRequests(fmt.Sprintf("%s/%s", owner, repo), opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, mrs...)
+
+		if resp.NextPage == 0 {
+			return res, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
+func getPreviewCIJob(opts *PreviewEnvOpts, jobName string) yaml.MapSlice {
+	res := yaml.MapSlice{}
+	instanceURL, _ := url.Parse(opts.InstanceURL)
+
+	variables := yaml.MapSlice{
+		{Key: "GIT_STRATEGY", Value: "clone"},
+		{Key: "PORTER_HOST", Value: opts.ServerURL},
+		{Key: "PORTER_PROJECT", Value: fmt.Sprintf("%d", opts.ProjectID)},
+		{Key: "PORTER_CLUSTER", Value: fmt.Sprintf("%d", opts.ClusterID)},
+		{Key: "PORTER_TOKEN", Value: fmt.Sprintf("$%s", getPreviewPorterTokenName(opts.ProjectID))},
+		{Key: "PORTER_NAMESPACE", Value: GetPreviewNamespace("$CI_MERGE_REQUEST_IID", opts.GitRepoName)},
+		{Key: "PORTER_TAG", Value: "$CI_COMMIT_SHORT_SHA"},
+		{Key: "PORTER_GITLAB_ENVIRONMENT_ID", Value: fmt.Sprintf("%d", opts.EnvironmentID)},
+	}
+
+	rule := yaml.MapSlice{
+		{Key: "if", Value: "$CI_PIPELINE_SOURCE == \"merge_request_event\""},
+	}
+
+	if opts.Mode == "manual" {
+		rule = append(rule,
+			yaml.MapItem{Key: "when", Value: "manual"},
+			yaml.MapItem{Key: "allow_failure", Value: true},
+		)
+	}
+
+	res = append(res,
+		yaml.MapItem{
+			Key:   "rules",
+			Value: []yaml.MapSlice{rule},
+		},
+		yaml.MapItem{
+			Key: "environment",
+			Value: map[string]string{
+				"name": GetPreviewEnvironmentName(opts.EnvironmentName, "$CI_MERGE_REQUEST_IID"),
+			},
+		},
+	)
+
+	if instanceURL != nil && (instanceURL.Hostname() == "gitlab.com" || instanceURL.Hostname() == "www.gitlab.com") {
+		envFlags := make([]string, 0, len(variables))
+
+		// pass the Porter variables through to the CLI container
+		for _, variable := range variables[1:] {
+			envFlags = append(envFlags, fmt.Sprintf("-e %s", variable.Key))
+		}
+
+		res = append(res,
+			yaml.MapItem{
+				Key:   "image",
+				Value: "docker:latest",
+			},
+			yaml.MapItem{
+				Key: "services",
+				Value: []string{
+					"docker:dind",
+				},
+			},
+			yaml.MapItem{
+				Key: "script",
+				Value: []string{
+					fmt.Sprintf(
+						"docker run --rm --workdir=\"/app\" "+
+							"-v /var/run/docker.sock:/var/run/docker.sock "+
+							"-v $(pwd):/app %s "+
+							"public.ecr.aws/o1j4x7p4/porter-cli:latest apply -f porter.yaml",
+						strings.Join(envFlags, " "),
+					),
+				},
+			},
+			yaml.MapItem{
+				Key: "tags",
+				Value: []string{
+					"docker",
+				},
+			},
+		)
+	} else {
+		res = append(res,
+			yaml.MapItem{
+				Key: "image",
+				Value: map[string]interface{}{
+					"name": "public.ecr.aws/o1j4x7p4/porter-cli:latest",
+					"entrypoint": []string{
+						"",
+					},
+				},
+			},
+			yaml.MapItem{
+				Key: "script",
+				Value: []string{
+					"porter apply -f porter.yaml",
+				},
+			},
+			yaml.MapItem{
+				Key: "tags",
+				Value: []string{
+					"porter-runner",
+				},
+			},
+		)
+	}
+
+	res = append(res,
+		yaml.MapItem{
+			Key:   "stage",
+			Value: jobName,
+		},
+		yaml.MapItem{
+			Key:   "timeout",
+			Value: "30 minutes",
+		},
+		yaml.MapItem{
+			Key:   "variables",
+			Value: variables,
+		},
+	)
+
+	return res
+}
+
+func upsertProjectVariable(client *gitlab.Client, pID, key, value string) error {
+	_, resp, err := client.ProjectVariables.GetVariable(pID, key, &gitlab.GetProjectVariableOptions{})
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		_, _, err = client.ProjectVariables.CreateVariable(pID, &gitlab.CreateProjectVariableOptions{
+			Key:    gitlab.String(key),
+			Value:  gitlab.String(value),
+			Masked: gitlab.Bool(true),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error creating porter token variable: %w", err)
+		}
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting porter token variable: %w", err)
+	}
+
+	_, _, err = client.ProjectVariables.UpdateVariable(pID, key, &gitlab.UpdateProjectVariableOptions{
+		Value:  gitlab.String(value),
+		Masked: gitlab.Bool(true),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating porter token variable: %w", err)
+	}
+
+	return nil
+}
+
+func getPreviewPorterTokenName(projectID uint) string {
+	return fmt.Sprintf("PORTER_TOKEN_%d", projectID)
+}
+
+func getPreviewJobName(envName string) string {
+	return fmt.Sprintf("porter-preview-%s", strings.ToLower(strings.ReplaceAll(envName, "_", "-")))
+}
@@ -0,0 +1,241 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+const (
+	colorSuccess = 0x2EB67D
+	colorWarning = 0xECB22E
+	colorFailure = 0xE01E5A
+)
+
+// maxInfoLength is the maximum length of error messages included in an embed. Discord
+// limits embed descriptions to 4096 characters.
+const maxInfoLength = 1000
+
+// WebhookPayload is the body accepted by Discord webhooks
+type WebhookPayload struct {
+	Username string   `json:"username"`
+	Embeds   []*Embed `json:"embeds"`
+}
+
+type Embed struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description,omitempty"`
+	URL         string        `json:"url,omitempty"`
+	Color       int           `json:"color"`
+	Fields      []*EmbedField `json:"fields,omitempty"`
+	Timestamp   string        `json:"timestamp,omitempty"`
+}
+
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type Notifier struct {
+	discordInts []*integrations.DiscordIntegration
+	Config      *types.NotificationConfig
+	client      *http.Client
+	logger      *logger.Logger
+
+	// deliveries tracks the notifications that are being sent in the background
+	deliveries sync.WaitGroup
+}
+
+// NewNotifier returns a notifier which posts an embed to the webhook of each Discord
+// channel. Error messages longer than maxInfoLength are truncated to fit in the embed.
+// Embeds are posted in the background, and failed deliveries are logged.
+func NewNotifier(
+	conf *types.NotificationConfig,
+	l *logger.Logger,
+	discordInts ...*integrations.DiscordIntegration,
+) *Notifier {
+	return &Notifier{
+		discordInts: discordInts,
+		Config:      conf,
+		client: &http.Client{
+			Timeout: time.Second * 5,
+		},
+		logger: l,
+	}
+}
+
+// Wait blocks until the notifications being sent in the background are delivered or
+// have failed
+func (n *Notifier) Wait() {
+	n.deliveries.Wait()
+}
+
+func (n *Notifier) Notify(opts *slack.NotifyOpts) error {
+	if !slack.ShouldNotify(n.Config, opts.Status) {
+		return nil
+	}
+
+	return n.send(getDeploymentEmbed(opts))
+}
+
+func (n *Notifier) NotifyNew(incident *porter_agent.Incident, url string) error {
+	if n.Config != nil && !n.Config.Enabled {
+		return nil
+	}
+
+	createdAt := time.Unix(incident.CreatedAt, 0).UTC()
+
+	return n.send(&Embed{
+		Title:       fmt.Sprintf("Your application %s crashed on Porter", incident.ReleaseName),
+		Description: getInfoText(incident.LatestMessage),
+		URL:         url,
+		Color:       colorFailure,
+		Fields: []*EmbedField{
+			{Name: "Namespace", Value: getIncidentNamespace(incident), Inline: true},
+			{Name: "Name", Value: incident.ReleaseName, Inline: true},
+		},
+		Timestamp: createdAt.Format(time.RFC3339),
+	})
+}
+
+func (n *Notifier) NotifyResolved(incident *porter_agent.Incident, url string) error {
+	if n.Config != nil && !n.Config.Enabled {
+		return nil
+	}
+
+	createdAt := time.Unix(incident.CreatedAt, 0).UTC()
+	resolvedAt := time.Unix(incident.UpdatedAt, 0).UTC()
+
+	return n.send(&Embed{
+		Title: fmt.Sprintf("The incident for application %s has been resolved", incident.ReleaseName),
+		URL:   url,
+		Color: colorSuccess,
+		Fields: []*EmbedField{
+			{Name: "Namespace", Value: getIncidentNamespace(incident), Inline: true},
+			{Name: "Name", Value: incident.ReleaseName, Inline: true},
+			{Name: "Created at", Value: createdAt.Format("2006-01-02 15:04:05 UTC")},
+		},
+		Timestamp: resolvedAt.Format(time.RFC3339),
+	})
+}
+
+// send posts the embed to every Discord channel in the background, so that a slow webhook
+// does not block the request that sent the notification. It only returns an error if the
+// embed cannot be encoded.
+func (n *Notifier) send(embed *Embed) error {
+	payload, err := json.Marshal(&WebhookPayload{
+		Username: "Porter",
+		Embeds:   []*Embed{embed},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, discordInt := range n.discordInts {
+		n.deliveries.Add(1)
+
+		go func(discordInt *integrations.DiscordIntegration) {
+			defer n.deliveries.Done()
+
+			if err := n.post(string(discordInt.Webhook), payload); err != nil {
+				n.logger.Error().Err(err).Msgf("could not send discord notification to %s", discordInt.Name)
+			}
+		}(discordInt)
+	}
+
+	return nil
+}
+
+func (n *Notifier) post(url string, payload []byte) error {
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(payload))
+
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("request failed with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func getDeploymentEmbed(opts *slack.NotifyOpts) *Embed {
+	embed := &Embed{
+		URL: opts.URL,
+		Fields: []*EmbedField{
+			{Name: "Name", Value: opts.Name, Inline: true},
+			{Name: "Namespace", Value: opts.Namespace, Inline: true},
+		},
+	}
+
+	switch opts.Status {
+	case slack.StatusHelmDeployed:
+		embed.Color = colorSuccess
+		embed.Title = fmt.Sprintf("Your application %s was successfully updated on Porter", opts.Name)
+	case slack.StatusHelmFailed:
+		embed.Color = colorFailure
+		embed.Title = fmt.Sprintf("Your application %s failed to deploy on Porter", opts.Name)
+	case slack.StatusHelmRolledBack:
+		embed.Color = colorWarning
+		embed.Title = fmt.Sprintf(
+			"Your application %s failed its health checks on Porter and was rolled back to version %d",
+			opts.Name, opts.Version,
+		)
+	case slack.StatusPodCrashed:
+		embed.Color = colorFailure
+		embed.Title = fmt.Sprintf("Your application %s crashed on Porter", opts.Name)
+	}
+
+	if opts.ClusterName != "" {
+		embed.Fields = append(embed.Fields, &EmbedField{Name: "Cluster", Value: opts.ClusterName, Inline: true})
+	}
+
+	if opts.Status != slack.StatusPodCrashed {
+		embed.Fields = append(embed.Fields, &EmbedField{Name: "Version", Value: fmt.Sprintf("%d", opts.Version), Inline: true})
+	}
+
+	if opts.Status != slack.StatusHelmDeployed {
+		embed.Description = getInfoText(opts.Info)
+	}
+
+	if opts.Timestamp != nil {
+		embed.Timestamp = opts.Timestamp.UTC().Format(time.RFC3339)
+	}
+
+	return embed
+}
+
+func getInfoText(info string) string {
+	if info == "" {
+		return ""
+	}
+
+	if len(info) > maxInfoLength {
+		info = info[0:maxInfoLength] + "..."
+	}
+
+	return fmt.Sprintf("```\n%s\n```", info)
+}
+
+func getIncidentNamespace(incident *porter_agent.Incident) string {
+	if segments := strings.Split(incident.ID, ":"); len(segments) > 2 {
+		return segments[2]
+	}
+
+	return ""
+}
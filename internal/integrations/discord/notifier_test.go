@@ -0,0 +1,151 @@
+package discord_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/discord"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// newDiscordWebhook returns a Discord webhook which responds with the given status code,
+// and the payloads that were posted to it
+func newDiscordWebhook(t *testing.T, status int) (*httptest.Server, *[]*discord.WebhookPayload) {
+	payloads := make([]*discord.WebhookPayload, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := &discord.WebhookPayload{}
+
+		if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+			t.Errorf("could not decode payload: %v", err)
+		}
+
+		payloads = append(payloads, payload)
+
+		w.WriteHeader(status)
+	}))
+
+	return server, &payloads
+}
+
+func TestNotifyDeployment(t *testing.T) {
+	server, posted := newDiscordWebhook(t, http.StatusNoContent)
+	defer server.Close()
+
+	notifier := discord.NewNotifier(&types.NotificationConfig{
+		Enabled: true,
+		Success: true,
+		Failure: false,
+	}, logger.NewErrorConsole(false), &integrations.DiscordIntegration{Name: "test", Webhook: []byte(server.URL)})
+
+	err := notifier.Notify(&slack.NotifyOpts{
+		Status:    slack.StatusHelmDeployed,
+		Name:      "web",
+		Namespace: "default",
+		Version:   4,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// failure notifications are disabled in the config
+	if err := notifier.Notify(&slack.NotifyOpts{Status: slack.StatusPodCrashed, Name: "web"}); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	payloads := *posted
+
+	if len(payloads) != 1 || len(payloads[0].Embeds) != 1 {
+		t.Fatalf("expected 1 payload with 1 embed, got %d", len(payloads))
+	}
+
+	embed := payloads[0].Embeds[0]
+
+	if !strings.Contains(embed.Title, "successfully updated") || embed.Description != "" {
+		t.Errorf("unexpected embed %+v", embed)
+	}
+
+	if embed.Fields[len(embed.Fields)-1].Value != "4" {
+		t.Errorf("expected version field, got %+v", embed.Fields[len(embed.Fields)-1])
+	}
+}
+
+func TestNotifyIncident(t *testing.T) {
+	server, posted := newDiscordWebhook(t, http.StatusNoContent)
+	defer server.Close()
+
+	notifier := discord.NewNotifier(nil, logger.NewErrorConsole(false), &integrations.DiscordIntegration{Name: "test", Webhook: []byte(server.URL)})
+
+	err := notifier.NotifyNew(&porter_agent.Incident{
+		ID:            "incident:web:default:1",
+		ReleaseName:   "web",
+		LatestMessage: "OOMKilled",
+	}, "https://dashboard.test/incident")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	payloads := *posted
+
+	if len(payloads) != 1 {
+		t.Fatalf("expected 1 payload, got %d", len(payloads))
+	}
+
+	embed := payloads[0].Embeds[0]
+
+	if !strings.Contains(embed.Description, "OOMKilled") || embed.URL != "https://dashboard.test/incident" {
+		t.Errorf("unexpected embed %+v", embed)
+	}
+}
+
+func TestNotifyError(t *testing.T) {
+	server, posted := newDiscordWebhook(t, http.StatusNotFound)
+	defer server.Close()
+
+	logFile, err := os.CreateTemp("", "discord-notifier-*.log")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(logFile.Name())
+	defer logFile.Close()
+
+	notifier := discord.NewNotifier(nil, logger.New(false, logFile), &integrations.DiscordIntegration{Name: "test", Webhook: []byte(server.URL)})
+
+	// notifications are sent in the background, so failed deliveries are logged rather
+	// than returned
+	if err := notifier.Notify(&slack.NotifyOpts{Status: slack.StatusHelmFailed}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	notifier.Wait()
+
+	if len(*posted) != 1 {
+		t.Fatalf("expected 1 payload, got %d", len(*posted))
+	}
+
+	logs, err := os.ReadFile(logFile.Name())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(logs), "could not send discord notification to test") {
+		t.Errorf("expected failed delivery to be logged, got %s", string(logs))
+	}
+}
@@ -0,0 +1,298 @@
+package envsource
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"google.golang.org/api/option"
+	gsm "google.golang.org/api/secretmanager/v1"
+)
+
+// Resolve reads the current value and version of the external secret referenced by an
+// env group source, using the integration of the source's project
+func Resolve(repo repository.Repository, source *models.EnvGroupSource) (string, string, error) {
+	var value, version string
+	var err error
+
+	switch source.Kind {
+	case types.EnvGroupSourceKindAWSSecretsManager:
+		value, version, err = resolveAWS(repo, source)
+	case types.EnvGroupSourceKindGCPSecretManager:
+		value, version, err = resolveGCP(repo, source)
+	case types.EnvGroupSourceKindVault:
+		return resolveVault(repo, source)
+	default:
+		return "", "", fmt.Errorf("unsupported env group source kind %s", source.Kind)
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if source.Field != "" {
+		value, err = getField(value, source.Field)
+
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return value, version, nil
+}
+
+// ValidateIntegration checks that the integration of a source exists in the project
+func ValidateIntegration(repo repository.Repository, projectID uint, source *types.EnvGroupSourceInput) error {
+	var err error
+
+	switch source.Kind {
+	case types.EnvGroupSourceKindAWSSecretsManager:
+		_, err = repo.AWSIntegration().ReadAWSIntegration(projectID, source.IntegrationID)
+	case types.EnvGroupSourceKindGCPSecretManager:
+		_, err = repo.GCPIntegration().ReadGCPIntegration(projectID, source.IntegrationID)
+	case types.EnvGroupSourceKindVault:
+		if source.Field == "" {
+			return fmt.Errorf("field is required for vault source %s", source.Key)
+		}
+
+		if _, err := getVaultSecretURL("https://vault", source.Path); err != nil {
+			return fmt.Errorf("invalid path for vault source %s: %w", source.Key, err)
+		}
+
+		_, err = repo.VaultIntegration().ReadVaultIntegration(projectID, source.IntegrationID)
+	default:
+		return fmt.Errorf("unsupported env group source kind %s", source.Kind)
+	}
+
+	if err != nil {
+		return fmt.Errorf("integration %d not found for source %s", source.IntegrationID, source.Key)
+	}
+
+	return nil
+}
+
+func resolveAWS(repo repository.Repository, source *models.EnvGroupSource) (string, string, error) {
+	awsInt, err := repo.AWSIntegration().ReadAWSIntegration(source.ProjectID, source.IntegrationID)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	sess, err := awsInt.GetSession()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	output, err := secretsmanager.New(sess).GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(source.Path),
+	})
+
+	if err != nil {
+		return "", "", err
+	}
+
+	value := aws.StringValue(output.SecretString)
+
+	if output.SecretString == nil {
+		value = string(output.SecretBinary)
+	}
+
+	return value, aws.StringValue(output.VersionId), nil
+}
+
+func resolveGCP(repo repository.Repository, source *models.EnvGroupSource) (string, string, error) {
+	gcpInt, err := repo.GCPIntegration().ReadGCPIntegration(source.ProjectID, source.IntegrationID)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	svc, err := gsm.NewService(context.Background(), option.WithCredentialsJSON(gcpInt.GCPKeyData))
+
+	if err != nil {
+		return "", "", err
+	}
+
+	// a path without a version reads the latest version of the secret
+	name := source.Path
+
+	if !strings.Contains(name, "/versions/") {
+		name = fmt.Sprintf("%s/versions/latest", strings.TrimSuffix(name, "/"))
+	}
+
+	resp, err := svc.Projects.Secrets.Versions.Access(name).Do()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if resp.Payload == nil {
+		return "", "", fmt.Errorf("secret version %s has no payload", resp.Name)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	// the response name is the resolved version, like projects/1234/secrets/name/versions/3
+	version := resp.Name[strings.LastIndex(resp.Name, "/")+1:]
+
+	return string(value), version, nil
+}
+
+type vaultSecretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func resolveVault(repo repository.Repository, source *models.EnvGroupSource) (string, string, error) {
+	vaultInt, err := repo.VaultIntegration().ReadVaultIntegration(source.ProjectID, source.IntegrationID)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	secretURL, err := getVaultSecretURL(vaultInt.Address, source.Path)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequest("GET", secretURL, nil)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	req.Header.Set("X-Vault-Token", string(vaultInt.Token))
+
+	client := &http.Client{
+		Timeout: time.Minute,
+		// redirects are not followed, since they would send the token to another address
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	defer res.Body.Close()
+
+	// the response body is not part of the error, since the error is shown to users of
+	// the env group
+	if res.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("request to vault failed with status code %d", res.StatusCode)
+	}
+
+	resp := &vaultSecretResponse{}
+
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil {
+		return "", "", err
+	}
+
+	// KV version 2 secrets are nested under data.data with the version in data.metadata,
+	// while KV version 1 secrets are stored directly under data and are not versioned
+	data := resp.Data
+	version := ""
+
+	if nested, ok := resp.Data["data"].(map[string]interface{}); ok {
+		data = nested
+
+		if metadata, ok := resp.Data["metadata"].(map[string]interface{}); ok {
+			if v, ok := metadata["version"]; ok {
+				version = fmt.Sprintf("%v", v)
+			}
+		}
+	}
+
+	val, ok := data[source.Field]
+
+	if !ok {
+		return "", "", fmt.Errorf("vault secret %s does not contain field %s", source.Path, source.Field)
+	}
+
+	value, err := fieldToString(val)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return value, version, nil
+}
+
+// getVaultSecretURL returns the URL of a KV secret, where path is relative to the API of
+// the Vault server, like secret/data/my-secret
+func getVaultSecretURL(address, secretPath string) (string, error) {
+	addr, err := url.Parse(address)
+
+	if err != nil {
+		return "", fmt.Errorf("invalid vault address: %w", err)
+	}
+
+	secretPath = strings.TrimPrefix(strings.TrimPrefix(secretPath, "/"), "v1/")
+
+	if secretPath == "" || strings.Contains(secretPath, "://") || strings.ContainsAny(secretPath, "?#\\") {
+		return "", fmt.Errorf("path must be a KV path like secret/data/my-secret")
+	}
+
+	for _, segment := range strings.Split(secretPath, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("path must be a KV path like secret/data/my-secret")
+		}
+	}
+
+	secretURL := &url.URL{
+		Scheme: addr.Scheme,
+		Host:   addr.Host,
+		Path:   "/v1/" + secretPath,
+	}
+
+	return secretURL.String(), nil
+}
+
+// getField reads a field from a secret that stores a JSON object
+func getField(value, field string) (string, error) {
+	data := make(map[string]interface{})
+
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", fmt.Errorf("could not read field %s: secret is not a JSON object", field)
+	}
+
+	val, ok := data[field]
+
+	if !ok {
+		return "", fmt.Errorf("secret does not contain field %s", field)
+	}
+
+	return fieldToString(val)
+}
+
+func fieldToString(val interface{}) (string, error) {
+	if str, ok := val.(string); ok {
+		return str, nil
+	}
+
+	bytes, err := json.Marshal(val)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
@@ -0,0 +1,129 @@
+package envsource_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/envsource"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func TestResolveVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			w.Write([]byte(`{"data":{"data":{"password":"hunter2"},"metadata":{"version":3}}}`))
+		case "/v1/kv/db":
+			w.Write([]byte(`{"data":{"password":"hunter1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":["secret ` + r.URL.Path + ` not found"]}`))
+		}
+	}))
+	defer server.Close()
+
+	repo := test.NewRepository(true)
+
+	vaultInt, err := repo.VaultIntegration().CreateVaultIntegration(&ints.VaultIntegration{
+		ProjectID: 1,
+		Address:   server.URL,
+		Token:     []byte("vault-token"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedValue   string
+		expectedVersion string
+		expectedErr     string
+	}{
+		{"kv version 2", "secret/data/db", "hunter2", "3", ""},
+		{"kv version 1", "kv/db", "hunter1", "", ""},
+		{"api prefix", "/v1/secret/data/db", "hunter2", "3", ""},
+		{"response body is not returned", "secret/data/missing", "", "", "request to vault failed with status code 404"},
+		{"url", "https://attacker.example.com/v1/secret/data/db", "", "", "path must be a KV path"},
+		{"parent path", "secret/../../sys/health", "", "", "path must be a KV path"},
+	}
+
+	for _, test := range tests {
+		value, version, err := envsource.Resolve(repo, &models.EnvGroupSource{
+			ProjectID:     1,
+			Kind:          types.EnvGroupSourceKindVault,
+			IntegrationID: vaultInt.ID,
+			Path:          test.path,
+			Field:         "password",
+		})
+
+		if test.expectedErr != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), test.expectedErr) {
+				t.Errorf("%s: expected error %q, got %v", test.name, test.expectedErr, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+
+		if value != test.expectedValue || version != test.expectedVersion {
+			t.Errorf("%s: expected %s at version %q, got %s at version %q",
+				test.name, test.expectedValue, test.expectedVersion, value, version)
+		}
+	}
+}
+
+func TestValidateVaultIntegration(t *testing.T) {
+	repo := test.NewRepository(true)
+
+	_, err := repo.VaultIntegration().CreateVaultIntegration(&ints.VaultIntegration{
+		ProjectID: 1,
+		Address:   "https://vault.example.com",
+		Token:     []byte("vault-token"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tests := []struct {
+		name      string
+		projectID uint
+		path      string
+		valid     bool
+	}{
+		{"vault integration", 1, "secret/data/db", true},
+		{"other project", 2, "secret/data/db", false},
+		{"url", 1, "https://vault.example.com/v1/secret/data/db", false},
+	}
+
+	for _, test := range tests {
+		err := envsource.ValidateIntegration(repo, test.projectID, &types.EnvGroupSourceInput{
+			Key:           "DB_PASSWORD",
+			Kind:          types.EnvGroupSourceKindVault,
+			IntegrationID: 1,
+			Path:          test.path,
+			Field:         "password",
+		})
+
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("%s: expected valid to be %t, got error %v", test.name, test.valid, err)
+		}
+	}
+}
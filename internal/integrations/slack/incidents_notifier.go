@@ -13,6 +13,46 @@ import (
 	"github.com/porter-dev/porter/internal/models/integrations"
 )
 
+// IncidentNotifier sends notifications when incidents are created and resolved
+type IncidentNotifier interface {
+	NotifyNew(incident *porter_agent.Incident, url string) error
+	NotifyResolved(incident *porter_agent.Incident, url string) error
+}
+
+type multiIncidentNotifier struct {
+	notifiers []IncidentNotifier
+}
+
+// NewMultiIncidentNotifier returns an incident notifier which sends each notification
+// to all of the given notifiers, returning the first error
+func NewMultiIncidentNotifier(notifiers ...IncidentNotifier) IncidentNotifier {
+	return &multiIncidentNotifier{notifiers}
+}
+
+func (m *multiIncidentNotifier) NotifyNew(incident *porter_agent.Incident, url string) error {
+	var firstErr error
+
+	for _, notifier := range m.notifiers {
+		if err := notifier.NotifyNew(incident, url); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (m *multiIncidentNotifier) NotifyResolved(incident *porter_agent.Incident, url string) error {
+	var firstErr error
+
+	for _, notifier := range m.notifiers {
+		if err := notifier.NotifyResolved(incident, url); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
 type IncidentsNotifier struct {
 	slackInts []*integrations.SlackIntegration
 	Config    *types.NotificationConfig
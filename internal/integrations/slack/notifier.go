@@ -16,12 +16,60 @@ type Notifier interface {
 	Notify(opts *NotifyOpts) error
 }
 
+// ShouldNotify returns whether a notification with the given status should be sent,
+// according to a release's notification config. A nil config allows all notifications.
+func ShouldNotify(conf *types.NotificationConfig, status DeploymentStatus) bool {
+	if conf == nil {
+		return true
+	}
+
+	if !conf.Enabled {
+		return false
+	}
+
+	switch status {
+	case StatusHelmDeployed:
+		return conf.Success
+	case StatusPodCrashed, StatusHelmFailed, StatusHelmRolledBack:
+		return conf.Failure
+	}
+
+	return true
+}
+
+type multiNotifier struct {
+	notifiers []Notifier
+}
+
+// NewMultiNotifier returns a notifier which sends each notification to all of the
+// given notifiers. Every notifier is called even if an earlier one fails, and the
+// first error is returned.
+func NewMultiNotifier(notifiers ...Notifier) Notifier {
+	return &multiNotifier{notifiers}
+}
+
+func (m *multiNotifier) Notify(opts *NotifyOpts) error {
+	var firstErr error
+
+	for _, notifier := range m.notifiers {
+		if err := notifier.Notify(opts); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
 type DeploymentStatus string
 
 const (
 	StatusHelmDeployed DeploymentStatus = "helm_deployed"
 	StatusPodCrashed   DeploymentStatus = "pod_crashed"
 	StatusHelmFailed   DeploymentStatus = "helm_failed"
+
+	// StatusHelmRolledBack is sent when an upgraded revision failed its health checks
+	// and was automatically rolled back to the previous revision
+	StatusHelmRolledBack DeploymentStatus = "helm_rolled_back"
 )
 
 type NotifyOpts struct {
@@ -81,19 +129,8 @@ type SlackText struct {
 }
 
 func (s *SlackNotifier) Notify(opts *NotifyOpts) error {
-	if s.Config != nil {
-		if !s.Config.Enabled {
-			return nil
-		}
-		if opts.Status == StatusHelmDeployed && !s.Config.Success {
-			return nil
-		}
-		if opts.Status == StatusPodCrashed && !s.Config.Failure {
-			return nil
-		}
-		if opts.Status == StatusHelmFailed && !s.Config.Failure {
-			return nil
-		}
+	if !ShouldNotify(s.Config, opts.Status) {
+		return nil
 	}
 
 	// we create a basic payload as a fallback if the detailed payload with "info" fails, due to
@@ -140,7 +177,7 @@ func (s *SlackNotifier) Notify(opts *NotifyOpts) error {
 func getSlackBlocks(opts *NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
 	res := []*SlackBlock{}
 
-	if opts.Status == StatusHelmDeployed || opts.Status == StatusHelmFailed {
+	if opts.Status == StatusHelmDeployed || opts.Status == StatusHelmFailed || opts.Status == StatusHelmRolledBack {
 		res = append(res, getHelmMessageBlock(opts))
 	} else if opts.Status == StatusPodCrashed {
 		res = append(res, getPodCrashedMessageBlock(opts))
@@ -162,7 +199,7 @@ func getSlackBlocks(opts *NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
 		)
 	}
 
-	if opts.Status == StatusHelmDeployed || opts.Status == StatusHelmFailed {
+	if opts.Status == StatusHelmDeployed || opts.Status == StatusHelmFailed || opts.Status == StatusHelmRolledBack {
 		res = append(res, getMarkdownBlock(fmt.Sprintf("*Version:* %d", opts.Version)))
 	}
 
@@ -201,6 +238,8 @@ func getHelmMessageBlock(opts *NotifyOpts) *SlackBlock {
 		md = getHelmSuccessMessage(opts)
 	case StatusHelmFailed:
 		md = getHelmFailedMessage(opts)
+	case StatusHelmRolledBack:
+		md = getHelmRolledBackMessage(opts)
 	}
 
 	return getMarkdownBlock(md)
@@ -224,6 +263,8 @@ func getInfoBlock(opts *NotifyOpts) *SlackBlock {
 		md = getFailedInfoMessage(opts)
 	case StatusPodCrashed:
 		md = getFailedInfoMessage(opts)
+	case StatusHelmRolledBack:
+		md = getFailedInfoMessage(opts)
 	default:
 		return nil
 	}
@@ -247,6 +288,15 @@ func getHelmFailedMessage(opts *NotifyOpts) string {
 	)
 }
 
+func getHelmRolledBackMessage(opts *NotifyOpts) string {
+	return fmt.Sprintf(
+		":rewind: Your application %s failed its health checks on Porter and was rolled back to version %d. <%s|View the status here.>",
+		"`"+opts.Name+"`",
+		opts.Version,
+		opts.URL,
+	)
+}
+
 func getFailedInfoMessage(opts *NotifyOpts) string {
 	info := opts.Info
 
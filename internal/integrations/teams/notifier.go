@@ -0,0 +1,279 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+const (
+	colorSuccess = "2EB67D"
+	colorWarning = "ECB22E"
+	colorFailure = "E01E5A"
+)
+
+// maxInfoLength is the maximum length of error messages included in a card
+const maxInfoLength = 1000
+
+// MessageCard is the card format accepted by Microsoft Teams incoming webhooks
+type MessageCard struct {
+	Type            string         `json:"@type"`
+	Context         string         `json:"@context"`
+	ThemeColor      string         `json:"themeColor"`
+	Summary         string         `json:"summary"`
+	Sections        []*CardSection `json:"sections"`
+	PotentialAction []*CardOpenURI `json:"potentialAction,omitempty"`
+}
+
+type CardSection struct {
+	ActivityTitle string      `json:"activityTitle"`
+	Facts         []*CardFact `json:"facts,omitempty"`
+	Text          string      `json:"text,omitempty"`
+	Markdown      bool        `json:"markdown"`
+}
+
+type CardFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CardOpenURI struct {
+	Type    string           `json:"@type"`
+	Name    string           `json:"name"`
+	Targets []*CardURITarget `json:"targets"`
+}
+
+type CardURITarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+type Notifier struct {
+	teamsInts []*integrations.TeamsIntegration
+	Config    *types.NotificationConfig
+	client    *http.Client
+	logger    *logger.Logger
+
+	// deliveries tracks the notifications that are being sent in the background
+	deliveries sync.WaitGroup
+}
+
+// NewNotifier returns a notifier which posts a message card to the incoming webhook of
+// each Teams channel, with a button that opens the release or incident in the dashboard.
+// Cards are posted in the background, and failed deliveries are logged.
+func NewNotifier(
+	conf *types.NotificationConfig,
+	l *logger.Logger,
+	teamsInts ...*integrations.TeamsIntegration,
+) *Notifier {
+	return &Notifier{
+		teamsInts: teamsInts,
+		Config:    conf,
+		client: &http.Client{
+			Timeout: time.Second * 5,
+		},
+		logger: l,
+	}
+}
+
+// Wait blocks until the notifications being sent in the background are delivered or
+// have failed
+func (n *Notifier) Wait() {
+	n.deliveries.Wait()
+}
+
+func (n *Notifier) Notify(opts *slack.NotifyOpts) error {
+	if !slack.ShouldNotify(n.Config, opts.Status) {
+		return nil
+	}
+
+	return n.send(getDeploymentCard(opts))
+}
+
+func (n *Notifier) NotifyNew(incident *porter_agent.Incident, url string) error {
+	if n.Config != nil && !n.Config.Enabled {
+		return nil
+	}
+
+	createdAt := time.Unix(incident.CreatedAt, 0).UTC()
+
+	card := newCard(
+		colorFailure,
+		fmt.Sprintf("Your application %s crashed on Porter", incident.ReleaseName),
+		url,
+		"View the incident",
+		&CardFact{Name: "Namespace", Value: getIncidentNamespace(incident)},
+		&CardFact{Name: "Name", Value: incident.ReleaseName},
+		&CardFact{Name: "Created at", Value: createdAt.Format("2006-01-02 15:04:05 UTC")},
+	)
+
+	card.Sections[0].Text = getInfoText(incident.LatestMessage)
+
+	return n.send(card)
+}
+
+func (n *Notifier) NotifyResolved(incident *porter_agent.Incident, url string) error {
+	if n.Config != nil && !n.Config.Enabled {
+		return nil
+	}
+
+	createdAt := time.Unix(incident.CreatedAt, 0).UTC()
+	resolvedAt := time.Unix(incident.UpdatedAt, 0).UTC()
+
+	return n.send(newCard(
+		colorSuccess,
+		fmt.Sprintf("The incident for application %s has been resolved", incident.ReleaseName),
+		url,
+		"View the incident",
+		&CardFact{Name: "Namespace", Value: getIncidentNamespace(incident)},
+		&CardFact{Name: "Name", Value: incident.ReleaseName},
+		&CardFact{Name: "Created at", Value: createdAt.Format("2006-01-02 15:04:05 UTC")},
+		&CardFact{Name: "Resolved at", Value: resolvedAt.Format("2006-01-02 15:04:05 UTC")},
+	))
+}
+
+// send posts the card to every Teams channel in the background, so that a slow webhook
+// does not block the request that sent the notification. It only returns an error if the
+// card cannot be encoded.
+func (n *Notifier) send(card *MessageCard) error {
+	payload, err := json.Marshal(card)
+
+	if err != nil {
+		return err
+	}
+
+	for _, teamsInt := range n.teamsInts {
+		n.deliveries.Add(1)
+
+		go func(teamsInt *integrations.TeamsIntegration) {
+			defer n.deliveries.Done()
+
+			if err := n.post(string(teamsInt.Webhook), payload); err != nil {
+				n.logger.Error().Err(err).Msgf("could not send teams notification to %s", teamsInt.Name)
+			}
+		}(teamsInt)
+	}
+
+	return nil
+}
+
+func (n *Notifier) post(url string, payload []byte) error {
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(payload))
+
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("request failed with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func getDeploymentCard(opts *slack.NotifyOpts) *MessageCard {
+	var color, title, action string
+
+	switch opts.Status {
+	case slack.StatusHelmDeployed:
+		color = colorSuccess
+		title = fmt.Sprintf("Your application %s was successfully updated on Porter", opts.Name)
+		action = "View the new release"
+	case slack.StatusHelmFailed:
+		color = colorFailure
+		title = fmt.Sprintf("Your application %s failed to deploy on Porter", opts.Name)
+		action = "View the status"
+	case slack.StatusHelmRolledBack:
+		color = colorWarning
+		title = fmt.Sprintf(
+			"Your application %s failed its health checks on Porter and was rolled back to version %d",
+			opts.Name, opts.Version,
+		)
+		action = "View the status"
+	case slack.StatusPodCrashed:
+		color = colorFailure
+		title = fmt.Sprintf("Your application %s crashed on Porter", opts.Name)
+		action = "View the application"
+	}
+
+	facts := []*CardFact{
+		{Name: "Name", Value: opts.Name},
+		{Name: "Namespace", Value: opts.Namespace},
+	}
+
+	if opts.ClusterName != "" {
+		facts = append(facts, &CardFact{Name: "Cluster", Value: opts.ClusterName})
+	}
+
+	if opts.Timestamp != nil {
+		facts = append(facts, &CardFact{Name: "Timestamp", Value: opts.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")})
+	}
+
+	if opts.Status != slack.StatusPodCrashed {
+		facts = append(facts, &CardFact{Name: "Version", Value: fmt.Sprintf("%d", opts.Version)})
+	}
+
+	card := newCard(color, title, opts.URL, action, facts...)
+
+	if opts.Status != slack.StatusHelmDeployed {
+		card.Sections[0].Text = getInfoText(opts.Info)
+	}
+
+	return card
+}
+
+func newCard(color, title, url, action string, facts ...*CardFact) *MessageCard {
+	card := &MessageCard{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		ThemeColor: color,
+		Summary:    title,
+		Sections: []*CardSection{{
+			ActivityTitle: title,
+			Facts:         facts,
+			Markdown:      true,
+		}},
+	}
+
+	if url != "" {
+		card.PotentialAction = []*CardOpenURI{{
+			Type:    "OpenUri",
+			Name:    action,
+			Targets: []*CardURITarget{{OS: "default", URI: url}},
+		}}
+	}
+
+	return card
+}
+
+func getInfoText(info string) string {
+	if info == "" {
+		return ""
+	}
+
+	if len(info) > maxInfoLength {
+		info = info[0:maxInfoLength] + "..."
+	}
+
+	return fmt.Sprintf("```\n%s\n```", info)
+}
+
+func getIncidentNamespace(incident *porter_agent.Incident) string {
+	if segments := strings.Split(incident.ID, ":"); len(segments) > 2 {
+		return segments[2]
+	}
+
+	return ""
+}
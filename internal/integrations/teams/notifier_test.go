@@ -0,0 +1,108 @@
+package teams_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/integrations/teams"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// cardRecorder is a Teams incoming webhook which records the cards posted to it
+type cardRecorder struct {
+	t     *testing.T
+	cards []*teams.MessageCard
+}
+
+func (c *cardRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	card := &teams.MessageCard{}
+
+	if err := json.NewDecoder(r.Body).Decode(card); err != nil {
+		c.t.Errorf("could not decode card: %v", err)
+	}
+
+	c.cards = append(c.cards, card)
+}
+
+func TestNotifyDeployment(t *testing.T) {
+	recorder := &cardRecorder{t: t}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	notifier := teams.NewNotifier(nil, logger.NewErrorConsole(false), &integrations.TeamsIntegration{Name: "test", Webhook: []byte(server.URL)})
+
+	err := notifier.Notify(&slack.NotifyOpts{
+		Status:    slack.StatusHelmFailed,
+		Name:      "web",
+		Namespace: "default",
+		Info:      "upgrade failed",
+		URL:       "https://dashboard.test/web",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	if len(recorder.cards) != 1 {
+		t.Fatalf("expected 1 card, got %d", len(recorder.cards))
+	}
+
+	card := recorder.cards[0]
+
+	if card.Type != "MessageCard" || !strings.Contains(card.Summary, "failed to deploy") {
+		t.Errorf("unexpected card %+v", card)
+	}
+
+	if !strings.Contains(card.Sections[0].Text, "upgrade failed") {
+		t.Errorf("expected info in card text, got %s", card.Sections[0].Text)
+	}
+
+	if len(card.PotentialAction) != 1 || card.PotentialAction[0].Targets[0].URI != "https://dashboard.test/web" {
+		t.Errorf("unexpected card actions %+v", card.PotentialAction)
+	}
+}
+
+func TestNotifyIncident(t *testing.T) {
+	recorder := &cardRecorder{t: t}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	notifier := teams.NewNotifier(nil, logger.NewErrorConsole(false), &integrations.TeamsIntegration{Name: "test", Webhook: []byte(server.URL)})
+
+	incident := &porter_agent.Incident{
+		ID:          "incident:web:default:1",
+		ReleaseName: "web",
+	}
+
+	if err := notifier.NotifyNew(incident, "https://dashboard.test/incident"); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	if err := notifier.NotifyResolved(incident, "https://dashboard.test/incident"); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	if len(recorder.cards) != 2 {
+		t.Fatalf("expected 2 cards, got %d", len(recorder.cards))
+	}
+
+	if !strings.Contains(recorder.cards[1].Summary, "resolved") {
+		t.Errorf("unexpected resolved card summary %s", recorder.cards[1].Summary)
+	}
+
+	if recorder.cards[0].Sections[0].Facts[0].Value != "default" {
+		t.Errorf("expected namespace fact, got %+v", recorder.cards[0].Sections[0].Facts[0])
+	}
+}
@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/webhook"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// EventHeader is the header which carries the event type of a notification
+const EventHeader = "X-Porter-Event"
+
+type Event string
+
+const (
+	EventDeployment       Event = "deployment"
+	EventIncidentNew      Event = "incident.new"
+	EventIncidentResolved Event = "incident.resolved"
+)
+
+// Payload is the JSON body sent to webhook integrations. Exactly one of Deployment
+// and Incident is set, depending on the event.
+type Payload struct {
+	Event      Event              `json:"event"`
+	SentAt     time.Time          `json:"sent_at"`
+	Deployment *DeploymentPayload `json:"deployment,omitempty"`
+	Incident   *IncidentPayload   `json:"incident,omitempty"`
+}
+
+// DeploymentPayload mirrors slack.NotifyOpts
+type DeploymentPayload struct {
+	ProjectID   uint                   `json:"project_id"`
+	ClusterID   uint                   `json:"cluster_id"`
+	ClusterName string                 `json:"cluster_name"`
+	Status      slack.DeploymentStatus `json:"status"`
+	Info        string                 `json:"info,omitempty"`
+	Name        string                 `json:"name"`
+	Namespace   string                 `json:"namespace"`
+	URL         string                 `json:"url"`
+	Timestamp   *time.Time             `json:"timestamp,omitempty"`
+	Version     int                    `json:"version,omitempty"`
+}
+
+// IncidentPayload mirrors porter_agent.Incident, with the namespace of the incident
+// and a link to the incident in the dashboard
+type IncidentPayload struct {
+	*porter_agent.Incident
+
+	Namespace string `json:"namespace"`
+	URL       string `json:"url"`
+}
+
+// maxAttempts is the number of times a notification is sent before giving up
+const maxAttempts = 3
+
+// retryBackoff is the wait before the first retry, doubled after every attempt
+var retryBackoff = time.Second
+
+// newClient returns the client used to send notifications, which refuses to connect to
+// internal addresses
+var newClient = func() *http.Client {
+	return webhook.NewClient(time.Second * 5)
+}
+
+type Notifier struct {
+	webhookInts []*integrations.WebhookIntegration
+	Config      *types.NotificationConfig
+	client      *http.Client
+	logger      *logger.Logger
+
+	// deliveries tracks the notifications that are being sent in the background
+	deliveries sync.WaitGroup
+}
+
+// NewNotifier returns a notifier which sends deployment and incident notifications to
+// the given webhook integrations. It implements both slack.Notifier and
+// slack.IncidentNotifier. Notifications are sent and retried in the background, and
+// failed deliveries are logged with the given logger.
+func NewNotifier(
+	conf *types.NotificationConfig,
+	l *logger.Logger,
+	webhookInts ...*integrations.WebhookIntegration,
+) *Notifier {
+	return &Notifier{
+		webhookInts: webhookInts,
+		Config:      conf,
+		client:      newClient(),
+		logger:      l,
+	}
+}
+
+// Wait blocks until the notifications being sent in the background are delivered or
+// have failed
+func (n *Notifier) Wait() {
+	n.deliveries.Wait()
+}
+
+func (n *Notifier) Notify(opts *slack.NotifyOpts) error {
+	if !slack.ShouldNotify(n.Config, opts.Status) {
+		return nil
+	}
+
+	return n.send(&Payload{
+		Event: EventDeployment,
+		Deployment: &DeploymentPayload{
+			ProjectID:   opts.ProjectID,
+			ClusterID:   opts.ClusterID,
+			ClusterName: opts.ClusterName,
+			Status:      opts.Status,
+			Info:        opts.Info,
+			Name:        opts.Name,
+			Namespace:   opts.Namespace,
+			URL:         opts.URL,
+			Timestamp:   opts.Timestamp,
+			Version:     opts.Version,
+		},
+	})
+}
+
+func (n *Notifier) NotifyNew(incident *porter_agent.Incident, url string) error {
+	return n.notifyIncident(EventIncidentNew, incident, url)
+}
+
+func (n *Notifier) NotifyResolved(incident *porter_agent.Incident, url string) error {
+	return n.notifyIncident(EventIncidentResolved, incident, url)
+}
+
+func (n *Notifier) notifyIncident(event Event, incident *porter_agent.Incident, url string) error {
+	if n.Config != nil && !n.Config.Enabled {
+		return nil
+	}
+
+	var namespace string
+
+	if segments := strings.Split(incident.ID, ":"); len(segments) > 2 {
+		namespace = segments[2]
+	}
+
+	return n.send(&Payload{
+		Event: event,
+		Incident: &IncidentPayload{
+			Incident:  incident,
+			Namespace: namespace,
+			URL:       url,
+		},
+	})
+}
+
+// send delivers the payload to every webhook integration in the background, so that
+// retrying an unreachable endpoint does not block the request that sent the notification.
+// It only returns an error if the payload cannot be encoded.
+func (n *Notifier) send(payload *Payload) error {
+	payload.SentAt = time.Now().UTC()
+
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	for _, webhookInt := range n.webhookInts {
+		n.deliveries.Add(1)
+
+		go func(webhookInt *integrations.WebhookIntegration) {
+			defer n.deliveries.Done()
+
+			if err := n.sendWithRetries(webhookInt, payload.Event, body); err != nil {
+				n.logger.Error().Err(err).Msgf("could not send webhook notification to %s", webhookInt.Name)
+			}
+		}(webhookInt)
+	}
+
+	return nil
+}
+
+func (n *Notifier) sendWithRetries(webhookInt *integrations.WebhookIntegration, event Event, body []byte) error {
+	var err error
+	backoff := retryBackoff
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		var retry bool
+
+		retry, err = n.sendOnce(webhookInt, event, body)
+
+		if err == nil || !retry {
+			return err
+		}
+
+		if attempt < maxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return err
+}
+
+// sendOnce sends a single request, and returns whether a failed request should be
+// retried. Network errors, rate limiting and server errors are retried.
+func (n *Notifier) sendOnce(webhookInt *integrations.WebhookIntegration, event Event, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, string(webhookInt.URL), bytes.NewReader(body))
+
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Porter-Webhook")
+	req.Header.Set(EventHeader, string(event))
+
+	if len(webhookInt.SigningSecret) > 0 {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(string(webhookInt.SigningSecret), time.Now(), body))
+	}
+
+	resp, err := n.client.Do(req)
+
+	if err != nil {
+		return true, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = fmt.Errorf("request failed with status code %d", resp.StatusCode)
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
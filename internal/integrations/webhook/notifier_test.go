@@ -0,0 +1,203 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/webhook"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+func init() {
+	retryBackoff = time.Millisecond
+
+	// the test servers listen on the loopback address
+	newClient = func() *http.Client {
+		return &http.Client{Timeout: time.Second}
+	}
+}
+
+type testReceiver struct {
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (t *testReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	t.requests = append(t.requests, r)
+	t.bodies = append(t.bodies, body)
+
+	status := http.StatusOK
+
+	if len(t.statuses) >= len(t.requests) {
+		status = t.statuses[len(t.requests)-1]
+	}
+
+	w.WriteHeader(status)
+}
+
+func TestNotifySigned(t *testing.T) {
+	receiver := &testReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	notifier := NewNotifier(nil, logger.NewErrorConsole(false), &integrations.WebhookIntegration{
+		Name:          "test",
+		URL:           []byte(server.URL),
+		SigningSecret: []byte("secret"),
+	})
+
+	err := notifier.Notify(&slack.NotifyOpts{
+		ProjectID: 1,
+		Status:    slack.StatusHelmDeployed,
+		Name:      "web",
+		Namespace: "default",
+		Version:   2,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(receiver.requests))
+	}
+
+	req := receiver.requests[0]
+
+	if req.Header.Get(EventHeader) != string(EventDeployment) {
+		t.Errorf("unexpected event header %s", req.Header.Get(EventHeader))
+	}
+
+	_, err = webhook.Verify("secret", req.Header.Get(webhook.SignatureHeader), receiver.bodies[0], time.Now(), time.Minute)
+
+	if err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+
+	payload := &Payload{}
+
+	if err := json.Unmarshal(receiver.bodies[0], payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Deployment == nil || payload.Deployment.Name != "web" || payload.Deployment.Version != 2 ||
+		payload.Deployment.Status != slack.StatusHelmDeployed {
+		t.Errorf("unexpected payload %+v", payload.Deployment)
+	}
+}
+
+func TestNotifyRetries(t *testing.T) {
+	receiver := &testReceiver{
+		statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK},
+	}
+
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	notifier := NewNotifier(nil, logger.NewErrorConsole(false), &integrations.WebhookIntegration{
+		Name: "test",
+		URL:  []byte(server.URL),
+	})
+
+	err := notifier.NotifyNew(&porter_agent.Incident{
+		ID:          "incident:web:default:1",
+		ReleaseName: "web",
+	}, "https://dashboard.test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	if len(receiver.requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(receiver.requests))
+	}
+
+	if receiver.requests[0].Header.Get(webhook.SignatureHeader) != "" {
+		t.Errorf("expected unsigned request")
+	}
+
+	payload := &Payload{}
+
+	if err := json.Unmarshal(receiver.bodies[2], payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Event != EventIncidentNew || payload.Incident == nil || payload.Incident.Namespace != "default" ||
+		payload.Incident.ReleaseName != "web" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
+
+func TestNotifyNoRetryOnClientError(t *testing.T) {
+	receiver := &testReceiver{
+		statuses: []int{http.StatusBadRequest},
+	}
+
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	notifier := NewNotifier(nil, logger.NewErrorConsole(false), &integrations.WebhookIntegration{
+		Name: "test",
+		URL:  []byte(server.URL),
+	})
+
+	err := notifier.sendWithRetries(notifier.webhookInts[0], EventDeployment, []byte("{}"))
+
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if len(receiver.requests) != 1 {
+		t.Errorf("expected 1 request, got %d", len(receiver.requests))
+	}
+}
+
+func TestNotifyConfig(t *testing.T) {
+	receiver := &testReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	notifier := NewNotifier(&types.NotificationConfig{
+		Enabled: true,
+		Success: false,
+		Failure: true,
+	}, logger.NewErrorConsole(false), &integrations.WebhookIntegration{
+		Name: "test",
+		URL:  []byte(server.URL),
+	})
+
+	if err := notifier.Notify(&slack.NotifyOpts{Status: slack.StatusHelmDeployed}); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	if len(receiver.requests) != 0 {
+		t.Fatalf("expected success notification to be skipped")
+	}
+
+	if err := notifier.Notify(&slack.NotifyOpts{Status: slack.StatusPodCrashed}); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Wait()
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("expected failure notification to be sent")
+	}
+}
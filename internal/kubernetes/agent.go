@@ -202,6 +202,25 @@ func (a *Agent) RemoveApplicationFromVersionedConfigMap(cm *v1.ConfigMap, appNam
 	)
 }
 
+// SetVersionedConfigMapAnnotation sets an annotation on a version of an env group
+func (a *Agent) SetVersionedConfigMapAnnotation(cm *v1.ConfigMap, key, value string) (*v1.ConfigMap, error) {
+	annons := cm.Annotations
+
+	if annons == nil {
+		annons = make(map[string]string)
+	}
+
+	annons[key] = value
+
+	cm.SetAnnotations(annons)
+
+	return a.Clientset.CoreV1().ConfigMaps(cm.Namespace).Update(
+		context.TODO(),
+		cm,
+		metav1.UpdateOptions{},
+	)
+}
+
 func (a *Agent) CreateLinkedVersionedSecret(name, namespace, cmName string, version uint, data map[string][]byte) (*v1.Secret, error) {
 	return a.Clientset.CoreV1().Secrets(namespace).Create(
 		context.TODO(),
@@ -420,6 +439,23 @@ func (a *Agent) ListAllVersionedConfigMaps(namespace string) ([]v1.ConfigMap, er
 	return res, nil
 }
 
+// ListAllConfigMapVersions lists every version of the versioned configmaps in a namespace,
+// or in all namespaces if the namespace is empty
+func (a *Agent) ListAllConfigMapVersions(namespace string) ([]v1.ConfigMap, error) {
+	listResp, err := a.Clientset.CoreV1().ConfigMaps(namespace).List(
+		context.Background(),
+		metav1.ListOptions{
+			LabelSelector: "envgroup,version",
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listResp.Items, nil
+}
+
 // GetConfigMap retrieves the configmap given its name and namespace
 func (a *Agent) GetConfigMap(name string, namespace string) (*v1.ConfigMap, error) {
 	return a.Clientset.CoreV1().ConfigMaps(namespace).Get(
@@ -79,6 +79,8 @@ func GetNGINXIngressServiceIP(clientset kubernetes.Interface) (string, bool, err
 type DNSRecord models.DNSRecord
 
 type CreateDNSRecordConfig struct {
+	ClusterID   uint
+	Namespace   string
 	ReleaseName string
 	RootDomain  string
 	Endpoint    string
@@ -96,6 +98,9 @@ func (c *CreateDNSRecordConfig) NewDNSRecordForEndpoint() *models.DNSRecord {
 		RootDomain:      c.RootDomain,
 		Endpoint:        c.Endpoint,
 		Hostname:        fmt.Sprintf("%s.%s", subdomain, c.RootDomain),
+		ClusterID:       c.ClusterID,
+		Namespace:       c.Namespace,
+		ReleaseName:     c.ReleaseName,
 	}
 }
 
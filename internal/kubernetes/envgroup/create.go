@@ -1,6 +1,7 @@
 package envgroup
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -74,6 +75,7 @@ func CreateEnvGroup(agent *kubernetes.Agent, input types.ConfigMapInput) (*v1.Co
 	}
 
 	apps := make([]string, 0)
+	sourceVersions := make(map[string]string)
 
 	if oldCM != nil {
 		oldEG, err := ToEnvGroup(oldCM)
@@ -81,6 +83,8 @@ func CreateEnvGroup(agent *kubernetes.Agent, input types.ConfigMapInput) (*v1.Co
 		if err == nil {
 			apps = oldEG.Applications
 		}
+
+		sourceVersions = getSourceVersions(oldCM)
 	}
 
 	oldSecret, _, err := agent.GetLatestVersionedSecret(input.Name, input.Namespace)
@@ -120,6 +124,23 @@ func CreateEnvGroup(agent *kubernetes.Agent, input types.ConfigMapInput) (*v1.Co
 		return nil, err
 	}
 
+	// keep the external versions of the sourced variables that are still secret variables
+	for key := range sourceVersions {
+		if _, ok := input.SecretVariables[key]; !ok {
+			delete(sourceVersions, key)
+		}
+	}
+
+	if len(sourceVersions) > 0 {
+		versionBytes, err := json.Marshal(sourceVersions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return agent.SetVersionedConfigMapAnnotation(cm, SourceVersionsAnnotationName, string(versionBytes))
+	}
+
 	return cm, err
 }
 
@@ -175,6 +196,10 @@ func ToEnvGroup(configMap *v1.ConfigMap) (*types.EnvGroup, error) {
 		res.Applications = []string{}
 	}
 
+	if sourceVersions := getSourceVersions(configMap); len(sourceVersions) > 0 {
+		res.SourceVersions = sourceVersions
+	}
+
 	return res, nil
 }
 
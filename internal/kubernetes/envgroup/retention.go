@@ -0,0 +1,208 @@
+package envgroup
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"helm.sh/helm/v3/pkg/release"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// GetReferencedVersions returns the env group versions that the synced env sections of
+// releases use, keyed by the namespace and name of the env group
+func GetReferencedVersions(releases []*release.Release) map[string]map[uint]bool {
+	res := make(map[string]map[uint]bool)
+
+	for _, rel := range releases {
+		if rel == nil {
+			continue
+		}
+
+		envConf, err := getNestedMap(rel.Config, "container", "env")
+
+		if err != nil {
+			continue
+		}
+
+		synced, ok := envConf["synced"].([]interface{})
+
+		if !ok {
+			continue
+		}
+
+		for _, sectionInter := range synced {
+			section, ok := sectionInter.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			name, ok := section["name"].(string)
+
+			if !ok || name == "" {
+				continue
+			}
+
+			version, ok := toVersion(section["version"])
+
+			if !ok {
+				continue
+			}
+
+			id := versionsID(rel.Namespace, name)
+
+			if _, exists := res[id]; !exists {
+				res[id] = make(map[uint]bool)
+			}
+
+			res[id][version] = true
+		}
+	}
+
+	return res
+}
+
+// PlanVersionRetention returns the versions of each env group in a list of versioned configmaps
+// that are kept and deleted by a policy keeping the last keepLast versions. The latest version
+// and the referenced versions, which should include those of the revisions that releases may
+// be rolled back to, are never deleted, and a keepLast of 0 keeps every version.
+func PlanVersionRetention(
+	configMaps []v1.ConfigMap,
+	keepLast uint,
+	referenced map[string]map[uint]bool,
+) []*types.EnvGroupVersionRetention {
+	ids := make([]string, 0)
+	names := make(map[string][2]string)
+	versions := make(map[string][]uint)
+
+	for _, cm := range configMaps {
+		name, ok := cm.Labels["envgroup"]
+
+		if !ok {
+			continue
+		}
+
+		version, err := strconv.ParseUint(cm.Labels["version"], 10, 64)
+
+		if err != nil {
+			continue
+		}
+
+		id := versionsID(cm.Namespace, name)
+
+		if _, exists := versions[id]; !exists {
+			ids = append(ids, id)
+			names[id] = [2]string{cm.Namespace, name}
+		}
+
+		versions[id] = append(versions[id], uint(version))
+	}
+
+	sort.Strings(ids)
+
+	res := make([]*types.EnvGroupVersionRetention, 0)
+
+	for _, id := range ids {
+		groupVersions := versions[id]
+
+		// sort the versions from newest to oldest
+		sort.Slice(groupVersions, func(i, j int) bool {
+			return groupVersions[i] > groupVersions[j]
+		})
+
+		retention := &types.EnvGroupVersionRetention{
+			Namespace:          names[id][0],
+			Name:               names[id][1],
+			LatestVersion:      groupVersions[0],
+			KeptVersions:       make([]uint, 0),
+			ReferencedVersions: make([]uint, 0),
+			DeletedVersions:    make([]uint, 0),
+		}
+
+		for i, version := range groupVersions {
+			switch {
+			case keepLast == 0 || i == 0 || uint(i) < keepLast:
+				retention.KeptVersions = append(retention.KeptVersions, version)
+			case referenced[id][version]:
+				retention.ReferencedVersions = append(retention.ReferencedVersions, version)
+			default:
+				retention.DeletedVersions = append(retention.DeletedVersions, version)
+			}
+		}
+
+		res = append(res, retention)
+	}
+
+	return res
+}
+
+// DeleteEnvGroupVersions deletes the configmaps and linked secrets of the versions of an env
+// group that a retention policy deletes
+func DeleteEnvGroupVersions(agent *kubernetes.Agent, retention *types.EnvGroupVersionRetention) error {
+	for _, version := range retention.DeletedVersions {
+		name := fmt.Sprintf("%s.v%d", retention.Name, version)
+
+		if err := agent.DeleteConfigMap(name, retention.Namespace); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		if err := agent.DeleteLinkedSecret(name, retention.Namespace); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func versionsID(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+func getNestedMap(obj map[string]interface{}, fields ...string) (map[string]interface{}, error) {
+	var res map[string]interface{}
+	curr := obj
+
+	for _, field := range fields {
+		objField, ok := curr[field]
+
+		if !ok {
+			return nil, fmt.Errorf("%s not found", field)
+		}
+
+		res, ok = objField.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("%s is not a map", field)
+		}
+
+		curr = res
+	}
+
+	return res, nil
+}
+
+// toVersion converts the version of a synced env section, which is a float when the values of
+// a release are decoded from JSON
+func toVersion(val interface{}) (uint, bool) {
+	switch v := val.(type) {
+	case float64:
+		return uint(v), v >= 0
+	case int:
+		return uint(v), v >= 0
+	case int64:
+		return uint(v), v >= 0
+	case uint:
+		return v, true
+	case uint64:
+		return uint(v), true
+	case string:
+		version, err := strconv.ParseUint(v, 10, 64)
+		return uint(version), err == nil
+	}
+
+	return 0, false
+}
@@ -0,0 +1,208 @@
+package envgroup_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/registry"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func syncedRelease(namespace string, sections ...map[string]interface{}) *release.Release {
+	synced := make([]interface{}, 0)
+
+	for _, section := range sections {
+		synced = append(synced, section)
+	}
+
+	return &release.Release{
+		Name:      "app",
+		Namespace: namespace,
+		Config: map[string]interface{}{
+			"container": map[string]interface{}{
+				"env": map[string]interface{}{
+					"synced": synced,
+				},
+			},
+		},
+	}
+}
+
+func TestGetReferencedVersions(t *testing.T) {
+	releases := []*release.Release{
+		syncedRelease("default", map[string]interface{}{"name": "test", "version": float64(2)}),
+		syncedRelease("default", map[string]interface{}{"name": "test", "version": float64(4)}),
+		syncedRelease("other", map[string]interface{}{"name": "test", "version": float64(1)}),
+		{Name: "no-env", Namespace: "default", Config: map[string]interface{}{}},
+	}
+
+	expected := map[string]map[uint]bool{
+		"default/test": {2: true, 4: true},
+		"other/test":   {1: true},
+	}
+
+	if referenced := envgroup.GetReferencedVersions(releases); !reflect.DeepEqual(referenced, expected) {
+		t.Fatalf("expected referenced versions to be %v, got %v", expected, referenced)
+	}
+}
+
+func TestPlanVersionRetention(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	for i := 1; i <= 5; i++ {
+		createVersion(t, agent, map[string]string{"KEY": fmt.Sprintf("%d", i)}, map[string]string{})
+	}
+
+	configMaps, err := agent.ListAllConfigMapVersions("default")
+
+	if err != nil {
+		t.Fatalf("error listing configmaps: %v", err)
+	}
+
+	referenced := map[string]map[uint]bool{
+		"default/test": {2: true},
+	}
+
+	plans := envgroup.PlanVersionRetention(configMaps, 2, referenced)
+
+	if len(plans) != 1 {
+		t.Fatalf("expected 1 env group, got %d", len(plans))
+	}
+
+	plan := plans[0]
+
+	if plan.LatestVersion != 5 {
+		t.Errorf("expected latest version to be 5, got %d", plan.LatestVersion)
+	}
+
+	if expected := []uint{5, 4}; !reflect.DeepEqual(plan.KeptVersions, expected) {
+		t.Errorf("expected kept versions to be %v, got %v", expected, plan.KeptVersions)
+	}
+
+	if expected := []uint{2}; !reflect.DeepEqual(plan.ReferencedVersions, expected) {
+		t.Errorf("expected referenced versions to be %v, got %v", expected, plan.ReferencedVersions)
+	}
+
+	if expected := []uint{3, 1}; !reflect.DeepEqual(plan.DeletedVersions, expected) {
+		t.Errorf("expected deleted versions to be %v, got %v", expected, plan.DeletedVersions)
+	}
+
+	// a policy of 0 keeps every version
+	plans = envgroup.PlanVersionRetention(configMaps, 0, referenced)
+
+	if len(plans[0].KeptVersions) != 5 || len(plans[0].DeletedVersions) != 0 {
+		t.Errorf("expected every version to be kept, got %v", plans[0].KeptVersions)
+	}
+
+	// the latest version is kept even if the policy keeps fewer versions
+	plans = envgroup.PlanVersionRetention(configMaps, 1, nil)
+
+	if expected := []uint{5}; !reflect.DeepEqual(plans[0].KeptVersions, expected) {
+		t.Errorf("expected kept versions to be %v, got %v", expected, plans[0].KeptVersions)
+	}
+}
+
+// TestPlanVersionRetentionKeepsRollbackRevisions checks that a version which is only used by a
+// superseded revision of a release is kept, since the release can be rolled back to it
+func TestPlanVersionRetentionKeepsRollbackRevisions(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	for i := 1; i <= 5; i++ {
+		createVersion(t, agent, map[string]string{"KEY": fmt.Sprintf("%d", i)}, map[string]string{})
+	}
+
+	configMaps, err := agent.ListAllConfigMapVersions("default")
+
+	if err != nil {
+		t.Fatalf("error listing configmaps: %v", err)
+	}
+
+	history := make([]*release.Release, 0)
+
+	// revision 1 uses version 1 and is older than the revisions the release can be rolled
+	// back to, revisions 2 to 11 use version 2 and revision 12 is deployed with version 5
+	for revision := 1; revision <= 12; revision++ {
+		version := float64(2)
+
+		switch revision {
+		case 1:
+			version = 1
+		case 12:
+			version = 5
+		}
+
+		rel := syncedRelease("default", map[string]interface{}{"name": "test", "version": version})
+		rel.Version = revision
+		rel.Info = &release.Info{Status: release.StatusSuperseded}
+
+		if revision == 12 {
+			rel.Info.Status = release.StatusDeployed
+		}
+
+		history = append(history, rel)
+	}
+
+	deployed := history[len(history)-1]
+	releases := append([]*release.Release{deployed}, registry.GetRollbackRevisions(history)...)
+
+	plans := envgroup.PlanVersionRetention(configMaps, 1, envgroup.GetReferencedVersions(releases))
+
+	if len(plans) != 1 {
+		t.Fatalf("expected 1 env group, got %d", len(plans))
+	}
+
+	if expected := []uint{2}; !reflect.DeepEqual(plans[0].ReferencedVersions, expected) {
+		t.Errorf("expected referenced versions to be %v, got %v", expected, plans[0].ReferencedVersions)
+	}
+
+	if expected := []uint{4, 3, 1}; !reflect.DeepEqual(plans[0].DeletedVersions, expected) {
+		t.Errorf("expected deleted versions to be %v, got %v", expected, plans[0].DeletedVersions)
+	}
+}
+
+func TestDeleteEnvGroupVersions(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	for i := 1; i <= 3; i++ {
+		createVersion(t, agent, map[string]string{}, map[string]string{"SECRET": fmt.Sprintf("%d", i)})
+	}
+
+	configMaps, err := agent.ListAllConfigMapVersions("")
+
+	if err != nil {
+		t.Fatalf("error listing configmaps: %v", err)
+	}
+
+	plans := envgroup.PlanVersionRetention(configMaps, 2, nil)
+
+	if err := envgroup.DeleteEnvGroupVersions(agent, plans[0]); err != nil {
+		t.Fatalf("error deleting versions: %v", err)
+	}
+
+	if _, err := agent.GetVersionedConfigMap("test", "default", 1); err == nil {
+		t.Errorf("expected version 1 to be deleted")
+	}
+
+	if _, err := agent.GetSecret("test.v1", "default"); err == nil {
+		t.Errorf("expected secret of version 1 to be deleted")
+	}
+
+	// the kept versions can still be read, including their secret variables
+	_, _, secretVariables, err := envgroup.GetEnvGroupVariables(agent, "test", "default", 2)
+
+	if err != nil {
+		t.Fatalf("error reading version 2: %v", err)
+	}
+
+	if secretVariables["SECRET"] != "2" {
+		t.Errorf("expected secret variable of version 2 to be 2, got %s", secretVariables["SECRET"])
+	}
+
+	// deleting versions that no longer exist is not an error
+	if err := envgroup.DeleteEnvGroupVersions(agent, plans[0]); err != nil {
+		t.Fatalf("error deleting versions again: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package envgroup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	v1 "k8s.io/api/core/v1"
+)
+
+// SourceVersionsAnnotationName is the annotation that stores the external versions that
+// the sourced variables of an env group version were read from, as a JSON object keyed by
+// variable name
+const SourceVersionsAnnotationName = "porter.run/source-versions"
+
+// SourceResolver returns the current value and external version of an env group source
+type SourceResolver func(source *models.EnvGroupSource) (string, string, error)
+
+// SyncEnvGroupSources resolves the sources of an env group into its secret variables. A new
+// version of the env group is only created if a value changed, in which case the returned
+// bool is true. The results of the sync are recorded on the sources, which should be saved
+// by the caller. A source that cannot be resolved keeps its previous value, and its error is
+// returned after the other sources are synced.
+func SyncEnvGroupSources(
+	agent *kubernetes.Agent,
+	name, namespace string,
+	sources []*models.EnvGroupSource,
+	resolve SourceResolver,
+	now time.Time,
+) (*v1.ConfigMap, bool, error) {
+	configMap, variables, secretVariables, err := GetEnvGroupVariables(agent, name, namespace, 0)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	oldVersions := getSourceVersions(configMap)
+	newVersions := make(map[string]string)
+	updated := false
+	syncErrs := make([]string, 0)
+
+	for _, source := range sources {
+		value, version, err := resolve(source)
+
+		if err != nil {
+			source.LastSyncError = err.Error()
+			syncErrs = append(syncErrs, fmt.Sprintf("%s: %s", source.Key, err.Error()))
+
+			if oldVersion, ok := oldVersions[source.Key]; ok {
+				newVersions[source.Key] = oldVersion
+			}
+
+			continue
+		}
+
+		source.ExternalVersion = version
+		source.LastSyncedAt = &now
+		source.LastSyncError = ""
+		newVersions[source.Key] = version
+
+		if oldValue, ok := secretVariables[source.Key]; !ok || oldValue != value {
+			updated = true
+		}
+
+		secretVariables[source.Key] = value
+		delete(variables, source.Key)
+	}
+
+	if updated {
+		configMap, err = CreateEnvGroup(agent, types.ConfigMapInput{
+			Name:            name,
+			Namespace:       namespace,
+			Variables:       variables,
+			SecretVariables: secretVariables,
+		})
+
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	if updated || !versionsEqual(oldVersions, newVersions) {
+		versionBytes, err := json.Marshal(newVersions)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		configMap, err = agent.SetVersionedConfigMapAnnotation(configMap, SourceVersionsAnnotationName, string(versionBytes))
+
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	if len(syncErrs) > 0 {
+		return configMap, updated, fmt.Errorf("could not sync env group sources: %s", strings.Join(syncErrs, "; "))
+	}
+
+	return configMap, updated, nil
+}
+
+func getSourceVersions(configMap *v1.ConfigMap) map[string]string {
+	res := make(map[string]string)
+
+	if versionStr, ok := configMap.Annotations[SourceVersionsAnnotationName]; ok && versionStr != "" {
+		// the annotation is written by SyncEnvGroupSources, so a failure to unmarshal just
+		// results in an empty map
+		json.Unmarshal([]byte(versionStr), &res)
+	}
+
+	return res
+}
+
+func versionsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, val := range a {
+		if bVal, ok := b[key]; !ok || bVal != val {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,199 @@
+package envgroup_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type externalSecret struct {
+	value   string
+	version string
+	err     error
+}
+
+func resolverFor(secrets map[string]*externalSecret) envgroup.SourceResolver {
+	return func(source *models.EnvGroupSource) (string, string, error) {
+		secret, ok := secrets[source.Path]
+
+		if !ok {
+			return "", "", fmt.Errorf("secret %s not found", source.Path)
+		}
+
+		return secret.value, secret.version, secret.err
+	}
+}
+
+func syncSources(
+	t *testing.T,
+	agent *kubernetes.Agent,
+	sources []*models.EnvGroupSource,
+	secrets map[string]*externalSecret,
+) bool {
+	t.Helper()
+
+	_, updated, err := envgroup.SyncEnvGroupSources(agent, "test", "default", sources, resolverFor(secrets), time.Now())
+
+	if err != nil {
+		t.Fatalf("error syncing sources: %v", err)
+	}
+
+	return updated
+}
+
+func getLatestEnvGroup(t *testing.T, agent *kubernetes.Agent) (uint, map[string]string, map[string]string) {
+	t.Helper()
+
+	cm, variables, secretVariables, err := envgroup.GetEnvGroupVariables(agent, "test", "default", 0)
+
+	if err != nil {
+		t.Fatalf("error getting variables: %v", err)
+	}
+
+	eg, err := envgroup.ToEnvGroup(cm)
+
+	if err != nil {
+		t.Fatalf("error converting configmap: %v", err)
+	}
+
+	return eg.Version, variables, secretVariables
+}
+
+func TestSyncEnvGroupSources(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	// DB_PASSWORD starts out as a normal variable and is replaced by the sourced value
+	createVersion(t, agent, map[string]string{"A": "1", "DB_PASSWORD": "plain"}, map[string]string{"S": "s1"})
+
+	sources := []*models.EnvGroupSource{
+		{Key: "DB_PASSWORD", Path: "db"},
+		{Key: "API_KEY", Path: "api"},
+	}
+
+	secrets := map[string]*externalSecret{
+		"db":  {value: "hunter2", version: "1"},
+		"api": {value: "key", version: "v-abc"},
+	}
+
+	if !syncSources(t, agent, sources, secrets) {
+		t.Fatalf("expected the first sync to create a new version")
+	}
+
+	version, variables, secretVariables := getLatestEnvGroup(t, agent)
+
+	if version != 2 {
+		t.Errorf("expected version 2, got %d", version)
+	}
+
+	if len(variables) != 1 || variables["A"] != "1" {
+		t.Errorf("expected sourced variables to be removed from the variables, got %v", variables)
+	}
+
+	if secretVariables["DB_PASSWORD"] != "hunter2" || secretVariables["API_KEY"] != "key" || secretVariables["S"] != "s1" {
+		t.Errorf("unexpected secret variables %v", secretVariables)
+	}
+
+	if sources[0].ExternalVersion != "1" || sources[0].LastSyncedAt == nil || sources[1].ExternalVersion != "v-abc" {
+		t.Errorf("expected sync results to be recorded on the sources, got %+v, %+v", sources[0], sources[1])
+	}
+
+	eg, err := envgroup.GetEnvGroup(agent, "test", "default", 0)
+
+	if err != nil {
+		t.Fatalf("error getting env group: %v", err)
+	}
+
+	if eg.SourceVersions["DB_PASSWORD"] != "1" || eg.SourceVersions["API_KEY"] != "v-abc" {
+		t.Errorf("expected source versions to be recorded on the env group, got %v", eg.SourceVersions)
+	}
+
+	// a new external version with the same value only updates the recorded version
+	secrets["db"].version = "2"
+
+	if syncSources(t, agent, sources, secrets) {
+		t.Errorf("expected a sync without changed values not to create a new version")
+	}
+
+	eg, err = envgroup.GetEnvGroup(agent, "test", "default", 0)
+
+	if err != nil {
+		t.Fatalf("error getting env group: %v", err)
+	}
+
+	if eg.Version != 2 || eg.SourceVersions["DB_PASSWORD"] != "2" {
+		t.Errorf("expected version 2 with source version 2, got %d and %v", eg.Version, eg.SourceVersions)
+	}
+
+	// a changed value creates a new version
+	secrets["db"] = &externalSecret{value: "rotated", version: "3"}
+
+	if !syncSources(t, agent, sources, secrets) {
+		t.Fatalf("expected a changed value to create a new version")
+	}
+
+	version, _, secretVariables = getLatestEnvGroup(t, agent)
+
+	if version != 3 || secretVariables["DB_PASSWORD"] != "rotated" {
+		t.Errorf("expected version 3 with the rotated value, got %d and %v", version, secretVariables)
+	}
+
+	// editing the env group keeps the source versions of the sourced variables
+	createVersion(t, agent, map[string]string{
+		"A":           "2",
+		"DB_PASSWORD": "PORTERSECRET_test.v3",
+		"API_KEY":     "PORTERSECRET_test.v3",
+	}, map[string]string{})
+
+	eg, err = envgroup.GetEnvGroup(agent, "test", "default", 0)
+
+	if err != nil {
+		t.Fatalf("error getting env group: %v", err)
+	}
+
+	if eg.Version != 4 || eg.SourceVersions["DB_PASSWORD"] != "3" || eg.SourceVersions["API_KEY"] != "v-abc" {
+		t.Errorf("expected source versions to be kept on version 4, got %d and %v", eg.Version, eg.SourceVersions)
+	}
+}
+
+func TestSyncEnvGroupSourcesError(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	createVersion(t, agent, map[string]string{"A": "1"}, map[string]string{})
+
+	sources := []*models.EnvGroupSource{
+		{Key: "DB_PASSWORD", Path: "db"},
+		{Key: "API_KEY", Path: "missing"},
+	}
+
+	secrets := map[string]*externalSecret{
+		"db": {value: "hunter2", version: "1"},
+	}
+
+	_, updated, err := envgroup.SyncEnvGroupSources(agent, "test", "default", sources, resolverFor(secrets), time.Now())
+
+	if err == nil {
+		t.Fatalf("expected an error for the missing secret")
+	}
+
+	if !updated {
+		t.Errorf("expected the resolved source to be synced")
+	}
+
+	if sources[1].LastSyncError == "" || sources[1].LastSyncedAt != nil {
+		t.Errorf("expected the error to be recorded on the failed source, got %+v", sources[1])
+	}
+
+	_, _, secretVariables := getLatestEnvGroup(t, agent)
+
+	if secretVariables["DB_PASSWORD"] != "hunter2" {
+		t.Errorf("expected DB_PASSWORD to be synced, got %v", secretVariables)
+	}
+
+	if _, ok := secretVariables["API_KEY"]; ok {
+		t.Errorf("expected API_KEY not to be set, got %v", secretVariables)
+	}
+}
@@ -0,0 +1,195 @@
+package envgroup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MaskedSecretValue replaces the values of secret variables when versions are compared
+const MaskedSecretValue = "********"
+
+// GetEnvGroupVariables returns the variables and the secret variables of a version of an env
+// group, reading the values of secret variables from their linked secrets. If version is 0,
+// the latest version is returned.
+func GetEnvGroupVariables(
+	agent *kubernetes.Agent,
+	name, namespace string,
+	version uint,
+) (*v1.ConfigMap, map[string]string, map[string]string, error) {
+	var configMap *v1.ConfigMap
+	var err error
+
+	if version == 0 {
+		configMap, _, err = agent.GetLatestVersionedConfigMap(name, namespace)
+	} else {
+		configMap, err = agent.GetVersionedConfigMap(name, namespace, version)
+	}
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	variables := make(map[string]string)
+	secretVariables := make(map[string]string)
+	secrets := make(map[string]*v1.Secret)
+
+	for key, val := range configMap.Data {
+		if !strings.HasPrefix(val, "PORTERSECRET_") {
+			variables[key] = val
+			continue
+		}
+
+		// secret variables reference the secret that holds their value, like
+		// PORTERSECRET_${name}.v${version}
+		secretName := strings.TrimPrefix(val, "PORTERSECRET_")
+		secret, ok := secrets[secretName]
+
+		if !ok {
+			secret, err = agent.GetSecret(secretName, namespace)
+
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("could not get secret %s for variable %s: %w", secretName, key, err)
+			}
+
+			secrets[secretName] = secret
+		}
+
+		secretVal, ok := secret.Data[key]
+
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("secret %s does not contain a value for variable %s", secretName, key)
+		}
+
+		secretVariables[key] = string(secretVal)
+	}
+
+	return configMap, variables, secretVariables, nil
+}
+
+// DiffEnvGroupVersions returns the variables that were added, removed or changed between two
+// versions of an env group. A variable that changed from a normal to a secret variable or back
+// is changed even if its value is the same. The values of secret variables are masked.
+func DiffEnvGroupVersions(
+	agent *kubernetes.Agent,
+	name, namespace string,
+	from, to uint,
+) (*types.GetEnvGroupDiffResponse, error) {
+	fromCM, fromVars, fromSecretVars, err := GetEnvGroupVariables(agent, name, namespace, from)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toCM, toVars, toSecretVars, err := GetEnvGroupVariables(agent, name, namespace, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromEG, err := ToEnvGroup(fromCM)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toEG, err := ToEnvGroup(toCM)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.GetEnvGroupDiffResponse{
+		Name:        name,
+		Namespace:   namespace,
+		FromVersion: fromEG.Version,
+		ToVersion:   toEG.Version,
+		Added:       make([]*types.EnvGroupVariableChange, 0),
+		Removed:     make([]*types.EnvGroupVariableChange, 0),
+		Changed:     make([]*types.EnvGroupVariableChange, 0),
+	}
+
+	keys := make(map[string]bool)
+
+	for _, vars := range []map[string]string{fromVars, fromSecretVars, toVars, toSecretVars} {
+		for key := range vars {
+			keys[key] = true
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		oldVal, oldSecret, inOld := lookupVariable(key, fromVars, fromSecretVars)
+		newVal, newSecret, inNew := lookupVariable(key, toVars, toSecretVars)
+
+		change := &types.EnvGroupVariableChange{
+			Key:    key,
+			Secret: oldSecret || newSecret,
+		}
+
+		if inOld {
+			change.OldValue = maskValue(oldVal, oldSecret)
+		}
+
+		if inNew {
+			change.NewValue = maskValue(newVal, newSecret)
+		}
+
+		switch {
+		case !inOld:
+			res.Added = append(res.Added, change)
+		case !inNew:
+			res.Removed = append(res.Removed, change)
+		case oldVal != newVal || oldSecret != newSecret:
+			res.Changed = append(res.Changed, change)
+		}
+	}
+
+	return res, nil
+}
+
+// RollbackEnvGroup creates a new version of an env group with the variables of an older
+// version. The applications linked to the latest version stay linked.
+func RollbackEnvGroup(agent *kubernetes.Agent, name, namespace string, version uint) (*v1.ConfigMap, error) {
+	_, variables, secretVariables, err := GetEnvGroupVariables(agent, name, namespace, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            name,
+		Namespace:       namespace,
+		Variables:       variables,
+		SecretVariables: secretVariables,
+	})
+}
+
+func lookupVariable(key string, variables, secretVariables map[string]string) (string, bool, bool) {
+	if val, ok := secretVariables[key]; ok {
+		return val, true, true
+	}
+
+	val, ok := variables[key]
+
+	return val, false, ok
+}
+
+func maskValue(val string, secret bool) string {
+	if secret {
+		return MaskedSecretValue
+	}
+
+	return val
+}
@@ -0,0 +1,144 @@
+package envgroup_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+)
+
+func createVersion(t *testing.T, agent *kubernetes.Agent, variables, secretVariables map[string]string) {
+	t.Helper()
+
+	_, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "test",
+		Namespace:       "default",
+		Variables:       variables,
+		SecretVariables: secretVariables,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating env group version: %v", err)
+	}
+}
+
+func keysOf(changes []*types.EnvGroupVariableChange) []string {
+	res := make([]string, 0)
+
+	for _, change := range changes {
+		res = append(res, change.Key)
+	}
+
+	return res
+}
+
+func assertKeys(t *testing.T, name string, changes []*types.EnvGroupVariableChange, expected ...string) {
+	t.Helper()
+
+	keys := keysOf(changes)
+
+	if len(keys) != len(expected) {
+		t.Fatalf("expected %s to be %v, got %v", name, expected, keys)
+	}
+
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Fatalf("expected %s to be %v, got %v", name, expected, keys)
+		}
+	}
+}
+
+func TestDiffEnvGroupVersions(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	createVersion(t, agent, map[string]string{"A": "1", "B": "2"}, map[string]string{"S": "s1"})
+
+	// secret values that are not changed are sent as references to the previous secret
+	createVersion(t, agent, map[string]string{"A": "1", "C": "3", "S": "PORTERSECRET_test.v1"}, map[string]string{"T": "t1"})
+	createVersion(t, agent, map[string]string{"A": "changed", "B": "2"}, map[string]string{"S": "s2"})
+
+	diff, err := envgroup.DiffEnvGroupVersions(agent, "test", "default", 1, 2)
+
+	if err != nil {
+		t.Fatalf("error diffing versions: %v", err)
+	}
+
+	assertKeys(t, "added", diff.Added, "C", "T")
+	assertKeys(t, "removed", diff.Removed, "B")
+	assertKeys(t, "changed", diff.Changed)
+
+	if !diff.Added[1].Secret || diff.Added[1].NewValue != envgroup.MaskedSecretValue {
+		t.Errorf("expected secret variable T to be masked, got %+v", diff.Added[1])
+	}
+
+	// version 0 compares against the latest version
+	diff, err = envgroup.DiffEnvGroupVersions(agent, "test", "default", 1, 0)
+
+	if err != nil {
+		t.Fatalf("error diffing versions: %v", err)
+	}
+
+	if diff.ToVersion != 3 {
+		t.Errorf("expected to version to be 3, got %d", diff.ToVersion)
+	}
+
+	assertKeys(t, "added", diff.Added)
+	assertKeys(t, "removed", diff.Removed)
+	assertKeys(t, "changed", diff.Changed, "A", "S")
+
+	if diff.Changed[0].OldValue != "1" || diff.Changed[0].NewValue != "changed" {
+		t.Errorf("unexpected change for A: %+v", diff.Changed[0])
+	}
+
+	if diff.Changed[1].OldValue != envgroup.MaskedSecretValue || diff.Changed[1].NewValue != envgroup.MaskedSecretValue {
+		t.Errorf("expected secret variable S to be masked, got %+v", diff.Changed[1])
+	}
+}
+
+func TestRollbackEnvGroup(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	createVersion(t, agent, map[string]string{"A": "1"}, map[string]string{"S": "s1"})
+	createVersion(t, agent, map[string]string{"A": "2", "B": "3"}, map[string]string{"S": "s2"})
+
+	cm, err := envgroup.RollbackEnvGroup(agent, "test", "default", 1)
+
+	if err != nil {
+		t.Fatalf("error rolling back: %v", err)
+	}
+
+	eg, err := envgroup.ToEnvGroup(cm)
+
+	if err != nil {
+		t.Fatalf("error converting configmap: %v", err)
+	}
+
+	if eg.Version != 3 {
+		t.Errorf("expected rollback to create version 3, got %d", eg.Version)
+	}
+
+	_, variables, secretVariables, err := envgroup.GetEnvGroupVariables(agent, "test", "default", 3)
+
+	if err != nil {
+		t.Fatalf("error getting variables: %v", err)
+	}
+
+	if len(variables) != 1 || variables["A"] != "1" {
+		t.Errorf("expected variables of version 1, got %v", variables)
+	}
+
+	if len(secretVariables) != 1 || secretVariables["S"] != "s1" {
+		t.Errorf("expected secret variables of version 1, got %v", secretVariables)
+	}
+
+	diff, err := envgroup.DiffEnvGroupVersions(agent, "test", "default", 1, 3)
+
+	if err != nil {
+		t.Fatalf("error diffing versions: %v", err)
+	}
+
+	if len(diff.Added)+len(diff.Removed)+len(diff.Changed) != 0 {
+		t.Errorf("expected no changes between version 1 and the rollback, got %+v", diff)
+	}
+}
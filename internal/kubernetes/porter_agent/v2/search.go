@@ -0,0 +1,191 @@
+package v2
+
+import (
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type SearchLogsOpts struct {
+	ReleaseName string
+	Namespace   string
+	Search      string
+
+	// Since and Until are unix timestamps in seconds, 0 disables the bound
+	Since int64
+	Until int64
+
+	// After continues the search after the lines of a previous page, and Limit is the
+	// maximum number of lines returned, 0 disables the limit
+	After *SearchLogsCursor
+	Limit int
+}
+
+// SearchLogsCursor points to the last line of a page: the timestamp and ID of the log
+// that the line belongs to, and the number of matching lines of that log that were
+// returned
+type SearchLogsCursor struct {
+	Timestamp int64
+	LogID     string
+	Line      int
+}
+
+type SearchLogLine struct {
+	Timestamp     int64
+	PodName       string
+	ContainerName string
+	Line          string
+}
+
+type searchLogEntry struct {
+	timestamp     int64
+	logID         string
+	podName       string
+	containerName string
+}
+
+// SearchLogs searches the container logs that the agent captured for the incidents of a
+// release. Since these are stored by the agent, this includes the logs of pods that have
+// crashed or been deleted. Lines are returned in order of the events they were captured for.
+//
+// Logs are only fetched from the cursor onwards, until the limit is reached. The returned
+// cursor is nil if there are no more logs to search.
+func SearchLogs(
+	clientset kubernetes.Interface,
+	service *v1.Service,
+	opts *SearchLogsOpts,
+) ([]*SearchLogLine, *SearchLogsCursor, error) {
+	entries, err := getSearchLogEntries(clientset, service, opts)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	search := strings.ToLower(opts.Search)
+	res := make([]*SearchLogLine, 0)
+
+	for i, entry := range entries {
+		skipLines := 0
+
+		if opts.After != nil && entry.timestamp == opts.After.Timestamp && entry.logID == opts.After.LogID {
+			skipLines = opts.After.Line
+		}
+
+		logs, err := GetLogs(clientset, service, entry.logID)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		matched := 0
+
+		for _, line := range strings.Split(logs.Contents, "\n") {
+			if line == "" || !strings.Contains(strings.ToLower(line), search) {
+				continue
+			}
+
+			matched++
+
+			if matched <= skipLines {
+				continue
+			}
+
+			if opts.Limit > 0 && len(res) == opts.Limit {
+				// the log has more matching lines than fit in the page
+				return res, &SearchLogsCursor{entry.timestamp, entry.logID, matched - 1}, nil
+			}
+
+			res = append(res, &SearchLogLine{
+				Timestamp:     entry.timestamp,
+				PodName:       entry.podName,
+				ContainerName: entry.containerName,
+				Line:          line,
+			})
+		}
+
+		if opts.Limit > 0 && len(res) == opts.Limit && i < len(entries)-1 {
+			return res, &SearchLogsCursor{entry.timestamp, entry.logID, matched}, nil
+		}
+	}
+
+	return res, nil, nil
+}
+
+// getSearchLogEntries lists the logs captured in the requested window from the cursor
+// onwards, sorted by timestamp and log ID. Only the incident events are read here, the
+// contents of the logs are fetched by the caller.
+func getSearchLogEntries(
+	clientset kubernetes.Interface,
+	service *v1.Service,
+	opts *SearchLogsOpts,
+) ([]*searchLogEntry, error) {
+	incidents, err := GetIncidentsByReleaseNamespace(clientset, service, opts.ReleaseName, opts.Namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entriesByLogID := make(map[string]*searchLogEntry)
+
+	for _, incident := range incidents.Incidents {
+		// skip incidents that ended before or started after the requested window
+		if (opts.Since != 0 && incident.UpdatedAt < opts.Since) || (opts.Until != 0 && incident.CreatedAt > opts.Until) {
+			continue
+		}
+
+		events, err := GetIncidentEventsByID(clientset, service, incident.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events.Events {
+			if (opts.Since != 0 && event.Timestamp < opts.Since) || (opts.Until != 0 && event.Timestamp > opts.Until) {
+				continue
+			}
+
+			for _, containerEvent := range event.ContainerEvents {
+				if containerEvent.LogID == "" {
+					continue
+				}
+
+				// a log is listed under the earliest event it was captured for
+				if entry, ok := entriesByLogID[containerEvent.LogID]; ok && entry.timestamp <= event.Timestamp {
+					continue
+				}
+
+				entriesByLogID[containerEvent.LogID] = &searchLogEntry{
+					timestamp:     event.Timestamp,
+					logID:         containerEvent.LogID,
+					podName:       event.PodName,
+					containerName: containerEvent.Name,
+				}
+			}
+		}
+	}
+
+	res := make([]*searchLogEntry, 0)
+
+	// logs before the cursor have already been returned. The cursor is only applied once
+	// the earliest event of each log is known, so that no log is listed twice.
+	for _, entry := range entriesByLogID {
+		if opts.After != nil && (entry.timestamp < opts.After.Timestamp ||
+			(entry.timestamp == opts.After.Timestamp && entry.logID < opts.After.LogID)) {
+			continue
+		}
+
+		res = append(res, entry)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].timestamp != res[j].timestamp {
+			return res[i].timestamp < res[j].timestamp
+		}
+
+		return res[i].logID < res[j].logID
+	})
+
+	return res, nil
+}
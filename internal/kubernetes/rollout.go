@@ -0,0 +1,199 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// failedWaitingReasons are container waiting reasons which will not resolve
+// without a change to the controller, so a rollout can be failed immediately
+var failedWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// rolloutFailedError is returned when a pod of a rollout is in a state that will not resolve
+// on its own, as opposed to errors reading the controller or its pods, which may be transient
+type rolloutFailedError struct {
+	msg string
+}
+
+func (e *rolloutFailedError) Error() string {
+	return e.msg
+}
+
+// WaitForControllersReady polls the deployments, statefulsets and daemonsets in the
+// list of controllers until all of them have rolled out and all of their replicas are
+// ready. It returns an error if the context is done first, or as soon as a pod created
+// after the since time is crash looping or cannot pull its image. Errors reading the
+// controllers are retried until the context is done. Other controller kinds, such as jobs
+// and cronjobs, are not checked.
+func (a *Agent) WaitForControllersReady(
+	ctx context.Context,
+	controllers []grapher.Object,
+	since time.Time,
+	pollInterval time.Duration,
+) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		var notReady []string
+		var lastErr error
+
+		for _, controller := range controllers {
+			ready, err := a.isControllerReady(controller, since)
+
+			var failedErr *rolloutFailedError
+
+			if errors.As(err, &failedErr) {
+				return err
+			} else if err != nil {
+				lastErr = err
+			}
+
+			if !ready {
+				notReady = append(notReady, fmt.Sprintf("%s/%s", strings.ToLower(controller.Kind), controller.Name))
+			}
+		}
+
+		if len(notReady) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("timed out waiting for %s to become ready: %w", strings.Join(notReady, ", "), lastErr)
+			}
+
+			return fmt.Errorf("timed out waiting for %s to become ready", strings.Join(notReady, ", "))
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Agent) isControllerReady(controller grapher.Object, since time.Time) (bool, error) {
+	switch strings.ToLower(controller.Kind) {
+	case "deployment":
+		obj, err := a.GetDeployment(controller)
+
+		if err != nil {
+			return false, err
+		}
+
+		if err := a.checkControllerPods(obj.Namespace, obj.Spec.Selector, since); err != nil {
+			return false, err
+		}
+
+		return isDeploymentReady(obj), nil
+	case "statefulset":
+		obj, err := a.GetStatefulSet(controller)
+
+		if err != nil {
+			return false, err
+		}
+
+		if err := a.checkControllerPods(obj.Namespace, obj.Spec.Selector, since); err != nil {
+			return false, err
+		}
+
+		return isStatefulSetReady(obj), nil
+	case "daemonset":
+		obj, err := a.GetDaemonSet(controller)
+
+		if err != nil {
+			return false, err
+		}
+
+		if err := a.checkControllerPods(obj.Namespace, obj.Spec.Selector, since); err != nil {
+			return false, err
+		}
+
+		return isDaemonSetReady(obj), nil
+	}
+
+	return true, nil
+}
+
+// checkControllerPods returns an error if a pod matching the selector which was created
+// after the since time has a container that is stuck in a failed waiting state. Older pods
+// belong to previous revisions, and are not considered.
+func (a *Agent) checkControllerPods(namespace string, selector *metav1.LabelSelector, since time.Time) error {
+	if selector == nil {
+		return nil
+	}
+
+	pods, err := a.GetPodsByLabel(metav1.FormatLabelSelector(selector), namespace)
+
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.CreationTimestamp.Time.Before(since) {
+			continue
+		}
+
+		statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			if status.State.Waiting != nil && failedWaitingReasons[status.State.Waiting.Reason] {
+				return &rolloutFailedError{fmt.Sprintf(
+					"container %s in pod %s is in state %s: %s",
+					status.Name,
+					pod.Name,
+					status.State.Waiting.Reason,
+					status.State.Waiting.Message,
+				)}
+			}
+		}
+	}
+
+	return nil
+}
+
+func isDeploymentReady(obj *appsv1.Deployment) bool {
+	replicas := int32(1)
+
+	if obj.Spec.Replicas != nil {
+		replicas = *obj.Spec.Replicas
+	}
+
+	return obj.Status.ObservedGeneration >= obj.Generation &&
+		obj.Status.UpdatedReplicas == replicas &&
+		obj.Status.Replicas == replicas &&
+		obj.Status.AvailableReplicas == replicas
+}
+
+func isStatefulSetReady(obj *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+
+	if obj.Spec.Replicas != nil {
+		replicas = *obj.Spec.Replicas
+	}
+
+	return obj.Status.ObservedGeneration >= obj.Generation &&
+		obj.Status.UpdatedReplicas == replicas &&
+		obj.Status.ReadyReplicas == replicas &&
+		obj.Status.CurrentRevision == obj.Status.UpdateRevision
+}
+
+func isDaemonSetReady(obj *appsv1.DaemonSet) bool {
+	return obj.Status.ObservedGeneration >= obj.Generation &&
+		obj.Status.UpdatedNumberScheduled == obj.Status.DesiredNumberScheduled &&
+		obj.Status.NumberAvailable == obj.Status.DesiredNumberScheduled
+}
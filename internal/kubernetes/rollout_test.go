@@ -0,0 +1,128 @@
+package kubernetes_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newDeploymentFixture(replicas, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "web",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  available,
+		},
+	}
+}
+
+func newPodFixture(name string, created time.Time, waitingReason string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{"app": "web"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{
+			{
+				Name: "web",
+				State: v1.ContainerState{
+					Waiting: &v1.ContainerStateWaiting{Reason: waitingReason},
+				},
+			},
+		}
+	}
+
+	return pod
+}
+
+func TestWaitForControllersReady(t *testing.T) {
+	since := time.Now()
+	controllers := []grapher.Object{{Kind: "Deployment", Name: "web", Namespace: "default"}}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		expErr      bool
+		expErrMatch string
+	}{
+		{
+			name:    "all replicas available",
+			objects: []runtime.Object{newDeploymentFixture(2, 2)},
+		},
+		{
+			name: "replicas not available before timeout",
+			objects: []runtime.Object{
+				newDeploymentFixture(2, 1),
+			},
+			expErr:      true,
+			expErrMatch: "timed out waiting for deployment/web",
+		},
+		{
+			name:        "deployment not created yet",
+			objects:     []runtime.Object{},
+			expErr:      true,
+			expErrMatch: "timed out waiting for deployment/web to become ready: ",
+		},
+		{
+			name: "new pod crash looping",
+			objects: []runtime.Object{
+				newDeploymentFixture(2, 1),
+				newPodFixture("web-new", since.Add(time.Minute), "CrashLoopBackOff"),
+			},
+			expErr:      true,
+			expErrMatch: "CrashLoopBackOff",
+		},
+		{
+			name: "pod of previous revision crash looping",
+			objects: []runtime.Object{
+				newDeploymentFixture(2, 2),
+				newPodFixture("web-old", since.Add(-time.Hour), "CrashLoopBackOff"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			agent := newAgentFixture(t, tc.objects...)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err := agent.WaitForControllersReady(ctx, controllers, since, 10*time.Millisecond)
+
+			if tc.expErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			} else if !tc.expErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if tc.expErr && !strings.Contains(err.Error(), tc.expErrMatch) {
+				t.Errorf("expected error to contain %q, got %q", tc.expErrMatch, err.Error())
+			}
+		})
+	}
+}
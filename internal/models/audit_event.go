@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AuditEvent is a record of a mutating API call made within a project
+type AuditEvent struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	// The actor of the request. API tokens are not backed by a user, so only one of
+	// UserID and APITokenUID is set.
+	UserID      uint
+	APITokenUID string
+
+	// The scopes resolved by the authorization middleware. The cluster, namespace and
+	// release name are stored in their own columns for filtering, while ScopesBytes
+	// stores the JSON-encoded map of every resolved scope.
+	ClusterID   uint
+	Namespace   string
+	ReleaseName string
+	ScopesBytes []byte
+
+	Verb       types.APIVerb
+	Method     types.HTTPVerb
+	Endpoint   string
+	Path       string
+	StatusCode int
+	Outcome    types.AuditEventOutcome
+}
+
+func (a *AuditEvent) ToAuditEventType() *types.AuditEvent {
+	scopes := make(map[string]string)
+
+	if len(a.ScopesBytes) > 0 {
+		// the scopes are written by the audit middleware, so a failure to unmarshal
+		// just results in an empty map
+		json.Unmarshal(a.ScopesBytes, &scopes)
+	}
+
+	return &types.AuditEvent{
+		ID:          a.ID,
+		CreatedAt:   a.CreatedAt,
+		ProjectID:   a.ProjectID,
+		UserID:      a.UserID,
+		APITokenUID: a.APITokenUID,
+		ClusterID:   a.ClusterID,
+		Namespace:   a.Namespace,
+		ReleaseName: a.ReleaseName,
+		Scopes:      scopes,
+		Verb:        a.Verb,
+		Method:      a.Method,
+		Endpoint:    a.Endpoint,
+		Path:        a.Path,
+		StatusCode:  a.StatusCode,
+		Outcome:     a.Outcome,
+	}
+}
@@ -55,6 +55,10 @@ type Cluster struct {
 
 	NotificationsDisabled bool `json:"notifications_disabled"`
 
+	// EnvGroupVersionRetention overrides the number of env group versions kept by the project,
+	// where 0 uses the retention of the project
+	EnvGroupVersionRetention uint
+
 	// ------------------------------------------------------------------
 	// All fields below this line are encrypted before storage
 	// ------------------------------------------------------------------
@@ -97,7 +101,19 @@ func (c *Cluster) ToClusterType() *types.Cluster {
 		Service:          serv,
 		InfraID:          c.InfraID,
 		AWSIntegrationID: c.AWSIntegrationID,
+
+		EnvGroupVersionRetention: c.EnvGroupVersionRetention,
+	}
+}
+
+// GetEnvGroupVersionRetention returns the number of env group versions kept in the cluster,
+// which is the retention of the cluster if set and the retention of the project otherwise
+func (c *Cluster) GetEnvGroupVersionRetention(project *Project) uint {
+	if c.EnvGroupVersionRetention != 0 || project == nil {
+		return c.EnvGroupVersionRetention
 	}
+
+	return project.EnvGroupVersionRetention
 }
 
 // ClusterCandidate is a cluster integration that requires additional action
@@ -18,6 +18,10 @@ type DNSRecord struct {
 	Hostname string `json:"hostname"`
 
 	ClusterID uint `json:"cluster_id"`
+
+	// The release that the subdomain was created for
+	Namespace   string `json:"namespace"`
+	ReleaseName string `json:"release_name"`
 }
 
 func (p *DNSRecord) ToDNSRecordType() *types.DNSRecord {
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSource references a secret in an external secret manager that is synced
+// into a secret variable of an env group
+type EnvGroupSource struct {
+	gorm.Model
+
+	ProjectID    uint `gorm:"index"`
+	ClusterID    uint
+	Namespace    string
+	EnvGroupName string
+
+	Key           string
+	Kind          types.EnvGroupSourceKind
+	IntegrationID uint
+	Path          string
+	Field         string
+
+	// The external version that was last synced, and the error of the last sync if
+	// it failed
+	ExternalVersion string
+	LastSyncedAt    *time.Time
+	LastSyncError   string
+}
+
+func (e *EnvGroupSource) ToEnvGroupSourceType() *types.EnvGroupSource {
+	return &types.EnvGroupSource{
+		EnvGroupSourceInput: &types.EnvGroupSourceInput{
+			Key:           e.Key,
+			Kind:          e.Kind,
+			IntegrationID: e.IntegrationID,
+			Path:          e.Path,
+			Field:         e.Field,
+		},
+		ID:              e.ID,
+		ExternalVersion: e.ExternalVersion,
+		LastSyncedAt:    e.LastSyncedAt,
+		LastSyncError:   e.LastSyncError,
+	}
+}
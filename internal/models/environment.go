@@ -1,6 +1,11 @@
 package models
 
 import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -16,25 +21,178 @@ type Environment struct {
 	GitRepoOwner      string
 	GitRepoName       string
 
+	// GitlabIntegrationID is set for environments backed by a GitLab repository instead
+	// of a GitHub app installation. The GitLab API is called on behalf of UserID.
+	GitlabIntegrationID uint
+	UserID              uint
+
 	Name string
 	Mode string
 
+	// TTLHours is the maximum lifetime of a preview deployment in hours, and IdleTTLHours
+	// is how long a preview deployment can go without a new push. 0 disables the limit.
+	TTLHours     uint
+	IdleTTLHours uint
+
+	// TriggerLabels, TriggerPaths and TriggerBaseBranches are comma-separated rules that
+	// restrict which pull requests are deployed automatically. A pull request has to match
+	// at least one entry of every rule that is set.
+	TriggerLabels       string
+	TriggerPaths        string
+	TriggerBaseBranches string
+
 	// WebhookID uniquely identifies the environment when other fields (project, cluster)
 	// aren't present
 	WebhookID string `gorm:"unique"`
 }
 
+// IsGitlab returns true if the environment is backed by a GitLab repository
+func (e *Environment) IsGitlab() bool {
+	return e.GitlabIntegrationID != 0
+}
+
+// HasTriggerRules returns true if only some pull requests are deployed automatically
+func (e *Environment) HasTriggerRules() bool {
+	return e.TriggerLabels != "" || e.TriggerPaths != "" || e.TriggerBaseBranches != ""
+}
+
+// HasPathTrigger returns true if pull requests must change files matching TriggerPaths
+// to be deployed, in which case the changed files have to be passed to ShouldTrigger
+func (e *Environment) HasPathTrigger() bool {
+	return e.TriggerPaths != ""
+}
+
+// ShouldTrigger returns true if a pull request with the given labels, base branch and
+// changed files matches the trigger rules of the environment
+func (e *Environment) ShouldTrigger(labels []string, baseBranch string, changedFiles []string) bool {
+	if triggerLabels := splitTriggerRule(e.TriggerLabels); len(triggerLabels) > 0 {
+		if !matchesAny(triggerLabels, labels, func(rule, label string) bool {
+			return rule == label
+		}) {
+			return false
+		}
+	}
+
+	if triggerBranches := splitTriggerRule(e.TriggerBaseBranches); len(triggerBranches) > 0 {
+		if !matchesAny(triggerBranches, []string{baseBranch}, func(rule, branch string) bool {
+			matched, err := path.Match(rule, branch)
+
+			return err == nil && matched
+		}) {
+			return false
+		}
+	}
+
+	if triggerPaths := splitTriggerRule(e.TriggerPaths); len(triggerPaths) > 0 {
+		if !matchesAny(triggerPaths, changedFiles, matchPathGlob) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func splitTriggerRule(rule string) []string {
+	res := make([]string, 0)
+
+	for _, entry := range strings.Split(rule, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			res = append(res, entry)
+		}
+	}
+
+	return res
+}
+
+func joinTriggerRule(entries []string) string {
+	return strings.Join(splitTriggerRule(strings.Join(entries, ",")), ",")
+}
+
+func matchesAny(rules, values []string, match func(rule, value string) bool) bool {
+	for _, rule := range rules {
+		for _, value := range values {
+			if match(rule, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchPathGlob matches a file path against a glob pattern, where "**" matches any
+// number of directories and every other segment is matched with path.Match
+func matchPathGlob(pattern, name string) bool {
+	return matchPathSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchPathSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchPathSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+
+	if matched, err := path.Match(pattern[0], name[0]); err != nil || !matched {
+		return false
+	}
+
+	return matchPathSegments(pattern[1:], name[1:])
+}
+
+// SetTriggerRules stores the trigger rules of the environment. Nil rules are left unchanged.
+func (e *Environment) SetTriggerRules(labels, paths, baseBranches []string) {
+	if labels != nil {
+		e.TriggerLabels = joinTriggerRule(labels)
+	}
+
+	if paths != nil {
+		e.TriggerPaths = joinTriggerRule(paths)
+	}
+
+	if baseBranches != nil {
+		e.TriggerBaseBranches = joinTriggerRule(baseBranches)
+	}
+}
+
+// GetPreviewNamespace returns the namespace that the preview deployment of a pull request
+// is deployed to
+func (e *Environment) GetPreviewNamespace(prNumber uint) string {
+	return fmt.Sprintf("pr-%d-%s", prNumber, strings.ToLower(strings.ReplaceAll(e.GitRepoName, "_", "-")))
+}
+
 func (e *Environment) ToEnvironmentType() *types.Environment {
 	return &types.Environment{
-		ID:                e.Model.ID,
-		ProjectID:         e.ProjectID,
-		ClusterID:         e.ClusterID,
-		GitInstallationID: e.GitInstallationID,
-		GitRepoOwner:      e.GitRepoOwner,
-		GitRepoName:       e.GitRepoName,
+		ID:                  e.Model.ID,
+		ProjectID:           e.ProjectID,
+		ClusterID:           e.ClusterID,
+		GitInstallationID:   e.GitInstallationID,
+		GitlabIntegrationID: e.GitlabIntegrationID,
+		GitRepoOwner:        e.GitRepoOwner,
+		GitRepoName:         e.GitRepoName,
 
-		Name: e.Name,
-		Mode: e.Mode,
+		Name:         e.Name,
+		Mode:         e.Mode,
+		TTLHours:     e.TTLHours,
+		IdleTTLHours: e.IdleTTLHours,
+
+		PreviewTriggerRules: types.PreviewTriggerRules{
+			TriggerLabels:       splitTriggerRule(e.TriggerLabels),
+			TriggerPaths:        splitTriggerRule(e.TriggerPaths),
+			TriggerBaseBranches: splitTriggerRule(e.TriggerBaseBranches),
+		},
 	}
 }
 
@@ -53,6 +211,55 @@ type Deployment struct {
 	CommitSHA      string
 	PRBranchFrom   string
 	PRBranchInto   string
+
+	// GHPRCommentID is the ID of the pull request comment that Porter keeps updated
+	// with the status of the deployment
+	GHPRCommentID int64
+
+	// ActiveSince is the time the deployment was last created or re-enabled, and
+	// LastActivityAt is the time of the last push to the pull request
+	ActiveSince    *time.Time
+	LastActivityAt *time.Time
+
+	// WarnedExpiry is the expiry time that the pull request was last warned about, so
+	// that the warning is only posted again if the expiry changes
+	WarnedExpiry *time.Time
+
+	// ExpiredAt is set when the deployment was deleted for exceeding the TTL or idle
+	// limits of its environment, in which case the next push rebuilds it
+	ExpiredAt *time.Time
+}
+
+// ExpiresAt returns the time at which the deployment exceeds the TTL or idle limits of
+// the environment. The boolean is false if the environment sets neither limit.
+func (d *Deployment) ExpiresAt(env *Environment) (time.Time, bool) {
+	activeSince := d.CreatedAt
+
+	if d.ActiveSince != nil {
+		activeSince = *d.ActiveSince
+	}
+
+	lastActivity := activeSince
+
+	if d.LastActivityAt != nil && d.LastActivityAt.After(lastActivity) {
+		lastActivity = *d.LastActivityAt
+	}
+
+	var expiresAt time.Time
+
+	if env.TTLHours > 0 {
+		expiresAt = activeSince.Add(time.Duration(env.TTLHours) * time.Hour)
+	}
+
+	if env.IdleTTLHours > 0 {
+		idleExpiresAt := lastActivity.Add(time.Duration(env.IdleTTLHours) * time.Hour)
+
+		if expiresAt.IsZero() || idleExpiresAt.Before(expiresAt) {
+			expiresAt = idleExpiresAt
+		}
+	}
+
+	return expiresAt, !expiresAt.IsZero()
 }
 
 func (d *Deployment) ToDeploymentType() *types.Deployment {
@@ -77,5 +284,6 @@ func (d *Deployment) ToDeploymentType() *types.Deployment {
 		Subdomain:      d.Subdomain,
 		PullRequestID:  d.PullRequestID,
 		GitHubMetadata: ghMetadata,
+		ExpiredAt:      d.ExpiredAt,
 	}
 }
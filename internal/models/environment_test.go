@@ -0,0 +1,176 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestDeploymentExpiresAt(t *testing.T) {
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	reenabled := created.Add(48 * time.Hour)
+	pushed := created.Add(10 * time.Hour)
+
+	tests := []struct {
+		name     string
+		env      *models.Environment
+		depl     *models.Deployment
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name: "no limits",
+			env:  &models.Environment{},
+			depl: &models.Deployment{Model: gorm.Model{CreatedAt: created}},
+		},
+		{
+			name:     "ttl from creation",
+			env:      &models.Environment{TTLHours: 24},
+			depl:     &models.Deployment{Model: gorm.Model{CreatedAt: created}},
+			expected: created.Add(24 * time.Hour),
+			ok:       true,
+		},
+		{
+			name: "ttl from re-enable",
+			env:  &models.Environment{TTLHours: 24},
+			depl: &models.Deployment{
+				Model:       gorm.Model{CreatedAt: created},
+				ActiveSince: &reenabled,
+			},
+			expected: reenabled.Add(24 * time.Hour),
+			ok:       true,
+		},
+		{
+			name: "idle from last push",
+			env:  &models.Environment{IdleTTLHours: 4},
+			depl: &models.Deployment{
+				Model:          gorm.Model{CreatedAt: created},
+				LastActivityAt: &pushed,
+			},
+			expected: pushed.Add(4 * time.Hour),
+			ok:       true,
+		},
+		{
+			name: "earliest of ttl and idle",
+			env:  &models.Environment{TTLHours: 12, IdleTTLHours: 4},
+			depl: &models.Deployment{
+				Model:          gorm.Model{CreatedAt: created},
+				LastActivityAt: &pushed,
+			},
+			expected: created.Add(12 * time.Hour),
+			ok:       true,
+		},
+	}
+
+	for _, test := range tests {
+		expiresAt, ok := test.depl.ExpiresAt(test.env)
+
+		if ok != test.ok {
+			t.Errorf("%s: expected ok to be %t, got %t", test.name, test.ok, ok)
+		}
+
+		if !expiresAt.Equal(test.expected) {
+			t.Errorf("%s: expected expiry %s, got %s", test.name, test.expected, expiresAt)
+		}
+	}
+}
+
+func TestEnvironmentShouldTrigger(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          *models.Environment
+		labels       []string
+		baseBranch   string
+		changedFiles []string
+		expected     bool
+	}{
+		{
+			name:       "no rules",
+			env:        &models.Environment{},
+			baseBranch: "main",
+			expected:   true,
+		},
+		{
+			name:       "label present",
+			env:        &models.Environment{TriggerLabels: "preview,deploy"},
+			labels:     []string{"bug", "deploy"},
+			baseBranch: "main",
+			expected:   true,
+		},
+		{
+			name:       "label missing",
+			env:        &models.Environment{TriggerLabels: "preview"},
+			labels:     []string{"bug"},
+			baseBranch: "main",
+		},
+		{
+			name:       "base branch glob",
+			env:        &models.Environment{TriggerBaseBranches: "main,release/*"},
+			baseBranch: "release/1.2",
+			expected:   true,
+		},
+		{
+			name:       "base branch mismatch",
+			env:        &models.Environment{TriggerBaseBranches: "main"},
+			baseBranch: "develop",
+		},
+		{
+			name:         "path with double star",
+			env:          &models.Environment{TriggerPaths: "services/api/**"},
+			baseBranch:   "main",
+			changedFiles: []string{"README.md", "services/api/cmd/main.go"},
+			expected:     true,
+		},
+		{
+			name:         "path extension in any directory",
+			env:          &models.Environment{TriggerPaths: "**/*.go"},
+			baseBranch:   "main",
+			changedFiles: []string{"main.go"},
+			expected:     true,
+		},
+		{
+			name:         "path mismatch",
+			env:          &models.Environment{TriggerPaths: "services/api/**"},
+			baseBranch:   "main",
+			changedFiles: []string{"services/web/index.js"},
+		},
+		{
+			name: "all rules must match",
+			env: &models.Environment{
+				TriggerLabels: "preview",
+				TriggerPaths:  "services/api/**",
+			},
+			labels:       []string{"preview"},
+			baseBranch:   "main",
+			changedFiles: []string{"docs/index.md"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := test.env.ShouldTrigger(test.labels, test.baseBranch, test.changedFiles); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEnvironmentSetTriggerRules(t *testing.T) {
+	env := &models.Environment{TriggerLabels: "preview", TriggerPaths: "api/**"}
+
+	env.SetTriggerRules(nil, []string{}, []string{" main ", "", "release/*"})
+
+	if env.TriggerLabels != "preview" {
+		t.Errorf("expected labels to be unchanged, got %q", env.TriggerLabels)
+	}
+
+	if env.TriggerPaths != "" {
+		t.Errorf("expected paths to be cleared, got %q", env.TriggerPaths)
+	}
+
+	if env.TriggerBaseBranches != "main,release/*" {
+		t.Errorf("expected base branches main,release/*, got %q", env.TriggerBaseBranches)
+	}
+}
@@ -22,6 +22,10 @@ type HelmRepo struct {
 	// GCS it may be gs://
 	RepoURL string `json:"repo_url"`
 
+	// RegistryID is the id of the registry integration used to authenticate with an
+	// OCI helm repo, i.e. a RepoURL prefixed with oci://
+	RegistryID uint `json:"registry_id"`
+
 	// ------------------------------------------------------------------
 	// All fields below this line are encrypted before storage
 	// ------------------------------------------------------------------
@@ -49,9 +53,10 @@ type HelmRepoExternal struct {
 // ToHelmRepoType generates an external HelmRepo to be shared over REST
 func (hr *HelmRepo) ToHelmRepoType() *types.HelmRepo {
 	return &types.HelmRepo{
-		ID:        hr.ID,
-		ProjectID: hr.ProjectID,
-		Name:      hr.Name,
-		RepoURL:   hr.RepoURL,
+		ID:         hr.ID,
+		ProjectID:  hr.ProjectID,
+		Name:       hr.Name,
+		RepoURL:    hr.RepoURL,
+		RegistryID: hr.RegistryID,
 	}
 }
@@ -0,0 +1,37 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// TeamsIntegration is an incoming webhook notifier to a channel in Microsoft Teams.
+type TeamsIntegration struct {
+	gorm.Model
+
+	// The id of the user that created this integration
+	UserID uint `json:"user_id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// A human-readable name for the integration, such as the channel name
+	Name string `json:"name"`
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The incoming webhook to call
+	Webhook []byte
+}
+
+func (i *TeamsIntegration) ToTeamsIntegrationType() *types.TeamsIntegration {
+	return &types.TeamsIntegration{
+		ID:        i.ID,
+		CreatedAt: i.CreatedAt,
+		ProjectID: i.ProjectID,
+		Name:      i.Name,
+	}
+}
@@ -0,0 +1,43 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// VaultIntegration is a HashiCorp Vault server and token that env group sources read
+// KV secrets from
+type VaultIntegration struct {
+	gorm.Model
+
+	// The id of the user that created this integration
+	UserID uint `json:"user_id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// A human-readable name for the integration
+	Name string `json:"name"`
+
+	// The address of the Vault server, like https://vault.example.com:8200. Secrets are
+	// only ever read from this address.
+	Address string `json:"address"`
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The token used to read secrets
+	Token []byte
+}
+
+func (v *VaultIntegration) ToVaultIntegrationType() *types.VaultIntegration {
+	return &types.VaultIntegration{
+		ID:        v.ID,
+		CreatedAt: v.CreatedAt,
+		ProjectID: v.ProjectID,
+		Name:      v.Name,
+		Address:   v.Address,
+	}
+}
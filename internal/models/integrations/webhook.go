@@ -0,0 +1,51 @@
+package integrations
+
+import (
+	"net/url"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// WebhookIntegration is a notification channel which sends a JSON payload to an
+// arbitrary HTTP endpoint for deployment and incident notifications.
+type WebhookIntegration struct {
+	gorm.Model
+
+	// The id of the user that created this integration
+	UserID uint `json:"user_id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// A human-readable name for the integration
+	Name string `json:"name"`
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The URL to send notifications to
+	URL []byte
+
+	// The secret used to sign notifications, if signing is enabled
+	SigningSecret []byte
+}
+
+func (w *WebhookIntegration) ToWebhookIntegrationType() *types.WebhookIntegration {
+	res := &types.WebhookIntegration{
+		ID:        w.ID,
+		CreatedAt: w.CreatedAt,
+		ProjectID: w.ProjectID,
+		Name:      w.Name,
+		Signed:    len(w.SigningSecret) > 0,
+	}
+
+	// only the host is returned, since the rest of the URL may contain credentials
+	if parsed, err := url.Parse(string(w.URL)); err == nil {
+		res.Host = parsed.Host
+	}
+
+	return res
+}
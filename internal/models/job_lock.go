@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// JobLock is a lease on a background job, so that only one of the Porter servers that
+// share a database runs the job at a time
+type JobLock struct {
+	Name string `gorm:"primaryKey"`
+
+	// Holder identifies the server that holds the lease until ExpiresAt
+	Holder    string
+	ExpiresAt time.Time
+}
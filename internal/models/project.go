@@ -62,6 +62,14 @@ type Project struct {
 	RDSDatabasesEnabled bool
 	ManagedInfraEnabled bool
 	APITokensEnabled    bool
+
+	// MFARequired denies access to the project to users who have not enabled multi-factor
+	// authentication
+	MFARequired bool
+
+	// EnvGroupVersionRetention is the number of versions of each env group kept in the clusters
+	// of the project, where 0 keeps every version
+	EnvGroupVersionRetention uint
 }
 
 // ToProjectType generates an external types.Project to be shared over REST
@@ -80,5 +88,8 @@ func (p *Project) ToProjectType() *types.Project {
 		RDSDatabasesEnabled: p.RDSDatabasesEnabled,
 		ManagedInfraEnabled: p.ManagedInfraEnabled,
 		APITokensEnabled:    p.APITokensEnabled,
+		MFARequired:         p.MFARequired,
+
+		EnvGroupVersionRetention: p.EnvGroupVersionRetention,
 	}
 }
@@ -25,6 +25,12 @@ type Registry struct {
 	// The infra id, if registry was provisioned with Porter
 	InfraID uint `json:"infra_id"`
 
+	// Image retention rules, which are disabled if both ImageRetentionKeepLast and
+	// ImageRetentionMaxAgeDays are 0
+	ImageRetentionKeepLast     uint
+	ImageRetentionMaxAgeDays   uint
+	ImageRetentionKeepDeployed bool
+
 	// ------------------------------------------------------------------
 	// All fields below this line are encrypted before storage
 	// ------------------------------------------------------------------
@@ -61,7 +67,7 @@ func (r *Registry) ToRegistryType() *types.Registry {
 		uri = splStr[1]
 	}
 
-	return &types.Registry{
+	res := &types.Registry{
 		ID:                 r.ID,
 		ProjectID:          r.ProjectID,
 		Name:               r.Name,
@@ -74,4 +80,24 @@ func (r *Registry) ToRegistryType() *types.Registry {
 		DOIntegrationID:    r.DOIntegrationID,
 		BasicIntegrationID: r.BasicIntegrationID,
 	}
+
+	if r.ImageRetentionEnabled() {
+		res.ImageRetention = r.GetImageRetentionRules()
+	}
+
+	return res
+}
+
+// ImageRetentionEnabled returns true if the registry deletes old images
+func (r *Registry) ImageRetentionEnabled() bool {
+	return r.ImageRetentionKeepLast != 0 || r.ImageRetentionMaxAgeDays != 0
+}
+
+// GetImageRetentionRules returns the image retention rules of the registry
+func (r *Registry) GetImageRetentionRules() *types.ImageRetentionRules {
+	return &types.ImageRetentionRules{
+		KeepLast:     r.ImageRetentionKeepLast,
+		MaxAgeDays:   r.ImageRetentionMaxAgeDays,
+		KeepDeployed: r.ImageRetentionKeepDeployed,
+	}
 }
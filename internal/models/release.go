@@ -26,6 +26,21 @@ type Release struct {
 	NotificationConfig uint
 	BuildConfig        uint
 	Tags               []*Tag `json:"tags" gorm:"many2many:release_tags"`
+
+	// AutoRollbackEnabled rolls the release back to the previous revision if the
+	// controllers of an upgraded revision are not ready after AutoRollbackTimeout seconds
+	AutoRollbackEnabled bool
+	AutoRollbackTimeout uint
+
+	// WebhookSigningSecret is used to verify the signature of deploy webhook requests.
+	// If WebhookSignatureRequired is set, unsigned requests are rejected.
+	WebhookSigningSecret     string
+	WebhookSignatureRequired bool
+
+	// the timestamp and value of the last accepted signature, used to reject replayed
+	// webhook requests
+	WebhookLastSignedAt  int64
+	WebhookLastSignature string
 }
 
 func (r *Release) ToReleaseType() *types.PorterRelease {
@@ -33,12 +48,18 @@ func (r *Release) ToReleaseType() *types.PorterRelease {
 		ID:           r.ID,
 		WebhookToken: r.WebhookToken,
 		ImageRepoURI: r.ImageRepoURI,
+
+		WebhookSignatureRequired: r.WebhookSignatureRequired,
 	}
 
 	if r.GitActionConfig != nil {
 		res.GitActionConfig = r.GitActionConfig.ToGitActionConfigType()
 	}
 
+	if r.AutoRollbackEnabled {
+		res.AutoRollback = r.ToAutoRollbackType()
+	}
+
 	tagsCount := len(r.Tags)
 
 	if tagsCount > 0 {
@@ -49,3 +70,16 @@ func (r *Release) ToReleaseType() *types.PorterRelease {
 
 	return res
 }
+
+func (r *Release) ToAutoRollbackType() *types.AutoRollback {
+	res := &types.AutoRollback{
+		Enabled:        r.AutoRollbackEnabled,
+		TimeoutSeconds: r.AutoRollbackTimeout,
+	}
+
+	if res.TimeoutSeconds == 0 {
+		res.TimeoutSeconds = types.DefaultAutoRollbackTimeoutSeconds
+	}
+
+	return res
+}
@@ -23,5 +23,6 @@ func (r *Role) ToRoleType() *types.Role {
 		Kind:      r.Kind,
 		UserID:    r.UserID,
 		ProjectID: r.ProjectID,
+		PolicyUID: r.PolicyUID,
 	}
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -19,6 +21,26 @@ type User struct {
 	// The github user id used for login (optional)
 	GithubUserID int64
 	GoogleUserID string
+
+	// The subject of the user at the configured OIDC provider (optional)
+	OIDCUserID string
+
+	// TOTPSecret is the base32-encoded secret used to generate one-time passwords. It is
+	// set when enrolment starts, and TOTPEnabled is set once a code has been verified.
+	TOTPSecret  []byte
+	TOTPEnabled bool
+
+	// TOTPLastStep is the time step of the last accepted one-time password, so that a
+	// password cannot be used twice
+	TOTPLastStep int64
+
+	// TOTPRecoveryCodes are the comma-separated hashes of the unused recovery codes
+	TOTPRecoveryCodes string
+
+	// TOTPFailedAttempts is the number of invalid codes entered since the last valid code.
+	// Once too many invalid codes are entered, codes are rejected until TOTPLockedUntil.
+	TOTPFailedAttempts int
+	TOTPLockedUntil    *time.Time
 }
 
 // ToUserType generates an external types.User to be shared over REST
@@ -27,5 +49,16 @@ func (u *User) ToUserType() *types.User {
 		ID:            u.ID,
 		Email:         u.Email,
 		EmailVerified: u.EmailVerified,
+		MFAEnabled:    u.TOTPEnabled,
 	}
 }
+
+// DisableTOTP removes the TOTP secret and recovery codes of the user
+func (u *User) DisableTOTP() {
+	u.TOTPSecret = nil
+	u.TOTPEnabled = false
+	u.TOTPLastStep = 0
+	u.TOTPRecoveryCodes = ""
+	u.TOTPFailedAttempts = 0
+	u.TOTPLockedUntil = nil
+}
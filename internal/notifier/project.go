@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/discord"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/integrations/teams"
+	"github.com/porter-dev/porter/internal/integrations/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// NewProjectNotifier returns a notifier which sends deployment notifications to every
+// notification integration in a project. Notifications which are sent in the background
+// log failed deliveries with the given logger.
+func NewProjectNotifier(
+	repo repository.Repository,
+	projectID uint,
+	conf *types.NotificationConfig,
+	l *logger.Logger,
+) slack.Notifier {
+	slackInts, _ := repo.SlackIntegration().ListSlackIntegrationsByProjectID(projectID)
+	webhookInts, _ := repo.WebhookIntegration().ListWebhookIntegrationsByProjectID(projectID)
+	teamsInts, _ := repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(projectID)
+	discordInts, _ := repo.DiscordIntegration().ListDiscordIntegrationsByProjectID(projectID)
+
+	return slack.NewMultiNotifier(
+		slack.NewSlackNotifier(conf, slackInts...),
+		webhook.NewNotifier(conf, l, webhookInts...),
+		teams.NewNotifier(conf, l, teamsInts...),
+		discord.NewNotifier(conf, l, discordInts...),
+	)
+}
+
+// NewProjectIncidentNotifier returns a notifier which sends incident notifications to
+// every notification integration in a project
+func NewProjectIncidentNotifier(
+	repo repository.Repository,
+	projectID uint,
+	conf *types.NotificationConfig,
+	l *logger.Logger,
+) slack.IncidentNotifier {
+	slackInts, _ := repo.SlackIntegration().ListSlackIntegrationsByProjectID(projectID)
+	webhookInts, _ := repo.WebhookIntegration().ListWebhookIntegrationsByProjectID(projectID)
+	teamsInts, _ := repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(projectID)
+	discordInts, _ := repo.DiscordIntegration().ListDiscordIntegrationsByProjectID(projectID)
+
+	return slack.NewMultiIncidentNotifier(
+		slack.NewIncidentsNotifier(conf, slackInts...),
+		webhook.NewNotifier(conf, l, webhookInts...),
+		teams.NewNotifier(conf, l, teamsInts...),
+		discord.NewNotifier(conf, l, discordInts...),
+	)
+}
@@ -0,0 +1,357 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/porter-dev/porter/api/types"
+	"golang.org/x/oauth2"
+)
+
+// OIDCConf is the configuration for a generic OpenID Connect provider. The endpoints
+// of the provider are found through discovery the first time that they are needed, so
+// that an unavailable provider does not prevent the server from starting.
+type OIDCConf struct {
+	oauth2.Config
+
+	Issuer string
+
+	// GroupRoles are the project roles granted to members of groups at the provider
+	GroupRoles []OIDCGroupRole
+
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]*rsa.PublicKey
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jwks struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// OIDCClaims are the claims of a verified ID token
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+
+	// Groups is the value of the configured groups claim, if present
+	Groups []string
+}
+
+// OIDCGroupRole grants a role in a project to members of a group at the provider
+type OIDCGroupRole struct {
+	Group     string
+	ProjectID uint
+	Kind      types.RoleKind
+}
+
+// ParseOIDCGroupRoles parses group role mappings in the form <group>:<project_id>:<role>.
+// The group name may itself contain colons.
+func ParseOIDCGroupRoles(mappings []string) ([]OIDCGroupRole, error) {
+	res := make([]OIDCGroupRole, 0)
+
+	for _, mapping := range mappings {
+		kindIdx := strings.LastIndex(mapping, ":")
+
+		if kindIdx <= 0 {
+			return nil, fmt.Errorf("invalid oidc group role mapping %q", mapping)
+		}
+
+		projIdx := strings.LastIndex(mapping[:kindIdx], ":")
+
+		if projIdx <= 0 {
+			return nil, fmt.Errorf("invalid oidc group role mapping %q", mapping)
+		}
+
+		projID, err := strconv.ParseUint(mapping[projIdx+1:kindIdx], 10, 64)
+
+		if err != nil || projID == 0 {
+			return nil, fmt.Errorf("invalid project id in oidc group role mapping %q", mapping)
+		}
+
+		kind := types.RoleKind(mapping[kindIdx+1:])
+
+		if kind != types.RoleAdmin && kind != types.RoleDeveloper && kind != types.RoleViewer {
+			return nil, fmt.Errorf("invalid role in oidc group role mapping %q", mapping)
+		}
+
+		res = append(res, OIDCGroupRole{
+			Group:     mapping[:projIdx],
+			ProjectID: uint(projID),
+			Kind:      kind,
+		})
+	}
+
+	return res, nil
+}
+
+func NewOIDCClient(cfg *Config, issuer string) *OIDCConf {
+	return &OIDCConf{
+		Config: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.BaseURL + "/api/oauth/oidc/callback",
+			Scopes:       cfg.Scopes,
+		},
+		Issuer:     strings.TrimSuffix(issuer, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AuthCodeURL returns the URL of the provider's consent page. The nonce is included in
+// the ID token, and should be checked with VerifyIDToken.
+func (o *OIDCConf) AuthCodeURL(state, nonce string) (string, error) {
+	conf, err := o.oauth2Config()
+
+	if err != nil {
+		return "", err
+	}
+
+	return conf.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// Exchange exchanges an authorization code for a token, and returns the raw ID token
+func (o *OIDCConf) Exchange(ctx context.Context, code string) (string, error) {
+	conf, err := o.oauth2Config()
+
+	if err != nil {
+		return "", err
+	}
+
+	tok, err := conf.Exchange(ctx, code)
+
+	if err != nil {
+		return "", err
+	}
+
+	rawIDToken, ok := tok.Extra("id_token").(string)
+
+	if !ok || rawIDToken == "" {
+		return "", fmt.Errorf("token response did not contain an id_token")
+	}
+
+	return rawIDToken, nil
+}
+
+// VerifyIDToken verifies the signature, issuer, audience, expiry and nonce of an ID
+// token, and returns its claims
+func (o *OIDCConf) VerifyIDToken(rawIDToken, nonce, groupsClaim string) (*OIDCClaims, error) {
+	disc, err := o.getDiscovery()
+
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &jwt.Parser{ValidMethods: []string{"RS256", "RS384", "RS512"}}
+	claims := jwt.MapClaims{}
+
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(tok *jwt.Token) (interface{}, error) {
+		kid, _ := tok.Header["kid"].(string)
+
+		return o.getKey(kid)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if !claims.VerifyIssuer(disc.Issuer, true) {
+		return nil, fmt.Errorf("invalid id token: unexpected issuer")
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("invalid id token: token is expired")
+	}
+
+	if !hasAudience(claims["aud"], o.ClientID) {
+		return nil, fmt.Errorf("invalid id token: unexpected audience")
+	}
+
+	if tokNonce, _ := claims["nonce"].(string); tokNonce != nonce {
+		return nil, fmt.Errorf("invalid id token: unexpected nonce")
+	}
+
+	res := &OIDCClaims{}
+	res.Subject, _ = claims["sub"].(string)
+	res.Email, _ = claims["email"].(string)
+
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		res.EmailVerified = verified
+	case string:
+		// some providers send the claim as a string
+		res.EmailVerified = verified == "true"
+	}
+
+	if res.Subject == "" {
+		return nil, fmt.Errorf("invalid id token: missing sub claim")
+	}
+
+	if groupsClaim != "" {
+		switch groups := claims[groupsClaim].(type) {
+		case []interface{}:
+			for _, group := range groups {
+				if groupStr, ok := group.(string); ok {
+					res.Groups = append(res.Groups, groupStr)
+				}
+			}
+		case string:
+			res.Groups = []string{groups}
+		}
+	}
+
+	return res, nil
+}
+
+func (o *OIDCConf) oauth2Config() (*oauth2.Config, error) {
+	disc, err := o.getDiscovery()
+
+	if err != nil {
+		return nil, err
+	}
+
+	conf := o.Config
+	conf.Endpoint = oauth2.Endpoint{
+		AuthURL:  disc.AuthorizationEndpoint,
+		TokenURL: disc.TokenEndpoint,
+	}
+
+	return &conf, nil
+}
+
+func (o *OIDCConf) getDiscovery() (*oidcDiscovery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.discovery != nil {
+		return o.discovery, nil
+	}
+
+	disc := &oidcDiscovery{}
+
+	if err := o.getJSON(o.Issuer+"/.well-known/openid-configuration", disc); err != nil {
+		return nil, fmt.Errorf("could not discover oidc provider: %w", err)
+	}
+
+	if strings.TrimSuffix(disc.Issuer, "/") != o.Issuer {
+		return nil, fmt.Errorf("oidc provider issuer %s does not match configured issuer %s", disc.Issuer, o.Issuer)
+	}
+
+	o.discovery = disc
+
+	return disc, nil
+}
+
+// getKey returns the signing key with the given id, refreshing the key set if the key
+// is not known, since providers rotate their keys
+func (o *OIDCConf) getKey(kid string) (*rsa.PublicKey, error) {
+	disc, err := o.getDiscovery()
+
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if key, ok := o.keys[kid]; ok {
+		return key, nil
+	}
+
+	set := &jwks{}
+
+	if err := o.getJSON(disc.JWKSURI, set); err != nil {
+		return nil, fmt.Errorf("could not get oidc signing keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+
+		if err != nil {
+			continue
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+
+		if err != nil {
+			continue
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	o.keys = keys
+
+	key, ok := keys[kid]
+
+	if !ok {
+		return nil, fmt.Errorf("oidc signing key %s not found", kid)
+	}
+
+	return key, nil
+}
+
+func (o *OIDCConf) getJSON(url string, v interface{}) error {
+	resp, err := o.httpClient.Get(url)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed with status code %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func hasAudience(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+package oauth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+func newTestOIDCProvider(t *testing.T) (*httptest.Server, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"jwks_uri":               server.URL + "/keys",
+		})
+	})
+
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	return server, key
+}
+
+func signTestIDToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = "test"
+
+	signed, err := tok.SignedString(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func TestOIDCVerifyIDToken(t *testing.T) {
+	server, key := newTestOIDCProvider(t)
+	defer server.Close()
+
+	conf := oauth.NewOIDCClient(&oauth.Config{
+		ClientID:     "porter",
+		ClientSecret: "secret",
+		BaseURL:      "http://porter.test",
+	}, server.URL)
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            server.URL,
+			"aud":            []string{"porter", "other"},
+			"sub":            "user-1",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"nonce":          "nonce",
+			"email":          "user@example.com",
+			"email_verified": true,
+			"groups":         []string{"engineering", "ops"},
+		}
+	}
+
+	claims, err := conf.VerifyIDToken(signTestIDToken(t, key, validClaims()), "nonce", "groups")
+
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+
+	if claims.Subject != "user-1" || claims.Email != "user@example.com" || !claims.EmailVerified {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	if strings.Join(claims.Groups, ",") != "engineering,ops" {
+		t.Errorf("unexpected groups %v", claims.Groups)
+	}
+
+	authURL, err := conf.AuthCodeURL("state", "nonce")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(authURL, server.URL+"/authorize?") || !strings.Contains(authURL, "nonce=nonce") {
+		t.Errorf("unexpected auth url %s", authURL)
+	}
+
+	invalid := map[string]func(c jwt.MapClaims){
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://other.test" },
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "other" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"wrong nonce":    func(c jwt.MapClaims) { c["nonce"] = "other" },
+		"missing sub":    func(c jwt.MapClaims) { delete(c, "sub") },
+	}
+
+	for name, modify := range invalid {
+		c := validClaims()
+		modify(c)
+
+		if _, err := conf.VerifyIDToken(signTestIDToken(t, key, c), "nonce", "groups"); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := conf.VerifyIDToken(signTestIDToken(t, otherKey, validClaims()), "nonce", "groups"); err == nil {
+		t.Errorf("wrong key: expected error, got nil")
+	}
+}
+
+func TestParseOIDCGroupRoles(t *testing.T) {
+	roles, err := oauth.ParseOIDCGroupRoles([]string{"engineering:1:developer", "org:admins:2:admin"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []oauth.OIDCGroupRole{
+		{Group: "engineering", ProjectID: 1, Kind: types.RoleDeveloper},
+		{Group: "org:admins", ProjectID: 2, Kind: types.RoleAdmin},
+	}
+
+	if len(roles) != len(expected) {
+		t.Fatalf("expected %d roles, got %d", len(expected), len(roles))
+	}
+
+	for i := range expected {
+		if roles[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], roles[i])
+		}
+	}
+
+	for _, mapping := range []string{"engineering", "engineering:x:admin", ":1:admin", "engineering:1:custom"} {
+		if _, err := oauth.ParseOIDCGroupRoles([]string{mapping}); err == nil {
+			t.Errorf("expected error for %q", mapping)
+		}
+	}
+}
@@ -0,0 +1,884 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/digitalocean/godo"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+
+	ptypes "github.com/porter-dev/porter/api/types"
+)
+
+// ListImageDetails lists the tagged images of an image repository with their digests and
+// push times, which are needed to apply retention rules. Unlike ListImages, it reads every
+// page of images. The push time is not set if the registry does not report it.
+func (r *Registry) ListImageDetails(
+	repoName string,
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) ([]*ptypes.Image, error) {
+	if r.AWSIntegrationID != 0 {
+		return r.listECRImageDetails(repoName, repo)
+	}
+
+	if r.AzureIntegrationID != 0 {
+		return r.listACRImageDetails(repoName, repo)
+	}
+
+	if r.GCPIntegrationID != 0 {
+		return r.listGCRImageDetails(repoName, repo)
+	}
+
+	if r.DOIntegrationID != 0 {
+		return r.listDOCRImageDetails(repoName, repo, doAuth)
+	}
+
+	if r.BasicIntegrationID != 0 {
+		if strings.Contains(r.URL, "docker.io") {
+			return r.listDockerHubImageDetails(repoName, repo)
+		}
+
+		return r.listPrivateRegistryImageDetails(repoName, repo)
+	}
+
+	return nil, fmt.Errorf("error listing images")
+}
+
+// DeleteImages deletes images from an image repository. Images are deleted by digest where
+// the registry supports it, which also deletes the other tags of the image.
+func (r *Registry) DeleteImages(
+	repoName string,
+	images []*ptypes.Image,
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) error {
+	if len(images) == 0 {
+		return nil
+	}
+
+	if r.AWSIntegrationID != 0 {
+		return r.deleteECRImages(repoName, images, repo)
+	}
+
+	if r.AzureIntegrationID != 0 {
+		return r.deleteACRImages(repoName, images, repo)
+	}
+
+	if r.GCPIntegrationID != 0 {
+		return r.deleteGCRImages(repoName, images, repo)
+	}
+
+	if r.DOIntegrationID != 0 {
+		return r.deleteDOCRImages(repoName, images, repo, doAuth)
+	}
+
+	if r.BasicIntegrationID != 0 {
+		if strings.Contains(r.URL, "docker.io") {
+			return r.deleteDockerHubImages(repoName, images, repo)
+		}
+
+		return r.deletePrivateRegistryImages(repoName, images, repo)
+	}
+
+	return fmt.Errorf("error deleting images")
+}
+
+func (r *Registry) listECRImageDetails(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	aws, err := repo.AWSIntegration().ReadAWSIntegration(
+		r.ProjectID,
+		r.AWSIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := aws.GetSession()
+
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ecr.New(sess)
+	tagStatus := ecr.TagStatusTagged
+	res := make([]*ptypes.Image, 0)
+
+	err = svc.DescribeImagesPages(&ecr.DescribeImagesInput{
+		RepositoryName: &repoName,
+		Filter: &ecr.DescribeImagesFilter{
+			TagStatus: &tagStatus,
+		},
+	}, func(page *ecr.DescribeImagesOutput, lastPage bool) bool {
+		for _, img := range page.ImageDetails {
+			for _, tag := range img.ImageTags {
+				res = append(res, &ptypes.Image{
+					Digest:         *img.ImageDigest,
+					Tag:            *tag,
+					RepositoryName: repoName,
+					PushedAt:       img.ImagePushedAt,
+				})
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Registry) deleteECRImages(repoName string, images []*ptypes.Image, repo repository.Repository) error {
+	aws, err := repo.AWSIntegration().ReadAWSIntegration(
+		r.ProjectID,
+		r.AWSIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	sess, err := aws.GetSession()
+
+	if err != nil {
+		return err
+	}
+
+	svc := ecr.New(sess)
+	imageIDs := make([]*ecr.ImageIdentifier, 0)
+
+	for _, digest := range uniqueDigests(images) {
+		imageDigest := digest
+
+		imageIDs = append(imageIDs, &ecr.ImageIdentifier{
+			ImageDigest: &imageDigest,
+		})
+	}
+
+	// ECR deletes at most 100 images per request
+	for start := 0; start < len(imageIDs); start += 100 {
+		end := start + 100
+
+		if end > len(imageIDs) {
+			end = len(imageIDs)
+		}
+
+		resp, err := svc.BatchDeleteImage(&ecr.BatchDeleteImageInput{
+			RepositoryName: &repoName,
+			ImageIds:       imageIDs[start:end],
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, failure := range resp.Failures {
+			// images that were already deleted are not an error
+			if failure.FailureCode != nil && *failure.FailureCode == ecr.ImageFailureCodeImageNotFound {
+				continue
+			}
+
+			return fmt.Errorf("could not delete image %s: %s", *failure.ImageId.ImageDigest, *failure.FailureReason)
+		}
+	}
+
+	return nil
+}
+
+type gcrImageDetailsResp struct {
+	Manifest map[string]gcrManifestDetails `json:"manifest"`
+}
+
+type gcrManifestDetails struct {
+	Tag            []string `json:"tag"`
+	TimeUploadedMs string   `json:"timeUploadedMs"`
+}
+
+// getGCRRepositoryURL returns the Docker registry API URL of a GCR or Artifact Registry
+// repository
+func (r *Registry) getGCRRepositoryURL(repoName string) (string, error) {
+	parsedURL, err := url.Parse("https://" + r.URL)
+
+	if err != nil {
+		return "", err
+	}
+
+	trimmedPath := strings.Trim(parsedURL.Path, "/")
+
+	if trimmedPath == "" {
+		return fmt.Sprintf("https://%s/v2/%s", parsedURL.Host, repoName), nil
+	}
+
+	return fmt.Sprintf("https://%s/v2/%s/%s", parsedURL.Host, trimmedPath, repoName), nil
+}
+
+func (r *Registry) listGCRImageDetails(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	gcp, err := repo.GCPIntegration().ReadGCPIntegration(
+		r.ProjectID,
+		r.GCPIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repoURL, err := r.getGCRRepositoryURL(repoName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", repoURL+"/tags/list", nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth("_json_key", string(gcp.GCPKeyData))
+
+	resp, err := doRegistryRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// GCR lists the manifests of a repository along with its tags
+	gcrResp := gcrImageDetailsResp{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&gcrResp); err != nil {
+		return nil, fmt.Errorf("Could not read GCR images: %v", err)
+	}
+
+	res := make([]*ptypes.Image, 0)
+
+	for digest, manifest := range gcrResp.Manifest {
+		var pushedAt *time.Time
+
+		if ms, err := strconv.ParseInt(manifest.TimeUploadedMs, 10, 64); err == nil {
+			t := time.UnixMilli(ms)
+			pushedAt = &t
+		}
+
+		for _, tag := range manifest.Tag {
+			res = append(res, &ptypes.Image{
+				Digest:         digest,
+				Tag:            tag,
+				RepositoryName: repoName,
+				PushedAt:       pushedAt,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Registry) deleteGCRImages(repoName string, images []*ptypes.Image, repo repository.Repository) error {
+	gcp, err := repo.GCPIntegration().ReadGCPIntegration(
+		r.ProjectID,
+		r.GCPIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	repoURL, err := r.getGCRRepositoryURL(repoName)
+
+	if err != nil {
+		return err
+	}
+
+	// GCR does not delete a manifest that is still tagged, so the tags are deleted first
+	references := make([]string, 0)
+
+	for _, img := range images {
+		references = append(references, img.Tag)
+	}
+
+	references = append(references, uniqueDigests(images)...)
+
+	for _, reference := range references {
+		req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/manifests/%s", repoURL, reference), nil)
+
+		if err != nil {
+			return err
+		}
+
+		req.SetBasicAuth("_json_key", string(gcp.GCPKeyData))
+
+		if err := deleteRegistryReference(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type acrTagDetailsResp struct {
+	Tags []acrTagDetails `json:"tags"`
+}
+
+type acrTagDetails struct {
+	Name           string     `json:"name"`
+	Digest         string     `json:"digest"`
+	LastUpdateTime *time.Time `json:"lastUpdateTime"`
+}
+
+func (r *Registry) listACRImageDetails(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	az, err := repo.AzureIntegration().ReadAzureIntegration(
+		r.ProjectID,
+		r.AzureIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.Image, 0)
+
+	// the ACR tags API reports the digest and update time of each tag, and links to the
+	// next page of tags
+	nextPath := fmt.Sprintf("/acr/v1/%s/_tags?n=100", repoName)
+
+	for nextPath != "" {
+		req, err := http.NewRequest("GET", strings.TrimSuffix(r.URL, "/")+nextPath, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.SetBasicAuth(az.AzureClientID, string(az.ServicePrincipalSecret))
+
+		resp, err := doRegistryRequest(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		acrResp := acrTagDetailsResp{}
+		err = json.NewDecoder(resp.Body).Decode(&acrResp)
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("Could not read Azure registry images: %v", err)
+		}
+
+		for _, tag := range acrResp.Tags {
+			res = append(res, &ptypes.Image{
+				Digest:         tag.Digest,
+				Tag:            tag.Name,
+				RepositoryName: repoName,
+				PushedAt:       tag.LastUpdateTime,
+			})
+		}
+
+		nextPath = getNextLinkPath(resp.Header.Get("Link"))
+	}
+
+	return res, nil
+}
+
+func (r *Registry) deleteACRImages(repoName string, images []*ptypes.Image, repo repository.Repository) error {
+	az, err := repo.AzureIntegration().ReadAzureIntegration(
+		r.ProjectID,
+		r.AzureIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	for _, digest := range uniqueDigests(images) {
+		req, err := http.NewRequest(
+			"DELETE",
+			fmt.Sprintf("%s/v2/%s/manifests/%s", strings.TrimSuffix(r.URL, "/"), repoName, digest),
+			nil,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		req.SetBasicAuth(az.AzureClientID, string(az.ServicePrincipalSecret))
+
+		if err := deleteRegistryReference(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getDOCRClient returns a DigitalOcean client and the name of the DOCR registry
+func (r *Registry) getDOCRClient(repo repository.Repository, doAuth *oauth2.Config) (*godo.Client, string, error) {
+	oauthInt, err := repo.OAuthIntegration().ReadOAuthIntegration(
+		r.ProjectID,
+		r.DOIntegrationID,
+	)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	tok, _, err := oauth.GetAccessToken(oauthInt.SharedOAuthModel, doAuth, oauth.MakeUpdateOAuthIntegrationTokenFunction(oauthInt, repo))
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	urlArr := strings.Split(r.URL, "/")
+
+	if len(urlArr) != 2 {
+		return nil, "", fmt.Errorf("invalid digital ocean registry url")
+	}
+
+	return godo.NewFromToken(tok), urlArr[1], nil
+}
+
+func (r *Registry) listDOCRImageDetails(
+	repoName string,
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+) ([]*ptypes.Image, error) {
+	client, name, err := r.getDOCRClient(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.Image, 0)
+	opts := &godo.ListOptions{PerPage: 200}
+
+	for {
+		tags, resp, err := client.Registry.ListRepositoryTags(context.TODO(), name, repoName, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tags {
+			updatedAt := tag.UpdatedAt
+
+			res = append(res, &ptypes.Image{
+				Digest:         tag.ManifestDigest,
+				Tag:            tag.Tag,
+				RepositoryName: repoName,
+				PushedAt:       &updatedAt,
+			})
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+
+		page, err := resp.Links.CurrentPage()
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Page = page + 1
+	}
+
+	return res, nil
+}
+
+func (r *Registry) deleteDOCRImages(
+	repoName string,
+	images []*ptypes.Image,
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+) error {
+	client, name, err := r.getDOCRClient(repo, doAuth)
+
+	if err != nil {
+		return err
+	}
+
+	// DOCR frees the storage of deleted manifests on the next garbage collection
+	for _, digest := range uniqueDigests(images) {
+		resp, err := client.Registry.DeleteManifest(context.TODO(), name, repoName, digest)
+
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type dockerHubTagDetailsResp struct {
+	Next    string                `json:"next"`
+	Results []dockerHubTagDetails `json:"results"`
+}
+
+type dockerHubTagDetails struct {
+	Name        string     `json:"name"`
+	LastUpdated *time.Time `json:"last_updated"`
+}
+
+// getDockerHubToken logs in to Docker Hub with the credentials of the registry
+func (r *Registry) getDockerHubToken(repo repository.Repository) (string, error) {
+	basic, err := repo.BasicIntegration().ReadBasicIntegration(
+		r.ProjectID,
+		r.BasicIntegrationID,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(&dockerHubLoginReq{
+		Username: string(basic.Username),
+		Password: string(basic.Password),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(
+		"POST",
+		"https://hub.docker.com/v2/users/login",
+		strings.NewReader(string(data)),
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := doRegistryRequest(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	tokenObj := dockerHubLoginResp{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&tokenObj); err != nil {
+		return "", fmt.Errorf("Could not decode Dockerhub token from response: %v", err)
+	}
+
+	return tokenObj.Token, nil
+}
+
+func (r *Registry) getDockerHubRepositoryURL() string {
+	return fmt.Sprintf("https://hub.docker.com/v2/repositories/%s", strings.Split(r.URL, "docker.io/")[1])
+}
+
+func (r *Registry) listDockerHubImageDetails(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	token, err := r.getDockerHubToken(repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.Image, 0)
+	nextURL := r.getDockerHubRepositoryURL() + "/tags?page_size=100"
+
+	for nextURL != "" {
+		req, err := http.NewRequest("GET", nextURL, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		resp, err := doRegistryRequest(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tagsResp := dockerHubTagDetailsResp{}
+		err = json.NewDecoder(resp.Body).Decode(&tagsResp)
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("Could not read Docker Hub images: %v", err)
+		}
+
+		// Docker Hub deletes images by tag, so the digest is not needed to delete an image
+		for _, result := range tagsResp.Results {
+			res = append(res, &ptypes.Image{
+				Tag:            result.Name,
+				RepositoryName: repoName,
+				PushedAt:       result.LastUpdated,
+			})
+		}
+
+		nextURL = tagsResp.Next
+	}
+
+	return res, nil
+}
+
+func (r *Registry) deleteDockerHubImages(repoName string, images []*ptypes.Image, repo repository.Repository) error {
+	token, err := r.getDockerHubToken(repo)
+
+	if err != nil {
+		return err
+	}
+
+	for _, img := range images {
+		req, err := http.NewRequest(
+			"DELETE",
+			fmt.Sprintf("%s/tags/%s/", r.getDockerHubRepositoryURL(), img.Tag),
+			nil,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		if err := deleteRegistryReference(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type registryManifest struct {
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+}
+
+type registryImageConfig struct {
+	Created *time.Time `json:"created"`
+}
+
+// minImageCreated is the earliest creation time of an image that is used as its push time,
+// which is the release of Docker
+var minImageCreated = time.Date(2013, 3, 1, 0, 0, 0, 0, time.UTC)
+
+const registryManifestAccept = "application/vnd.docker.distribution.manifest.v2+json, " +
+	"application/vnd.oci.image.manifest.v1+json"
+
+func (r *Registry) listPrivateRegistryImageDetails(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	basic, err := repo.BasicIntegration().ReadBasicIntegration(
+		r.ProjectID,
+		r.BasicIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := r.listPrivateRegistryImages(repoName, repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsedURL, err := url.Parse(r.URL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repoURL := fmt.Sprintf("%s://%s/v2/%s", parsedURL.Scheme, parsedURL.Host, repoName)
+
+	// the Docker registry API does not list push times. Some registries set the Last-Modified
+	// header of manifests, which is used where it is set. Otherwise the creation time of
+	// each image is read from its config, unless it cannot be a push time, since reproducible
+	// builds set a fixed creation time such as the Unix epoch. Images without a config, like
+	// multi-platform images, have no push time.
+	for _, img := range images {
+		req, err := http.NewRequest("GET", fmt.Sprintf("%s/manifests/%s", repoURL, img.Tag), nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("Accept", registryManifestAccept)
+		req.SetBasicAuth(string(basic.Username), string(basic.Password))
+
+		resp, err := doRegistryRequest(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		manifest := registryManifest{}
+		err = json.NewDecoder(resp.Body).Decode(&manifest)
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("Could not read manifest of image %s: %v", img.Tag, err)
+		}
+
+		img.Digest = resp.Header.Get("Docker-Content-Digest")
+
+		if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+			img.PushedAt = &lastModified
+			continue
+		}
+
+		if manifest.Config.Digest == "" {
+			continue
+		}
+
+		req, err = http.NewRequest("GET", fmt.Sprintf("%s/blobs/%s", repoURL, manifest.Config.Digest), nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.SetBasicAuth(string(basic.Username), string(basic.Password))
+
+		resp, err = doRegistryRequest(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		imageConfig := registryImageConfig{}
+		err = json.NewDecoder(resp.Body).Decode(&imageConfig)
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("Could not read config of image %s: %v", img.Tag, err)
+		}
+
+		if created := imageConfig.Created; created != nil && !created.Before(minImageCreated) && !created.After(time.Now()) {
+			img.PushedAt = created
+		}
+	}
+
+	return images, nil
+}
+
+func (r *Registry) deletePrivateRegistryImages(repoName string, images []*ptypes.Image, repo repository.Repository) error {
+	basic, err := repo.BasicIntegration().ReadBasicIntegration(
+		r.ProjectID,
+		r.BasicIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	parsedURL, err := url.Parse(r.URL)
+
+	if err != nil {
+		return err
+	}
+
+	// the registry must allow deletes, which is disabled by default for the Docker registry
+	for _, digest := range uniqueDigests(images) {
+		req, err := http.NewRequest(
+			"DELETE",
+			fmt.Sprintf("%s://%s/v2/%s/manifests/%s", parsedURL.Scheme, parsedURL.Host, repoName, digest),
+			nil,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		req.SetBasicAuth(string(basic.Username), string(basic.Password))
+
+		if err := deleteRegistryReference(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doRegistryRequest sends a request to a registry API, returning an error if the response
+// does not have a 2xx status code
+func doRegistryRequest(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return nil, fmt.Errorf("%s %s returned status %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// deleteRegistryReference sends a delete request to a registry API. References that were
+// already deleted are not an error.
+func deleteRegistryReference(req *http.Request) error {
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	return fmt.Errorf("DELETE %s returned status %d: %s", req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// getNextLinkPath returns the path of the next page from a Link header, such as
+// </acr/v1/app/_tags?last=v1&n=100>; rel="next"
+func getNextLinkPath(link string) string {
+	if !strings.Contains(link, `rel="next"`) {
+		return ""
+	}
+
+	start := strings.Index(link, "<")
+	end := strings.Index(link, ">")
+
+	if start == -1 || end <= start {
+		return ""
+	}
+
+	return link[start+1 : end]
+}
+
+func uniqueDigests(images []*ptypes.Image) []string {
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, img := range images {
+		if img.Digest == "" || seen[img.Digest] {
+			continue
+		}
+
+		seen[img.Digest] = true
+		res = append(res, img.Digest)
+	}
+
+	return res
+}
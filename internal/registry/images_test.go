@@ -0,0 +1,97 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func TestListPrivateRegistryImagePushTimes(t *testing.T) {
+	lastModified := time.Date(2022, 5, 20, 12, 0, 0, 0, time.UTC)
+	created := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// the creation times of the image configs, where reproducible builds use the Unix epoch
+	configs := map[string]time.Time{
+		"sha256:built":        created,
+		"sha256:reproducible": time.Unix(0, 0).UTC(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/app/tags/list":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"tags": []string{"pushed", "built", "reproducible"},
+			})
+		case strings.HasPrefix(r.URL.Path, "/v2/app/manifests/"):
+			tag := strings.TrimPrefix(r.URL.Path, "/v2/app/manifests/")
+
+			if tag == "pushed" {
+				w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+			}
+
+			w.Header().Set("Docker-Content-Digest", "sha256:"+tag)
+
+			fmt.Fprintf(w, `{"config": {"digest": "sha256:%s"}}`, tag)
+		case strings.HasPrefix(r.URL.Path, "/v2/app/blobs/"):
+			digest := strings.TrimPrefix(r.URL.Path, "/v2/app/blobs/")
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"created": configs[digest],
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	repo := test.NewRepository(true)
+
+	basic, err := repo.BasicIntegration().CreateBasicIntegration(&ints.BasicIntegration{
+		ProjectID: 1,
+		Username:  []byte("user"),
+		Password:  []byte("password"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg := &registry.Registry{
+		ProjectID:          1,
+		URL:                server.URL,
+		BasicIntegrationID: basic.ID,
+	}
+
+	images, err := reg.ListImageDetails("app", repo, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pushedAt := make(map[string]*time.Time)
+
+	for _, img := range images {
+		pushedAt[img.Tag] = img.PushedAt
+	}
+
+	if pushedAt["pushed"] == nil || !pushedAt["pushed"].Equal(lastModified) {
+		t.Errorf("expected the push time of pushed to be %v, got %v", lastModified, pushedAt["pushed"])
+	}
+
+	if pushedAt["built"] == nil || !pushedAt["built"].Equal(created) {
+		t.Errorf("expected the push time of built to be %v, got %v", created, pushedAt["built"])
+	}
+
+	if pushedAt["reproducible"] != nil {
+		t.Errorf("expected reproducible to have no push time, got %v", pushedAt["reproducible"])
+	}
+}
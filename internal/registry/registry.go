@@ -1059,6 +1059,44 @@ func (r *Registry) GetDockerConfigJSON(
 	repo repository.Repository,
 	doAuth *oauth2.Config, // only required if using DOCR
 ) ([]byte, error) {
+	conf, err := r.getDockerConfigFile(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(conf)
+}
+
+// GetBasicAuthCredentials returns a username and password which can be used to
+// log in to the registry, for clients which do not read a docker config file, such
+// as the Helm OCI registry client.
+func (r *Registry) GetBasicAuthCredentials(
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) (string, string, error) {
+	conf, err := r.getDockerConfigFile(repo, doAuth)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if conf == nil {
+		return "", "", fmt.Errorf("registry %d does not have an auth mechanism", r.ID)
+	}
+
+	// each docker config file contains a single auth config for the registry host
+	for _, authConf := range conf.AuthConfigs {
+		return authConf.Username, authConf.Password, nil
+	}
+
+	return "", "", fmt.Errorf("could not get credentials for registry %d", r.ID)
+}
+
+func (r *Registry) getDockerConfigFile(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+) (*configfile.ConfigFile, error) {
 	var conf *configfile.ConfigFile
 	var err error
 
@@ -1087,7 +1125,7 @@ func (r *Registry) GetDockerConfigJSON(
 		return nil, err
 	}
 
-	return json.Marshal(conf)
+	return conf, nil
 }
 
 func (r *Registry) getECRDockerConfigFile(
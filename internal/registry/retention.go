@@ -0,0 +1,217 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ptypes "github.com/porter-dev/porter/api/types"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// PlanImageRetention returns the images of a repository that retention rules keep and delete.
+// Images without a push time are always kept, since their age is unknown. Images are deleted
+// by digest, so an image that shares its digest with a kept image is kept as well.
+func PlanImageRetention(
+	repoName string,
+	images []*ptypes.Image,
+	rules *ptypes.ImageRetentionRules,
+	isDeployed func(tag string) bool,
+	now time.Time,
+) *ptypes.RepositoryImageRetention {
+	res := &ptypes.RepositoryImageRetention{
+		RepositoryName: repoName,
+		KeptTags:       make([]string, 0),
+		DeployedTags:   make([]string, 0),
+		DeletedImages:  make([]*ptypes.Image, 0),
+	}
+
+	sorted := make([]*ptypes.Image, len(images))
+	copy(sorted, images)
+
+	// sort the images from newest to oldest, with images without a push time last
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].PushedAt == nil || sorted[j].PushedAt == nil {
+			return sorted[j].PushedAt == nil && sorted[i].PushedAt != nil
+		}
+
+		return sorted[i].PushedAt.After(*sorted[j].PushedAt)
+	})
+
+	enabled := rules.KeepLast != 0 || rules.MaxAgeDays != 0
+	maxAge := time.Duration(rules.MaxAgeDays) * 24 * time.Hour
+	keptDigests := make(map[string]bool)
+	expired := make([]*ptypes.Image, 0)
+
+	for i, img := range sorted {
+		isExpired := enabled && img.PushedAt != nil &&
+			(rules.KeepLast == 0 || uint(i) >= rules.KeepLast) &&
+			(rules.MaxAgeDays == 0 || now.Sub(*img.PushedAt) > maxAge)
+
+		switch {
+		case !isExpired:
+			res.KeptTags = append(res.KeptTags, img.Tag)
+		case rules.KeepDeployed && isDeployed != nil && isDeployed(img.Tag):
+			res.DeployedTags = append(res.DeployedTags, img.Tag)
+		default:
+			expired = append(expired, img)
+			continue
+		}
+
+		if img.Digest != "" {
+			keptDigests[img.Digest] = true
+		}
+	}
+
+	for _, img := range expired {
+		if img.Digest != "" && keptDigests[img.Digest] {
+			res.KeptTags = append(res.KeptTags, img.Tag)
+		} else {
+			res.DeletedImages = append(res.DeletedImages, img)
+		}
+	}
+
+	return res
+}
+
+// DeployedImages is the set of images used by releases, stored as the repositories that use
+// each tag
+type DeployedImages map[string][]string
+
+// AddReleases adds the images set in the image.repository and image.tag values of releases,
+// along with the tags that blue-green deployments of the repository run, which are set in the
+// bluegreen.activeImageTag and bluegreen.imageTags values
+func (d DeployedImages) AddReleases(releases []*release.Release) {
+	for _, rel := range releases {
+		if rel == nil {
+			continue
+		}
+
+		imageVals, ok := rel.Config["image"].(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		repository, ok := imageVals["repository"].(string)
+
+		if !ok || repository == "" {
+			continue
+		}
+
+		tags := []interface{}{imageVals["tag"]}
+
+		if blueGreenVals, ok := rel.Config["bluegreen"].(map[string]interface{}); ok {
+			tags = append(tags, blueGreenVals["activeImageTag"])
+
+			switch imageTags := blueGreenVals["imageTags"].(type) {
+			case []interface{}:
+				tags = append(tags, imageTags...)
+			case []string:
+				for _, tag := range imageTags {
+					tags = append(tags, tag)
+				}
+			}
+		}
+
+		for _, tagVal := range tags {
+			tag := toImageTag(tagVal)
+
+			if tag == "" {
+				continue
+			}
+
+			d[tag] = append(d[tag], normalizeImageRepository(repository))
+		}
+	}
+}
+
+// toImageTag converts a tag in the values of a release to a string, since numeric tags are
+// decoded as floats
+func toImageTag(val interface{}) string {
+	switch t := val.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// RollbackRevisions is the number of most recent revisions of a release whose images are
+// kept, so that the release can still be rolled back to them
+const RollbackRevisions = 10
+
+// GetRollbackRevisions returns the revisions in the history of a release that it may be
+// rolled back to: the most recent RollbackRevisions revisions, and the most recent revision
+// that was deployed, which is the target of an automatic rollback.
+func GetRollbackRevisions(history []*release.Release) []*release.Release {
+	sorted := make([]*release.Release, 0, len(history))
+
+	for _, rel := range history {
+		if rel != nil {
+			sorted = append(sorted, rel)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version > sorted[j].Version
+	})
+
+	if len(sorted) <= RollbackRevisions {
+		return sorted
+	}
+
+	res := sorted[:RollbackRevisions]
+
+	// an older revision is only the rollback target if none of the recent revisions
+	// were deployed
+	for _, rel := range res {
+		if wasDeployed(rel) {
+			return res
+		}
+	}
+
+	for _, rel := range sorted[RollbackRevisions:] {
+		if wasDeployed(rel) {
+			return append(res, rel)
+		}
+	}
+
+	return res
+}
+
+func wasDeployed(rel *release.Release) bool {
+	return rel.Info != nil && (rel.Info.Status == release.StatusDeployed || rel.Info.Status == release.StatusSuperseded)
+}
+
+// IsDeployed returns true if a release uses a tag of a repository. The repository of a
+// release matches if it is the URI of the repository, like gcr.io/project/app, or ends with
+// the name of the repository, since Docker Hub images can be set without the registry host.
+func (d DeployedImages) IsDeployed(repoURI, repoName, tag string) bool {
+	uri := normalizeImageRepository(repoURI)
+
+	for _, repository := range d[tag] {
+		if repository == uri || repository == repoName || strings.HasSuffix(repository, "/"+repoName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeImageRepository(repository string) string {
+	if spl := strings.Split(repository, "://"); len(spl) > 1 {
+		repository = spl[1]
+	}
+
+	repository = strings.TrimPrefix(repository, "index.docker.io/")
+	repository = strings.TrimPrefix(repository, "docker.io/")
+
+	return strings.TrimSuffix(repository, "/")
+}
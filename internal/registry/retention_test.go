@@ -0,0 +1,265 @@
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+var now = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func image(tag, digest string, daysOld int) *types.Image {
+	pushedAt := now.Add(-time.Duration(daysOld) * 24 * time.Hour)
+
+	return &types.Image{
+		Tag:            tag,
+		Digest:         digest,
+		RepositoryName: "app",
+		PushedAt:       &pushedAt,
+	}
+}
+
+func deletedTags(retention *types.RepositoryImageRetention) []string {
+	res := make([]string, 0)
+
+	for _, img := range retention.DeletedImages {
+		res = append(res, img.Tag)
+	}
+
+	return res
+}
+
+func TestPlanImageRetention(t *testing.T) {
+	images := []*types.Image{
+		image("v1", "sha256:1", 40),
+		image("v4", "sha256:4", 1),
+		image("v2", "sha256:2", 30),
+		image("v3", "sha256:3", 10),
+		image("latest", "sha256:4", 1),
+		{Tag: "unknown", Digest: "sha256:5", RepositoryName: "app"},
+	}
+
+	tests := []struct {
+		name         string
+		rules        *types.ImageRetentionRules
+		deployed     []string
+		kept         []string
+		deployedTags []string
+		deleted      []string
+	}{
+		{
+			name:         "disabled",
+			rules:        &types.ImageRetentionRules{},
+			kept:         []string{"v4", "latest", "v3", "v2", "v1", "unknown"},
+			deployedTags: []string{},
+			deleted:      []string{},
+		},
+		{
+			name:         "keep last",
+			rules:        &types.ImageRetentionRules{KeepLast: 3},
+			kept:         []string{"v4", "latest", "v3", "unknown"},
+			deployedTags: []string{},
+			deleted:      []string{"v2", "v1"},
+		},
+		{
+			name:         "max age",
+			rules:        &types.ImageRetentionRules{MaxAgeDays: 20},
+			kept:         []string{"v4", "latest", "v3", "unknown"},
+			deployedTags: []string{},
+			deleted:      []string{"v2", "v1"},
+		},
+		{
+			name:         "keep last and max age",
+			rules:        &types.ImageRetentionRules{KeepLast: 4, MaxAgeDays: 5},
+			kept:         []string{"v4", "latest", "v3", "v2", "unknown"},
+			deployedTags: []string{},
+			deleted:      []string{"v1"},
+		},
+		{
+			name:     "keep deployed",
+			rules:    &types.ImageRetentionRules{KeepLast: 1, KeepDeployed: true},
+			deployed: []string{"v2"},
+			// latest shares its digest with the kept v4 image
+			kept:         []string{"v4", "unknown", "latest"},
+			deployedTags: []string{"v2"},
+			deleted:      []string{"v3", "v1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			isDeployed := func(tag string) bool {
+				for _, deployedTag := range test.deployed {
+					if tag == deployedTag {
+						return true
+					}
+				}
+
+				return false
+			}
+
+			retention := registry.PlanImageRetention("app", images, test.rules, isDeployed, now)
+
+			if !reflect.DeepEqual(retention.KeptTags, test.kept) {
+				t.Errorf("expected kept tags to be %v, got %v", test.kept, retention.KeptTags)
+			}
+
+			if !reflect.DeepEqual(retention.DeployedTags, test.deployedTags) {
+				t.Errorf("expected deployed tags to be %v, got %v", test.deployedTags, retention.DeployedTags)
+			}
+
+			if deleted := deletedTags(retention); !reflect.DeepEqual(deleted, test.deleted) {
+				t.Errorf("expected deleted tags to be %v, got %v", test.deleted, deleted)
+			}
+		})
+	}
+}
+
+func imageRelease(repository string, tag interface{}) *release.Release {
+	return &release.Release{
+		Name: "app",
+		Config: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": repository,
+				"tag":        tag,
+			},
+		},
+	}
+}
+
+func TestDeployedImages(t *testing.T) {
+	deployed := make(registry.DeployedImages)
+
+	deployed.AddReleases([]*release.Release{
+		imageRelease("gcr.io/project/app", "v1"),
+		imageRelease("https://123.dkr.ecr.us-east-1.amazonaws.com/web", float64(12)),
+		imageRelease("docker.io/org/worker", "v2"),
+		imageRelease("gcr.io/project/app", nil),
+		{Name: "no-image", Config: map[string]interface{}{}},
+	})
+
+	tests := []struct {
+		repoURI  string
+		repoName string
+		tag      string
+		expected bool
+	}{
+		{"gcr.io/project/app", "app", "v1", true},
+		{"gcr.io/project/app", "app", "v2", false},
+		{"gcr.io/project/other", "other", "v1", false},
+		{"123.dkr.ecr.us-east-1.amazonaws.com/web", "web", "12", true},
+		{"index.docker.io/org/worker", "org/worker", "v2", true},
+		{"org/worker", "worker", "v2", true},
+	}
+
+	for _, test := range tests {
+		if isDeployed := deployed.IsDeployed(test.repoURI, test.repoName, test.tag); isDeployed != test.expected {
+			t.Errorf("expected %s:%s deployed to be %t, got %t", test.repoURI, test.tag, test.expected, isDeployed)
+		}
+	}
+}
+
+func TestDeployedImagesBlueGreen(t *testing.T) {
+	deployed := make(registry.DeployedImages)
+
+	rel := imageRelease("gcr.io/project/app", "v3")
+
+	// values of releases read from Helm are decoded from JSON, so lists are []interface{}
+	rel.Config["bluegreen"] = map[string]interface{}{
+		"enabled":        true,
+		"activeImageTag": "v1",
+		"imageTags":      []interface{}{"v1", "v2"},
+	}
+
+	deployed.AddReleases([]*release.Release{rel})
+
+	for _, tag := range []string{"v1", "v2", "v3"} {
+		if !deployed.IsDeployed("gcr.io/project/app", "app", tag) {
+			t.Errorf("expected gcr.io/project/app:%s to be deployed", tag)
+		}
+	}
+
+	if deployed.IsDeployed("gcr.io/project/app", "app", "v4") {
+		t.Errorf("expected gcr.io/project/app:v4 not to be deployed")
+	}
+}
+
+func revision(version int, status release.Status) *release.Release {
+	return &release.Release{
+		Name:    "app",
+		Version: version,
+		Info:    &release.Info{Status: status},
+	}
+}
+
+func revisionVersions(releases []*release.Release) []int {
+	res := make([]int, 0)
+
+	for _, rel := range releases {
+		res = append(res, rel.Version)
+	}
+
+	return res
+}
+
+func TestGetRollbackRevisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  []*release.Release
+		expected []int
+	}{
+		{
+			name:     "short history",
+			history:  []*release.Release{revision(1, release.StatusSuperseded), revision(2, release.StatusDeployed)},
+			expected: []int{2, 1},
+		},
+		{
+			name: "recent revisions",
+			history: []*release.Release{
+				revision(1, release.StatusSuperseded),
+				revision(2, release.StatusSuperseded),
+				revision(3, release.StatusSuperseded),
+				revision(4, release.StatusSuperseded),
+				revision(5, release.StatusSuperseded),
+				revision(6, release.StatusSuperseded),
+				revision(7, release.StatusSuperseded),
+				revision(8, release.StatusSuperseded),
+				revision(9, release.StatusSuperseded),
+				revision(10, release.StatusSuperseded),
+				revision(11, release.StatusSuperseded),
+				revision(12, release.StatusDeployed),
+			},
+			expected: []int{12, 11, 10, 9, 8, 7, 6, 5, 4, 3},
+		},
+		{
+			name: "rollback target before failed revisions",
+			history: []*release.Release{
+				revision(1, release.StatusSuperseded),
+				revision(2, release.StatusDeployed),
+				revision(3, release.StatusFailed),
+				revision(4, release.StatusFailed),
+				revision(5, release.StatusFailed),
+				revision(6, release.StatusFailed),
+				revision(7, release.StatusFailed),
+				revision(8, release.StatusFailed),
+				revision(9, release.StatusFailed),
+				revision(10, release.StatusFailed),
+				revision(11, release.StatusFailed),
+				revision(12, release.StatusFailed),
+			},
+			expected: []int{12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2},
+		},
+	}
+
+	for _, test := range tests {
+		revisions := revisionVersions(registry.GetRollbackRevisions(test.history))
+
+		if !reflect.DeepEqual(revisions, test.expected) {
+			t.Errorf("%s: expected revisions %v, got %v", test.name, test.expected, revisions)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditEventRepository represents the set of queries on the AuditEvent model
+type AuditEventRepository interface {
+	CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error)
+	ListAuditEventsByProjectID(
+		projectID uint,
+		opts *types.ListAuditEventsRequest,
+	) ([]*models.AuditEvent, int64, error)
+}
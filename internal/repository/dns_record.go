@@ -8,4 +8,5 @@ import (
 // DNSRecord model
 type DNSRecordRepository interface {
 	CreateDNSRecord(record *models.DNSRecord) (*models.DNSRecord, error)
+	ListDNSRecordsByNamespace(clusterID uint, namespace string) ([]*models.DNSRecord, error)
 }
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// EnvGroupSourceRepository represents the set of queries on the EnvGroupSource model
+type EnvGroupSourceRepository interface {
+	CreateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error)
+	ListEnvGroupSources(projectID, clusterID uint, namespace, name string) ([]*models.EnvGroupSource, error)
+	ListAllEnvGroupSources() ([]*models.EnvGroupSource, error)
+	UpdateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error)
+	DeleteEnvGroupSource(source *models.EnvGroupSource) error
+}
@@ -0,0 +1,98 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AuditEventRepository uses gorm.DB for querying the database
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditEventRepository returns an AuditEventRepository which uses
+// gorm.DB for querying the database
+func NewAuditEventRepository(db *gorm.DB) repository.AuditEventRepository {
+	return &AuditEventRepository{db}
+}
+
+// CreateAuditEvent creates a new audit event
+func (repo *AuditEventRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	if err := repo.db.Create(event).Error; err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListAuditEventsByProjectID finds all audit events for a given project id
+// with the given options, sorted by most recent first
+func (repo *AuditEventRepository) ListAuditEventsByProjectID(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+) ([]*models.AuditEvent, int64, error) {
+	listOpts := opts
+
+	if listOpts.Limit == 0 {
+		listOpts.Limit = 50
+	}
+
+	events := []*models.AuditEvent{}
+
+	query := repo.db.Where("project_id = ?", projectID)
+
+	if listOpts.UserID != 0 {
+		query = query.Where("user_id = ?", listOpts.UserID)
+	}
+
+	if listOpts.APITokenUID != "" {
+		query = query.Where("api_token_uid = ?", listOpts.APITokenUID)
+	}
+
+	if listOpts.ClusterID != 0 {
+		query = query.Where("cluster_id = ?", listOpts.ClusterID)
+	}
+
+	if listOpts.Namespace != "" {
+		query = query.Where("namespace = ?", listOpts.Namespace)
+	}
+
+	if listOpts.ReleaseName != "" {
+		query = query.Where("release_name = ?", listOpts.ReleaseName)
+	}
+
+	if listOpts.Verb != "" {
+		query = query.Where("verb = ?", listOpts.Verb)
+	}
+
+	if listOpts.Outcome != "" {
+		query = query.Where("outcome = ?", listOpts.Outcome)
+	}
+
+	if listOpts.Since != 0 {
+		query = query.Where("created_at >= ?", time.Unix(listOpts.Since, 0))
+	}
+
+	if listOpts.Until != 0 {
+		query = query.Where("created_at <= ?", time.Unix(listOpts.Until, 0))
+	}
+
+	// get the count before limit and offset
+	var count int64
+
+	if err := query.Model([]*models.AuditEvent{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("created_at desc").Order("id desc").Limit(listOpts.Limit).Offset(listOpts.Skip)
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
@@ -0,0 +1,179 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestCreateAuditEvent(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_create_audit_event.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	event := &models.AuditEvent{
+		ProjectID:   tester.initProjects[0].ID,
+		UserID:      tester.initUsers[0].ID,
+		ClusterID:   1,
+		Namespace:   "default",
+		ReleaseName: "web",
+		ScopesBytes: []byte(`{"cluster":"1","namespace":"default","project":"1","release":"web"}`),
+		Verb:        types.APIVerbUpdate,
+		Method:      types.HTTPVerbPost,
+		Endpoint:    "/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/releases/{name}/0/upgrade",
+		Path:        "/api/projects/1/clusters/1/namespaces/default/releases/web/0/upgrade",
+		StatusCode:  200,
+		Outcome:     types.AuditEventOutcomeSuccess,
+	}
+
+	event, err := tester.repo.AuditEvent().CreateAuditEvent(event)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	events, count, err := tester.repo.AuditEvent().ListAuditEventsByProjectID(
+		tester.initProjects[0].ID,
+		&types.ListAuditEventsRequest{},
+	)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 || len(events) != 1 {
+		t.Fatalf("expected 1 audit event, got count %d and length %d\n", count, len(events))
+	}
+
+	expEvent := event.ToAuditEventType()
+	gotEvent := events[0].ToAuditEventType()
+
+	// reset created at, since the precision differs after reading from the db
+	expEvent.CreatedAt = gotEvent.CreatedAt
+
+	if diff := deep.Equal(expEvent, gotEvent); diff != nil {
+		t.Errorf("incorrect audit event")
+		t.Error(diff)
+	}
+}
+
+func TestListAuditEventsByProjectID(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_audit_events.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projID := tester.initProjects[0].ID
+
+	initEvents := []*models.AuditEvent{
+		{
+			ProjectID: projID,
+			UserID:    1,
+			Verb:      types.APIVerbCreate,
+			Outcome:   types.AuditEventOutcomeSuccess,
+		},
+		{
+			ProjectID:   projID,
+			APITokenUID: "token-uid",
+			Verb:        types.APIVerbDelete,
+			Outcome:     types.AuditEventOutcomeFailure,
+		},
+		{
+			ProjectID:   projID,
+			UserID:      1,
+			ReleaseName: "web",
+			Verb:        types.APIVerbUpdate,
+			Outcome:     types.AuditEventOutcomeSuccess,
+		},
+		{
+			ProjectID: projID + 1,
+			UserID:    1,
+			Verb:      types.APIVerbUpdate,
+			Outcome:   types.AuditEventOutcomeSuccess,
+		},
+	}
+
+	for _, event := range initEvents {
+		if _, err := tester.repo.AuditEvent().CreateAuditEvent(event); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	tests := []struct {
+		description string
+		opts        *types.ListAuditEventsRequest
+		expCount    int64
+		expIDs      []uint
+	}{
+		{
+			description: "all events in project, most recent first",
+			opts:        &types.ListAuditEventsRequest{},
+			expCount:    3,
+			expIDs:      []uint{3, 2, 1},
+		},
+		{
+			description: "filter by user",
+			opts:        &types.ListAuditEventsRequest{UserID: 1},
+			expCount:    2,
+			expIDs:      []uint{3, 1},
+		},
+		{
+			description: "filter by api token",
+			opts:        &types.ListAuditEventsRequest{APITokenUID: "token-uid"},
+			expCount:    1,
+			expIDs:      []uint{2},
+		},
+		{
+			description: "filter by release and verb",
+			opts:        &types.ListAuditEventsRequest{ReleaseName: "web", Verb: types.APIVerbUpdate},
+			expCount:    1,
+			expIDs:      []uint{3},
+		},
+		{
+			description: "filter by outcome",
+			opts:        &types.ListAuditEventsRequest{Outcome: types.AuditEventOutcomeFailure},
+			expCount:    1,
+			expIDs:      []uint{2},
+		},
+		{
+			description: "paginate",
+			opts:        &types.ListAuditEventsRequest{Limit: 1, Skip: 1},
+			expCount:    3,
+			expIDs:      []uint{2},
+		},
+	}
+
+	for _, test := range tests {
+		events, count, err := tester.repo.AuditEvent().ListAuditEventsByProjectID(projID, test.opts)
+
+		if err != nil {
+			t.Fatalf("[ %s ]: %v\n", test.description, err)
+		}
+
+		if count != test.expCount {
+			t.Errorf("[ %s ]: expected count %d, got %d\n", test.description, test.expCount, count)
+		}
+
+		ids := make([]uint, 0)
+
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+
+		if diff := deep.Equal(test.expIDs, ids); diff != nil {
+			t.Errorf("[ %s ]: incorrect audit event ids", test.description)
+			t.Error(diff)
+		}
+	}
+}
@@ -250,6 +250,24 @@ func (repo *ClusterRepository) ListClustersByProjectID(
 	return clusters, nil
 }
 
+// ListClustersWithEnvGroupRetention lists the clusters that override the number of env group
+// versions kept by their project
+func (repo *ClusterRepository) ListClustersWithEnvGroupRetention() ([]*models.Cluster, error) {
+	ctxDB := repo.db.WithContext(context.Background())
+
+	clusters := []*models.Cluster{}
+
+	if err := ctxDB.Where("env_group_version_retention > 0").Find(&clusters).Error; err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		repo.DecryptClusterData(cluster, repo.key)
+	}
+
+	return clusters, nil
+}
+
 // UpdateCluster modifies an existing Cluster in the database
 func (repo *ClusterRepository) UpdateCluster(
 	cluster *models.Cluster,
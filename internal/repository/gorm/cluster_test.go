@@ -305,6 +305,48 @@ func TestListClustersByProjectID(t *testing.T) {
 	}
 }
 
+func TestListClustersWithEnvGroupRetention(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_clusters_env_group_retention.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	initCluster(tester, t)
+	defer cleanup(tester, t)
+
+	clusters, err := tester.repo.Cluster().ListClustersWithEnvGroupRetention()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(clusters) != 0 {
+		t.Fatalf("length of clusters incorrect: expected %d, got %d\n", 0, len(clusters))
+	}
+
+	cluster := tester.initClusters[0]
+	cluster.EnvGroupVersionRetention = 10
+
+	if _, err := tester.repo.Cluster().UpdateCluster(cluster); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	clusters, err = tester.repo.Cluster().ListClustersWithEnvGroupRetention()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(clusters) != 1 {
+		t.Fatalf("length of clusters incorrect: expected %d, got %d\n", 1, len(clusters))
+	}
+
+	if clusters[0].EnvGroupVersionRetention != 10 {
+		t.Errorf("incorrect env group version retention: expected %d, got %d\n", 10, clusters[0].EnvGroupVersionRetention)
+	}
+}
+
 func TestUpdateCluster(t *testing.T) {
 	tester := &tester{
 		dbFileName: "./porter_update_cluster.db",
@@ -0,0 +1,137 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// DiscordIntegrationRepository uses gorm.DB for querying the database
+type DiscordIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewDiscordIntegrationRepository returns a DiscordIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewDiscordIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.DiscordIntegrationRepository {
+	return &DiscordIntegrationRepository{db, key}
+}
+
+// CreateDiscordIntegration creates a new Discord integration
+func (repo *DiscordIntegrationRepository) CreateDiscordIntegration(
+	discordInt *ints.DiscordIntegration,
+) (*ints.DiscordIntegration, error) {
+	err := repo.EncryptDiscordIntegrationData(discordInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(discordInt).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptDiscordIntegrationData(discordInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return discordInt, nil
+}
+
+// ReadDiscordIntegration finds a Discord integration by project id and id
+func (repo *DiscordIntegrationRepository) ReadDiscordIntegration(
+	projectID, id uint,
+) (*ints.DiscordIntegration, error) {
+	discordInt := &ints.DiscordIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&discordInt).Error; err != nil {
+		return nil, err
+	}
+
+	err := repo.DecryptDiscordIntegrationData(discordInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return discordInt, nil
+}
+
+// ListDiscordIntegrationsByProjectID finds all Discord integrations for a given project id
+func (repo *DiscordIntegrationRepository) ListDiscordIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.DiscordIntegration, error) {
+	discordInts := []*ints.DiscordIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&discordInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, discordInt := range discordInts {
+		err := repo.DecryptDiscordIntegrationData(discordInt, repo.key)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return discordInts, nil
+}
+
+// DeleteDiscordIntegration deletes a Discord integration by ID
+func (repo *DiscordIntegrationRepository) DeleteDiscordIntegration(
+	integrationID uint,
+) error {
+	if err := repo.db.Where("id = ?", integrationID).Delete(&ints.DiscordIntegration{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EncryptDiscordIntegrationData will encrypt the Discord integration data before
+// writing to the DB
+func (repo *DiscordIntegrationRepository) EncryptDiscordIntegrationData(
+	discordInt *ints.DiscordIntegration,
+	key *[32]byte,
+) error {
+	if len(discordInt.Webhook) > 0 {
+		cipherData, err := encryption.Encrypt(discordInt.Webhook, key)
+
+		if err != nil {
+			return err
+		}
+
+		discordInt.Webhook = cipherData
+	}
+
+	return nil
+}
+
+// DecryptDiscordIntegrationData will decrypt the Discord integration data before
+// returning it from the DB
+func (repo *DiscordIntegrationRepository) DecryptDiscordIntegrationData(
+	discordInt *ints.DiscordIntegration,
+	key *[32]byte,
+) error {
+	if len(discordInt.Webhook) > 0 {
+		plaintext, err := encryption.Decrypt(discordInt.Webhook, key)
+
+		if err != nil {
+			return err
+		}
+
+		discordInt.Webhook = plaintext
+	}
+
+	return nil
+}
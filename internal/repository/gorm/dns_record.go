@@ -25,3 +25,17 @@ func (repo *DNSRecordRepository) CreateDNSRecord(record *models.DNSRecord) (*mod
 
 	return record, nil
 }
+
+// ListDNSRecordsByNamespace finds all DNS records created for releases in a namespace,
+// oldest first
+func (repo *DNSRecordRepository) ListDNSRecordsByNamespace(clusterID uint, namespace string) ([]*models.DNSRecord, error) {
+	records := []*models.DNSRecord{}
+
+	query := repo.db.Where("cluster_id = ? AND namespace = ?", clusterID, namespace).Order("id asc")
+
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,74 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSourceRepository uses gorm.DB for querying the database
+type EnvGroupSourceRepository struct {
+	db *gorm.DB
+}
+
+// NewEnvGroupSourceRepository returns an EnvGroupSourceRepository which uses
+// gorm.DB for querying the database
+func NewEnvGroupSourceRepository(db *gorm.DB) repository.EnvGroupSourceRepository {
+	return &EnvGroupSourceRepository{db}
+}
+
+// CreateEnvGroupSource creates a new env group source
+func (repo *EnvGroupSourceRepository) CreateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	if err := repo.db.Create(source).Error; err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// ListEnvGroupSources finds all sources of an env group, sorted by key
+func (repo *EnvGroupSourceRepository) ListEnvGroupSources(
+	projectID, clusterID uint,
+	namespace, name string,
+) ([]*models.EnvGroupSource, error) {
+	sources := []*models.EnvGroupSource{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND env_group_name = ?",
+		projectID, clusterID, namespace, name,
+	).Order("key asc")
+
+	if err := query.Find(&sources).Error; err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+// ListAllEnvGroupSources finds the sources of all env groups, sorted so that the sources
+// of the same env group are adjacent
+func (repo *EnvGroupSourceRepository) ListAllEnvGroupSources() ([]*models.EnvGroupSource, error) {
+	sources := []*models.EnvGroupSource{}
+
+	query := repo.db.Order("project_id asc, cluster_id asc, namespace asc, env_group_name asc, key asc")
+
+	if err := query.Find(&sources).Error; err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+// UpdateEnvGroupSource modifies an existing env group source in the database
+func (repo *EnvGroupSourceRepository) UpdateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	if err := repo.db.Save(source).Error; err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// DeleteEnvGroupSource deletes an env group source
+func (repo *EnvGroupSourceRepository) DeleteEnvGroupSource(source *models.EnvGroupSource) error {
+	return repo.db.Delete(source).Error
+}
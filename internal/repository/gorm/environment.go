@@ -86,6 +86,26 @@ func (repo *EnvironmentRepository) ListEnvironments(projectID, clusterID uint) (
 	return envs, nil
 }
 
+// ListEnvironmentsWithExpiry lists the environments across all projects that set a TTL
+// or idle limit for their preview deployments
+func (repo *EnvironmentRepository) ListEnvironmentsWithExpiry() ([]*models.Environment, error) {
+	envs := make([]*models.Environment, 0)
+
+	if err := repo.db.Order("id asc").Where("ttl_hours > 0 OR idle_ttl_hours > 0").Find(&envs).Error; err != nil {
+		return nil, err
+	}
+
+	return envs, nil
+}
+
+func (repo *EnvironmentRepository) UpdateEnvironment(env *models.Environment) (*models.Environment, error) {
+	if err := repo.db.Save(env).Error; err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
 func (repo *EnvironmentRepository) DeleteEnvironment(env *models.Environment) (*models.Environment, error) {
 	if err := repo.db.Delete(&env).Error; err != nil {
 		return nil, err
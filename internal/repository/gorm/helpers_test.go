@@ -78,6 +78,9 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&models.Onboarding{},
 		&models.Allowlist{},
 		&models.Tag{},
+		&models.AuditEvent{},
+		&models.EnvGroupSource{},
+		&models.JobLock{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -0,0 +1,55 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobLockRepository uses gorm.DB for querying the database
+type JobLockRepository struct {
+	db *gorm.DB
+}
+
+// NewJobLockRepository returns a JobLockRepository which uses gorm.DB for querying
+// the database
+func NewJobLockRepository(db *gorm.DB) repository.JobLockRepository {
+	return &JobLockRepository{db}
+}
+
+// AcquireJobLock takes over the lease if it is held by the same holder or has expired,
+// and otherwise creates it if no server has taken it yet. Both statements are atomic, so
+// at most one holder acquires the lease.
+func (repo *JobLockRepository) AcquireJobLock(name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	res := repo.db.Model(&models.JobLock{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{
+			"holder":     holder,
+			"expires_at": now.Add(ttl),
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+
+	res = repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.JobLock{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: now.Add(ttl),
+	})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,53 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcquireJobLock(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_acquire_job_lock.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	repo := tester.repo.JobLock()
+
+	tests := []struct {
+		name     string
+		holder   string
+		ttl      time.Duration
+		expected bool
+	}{
+		{"first holder creates the lock", "server-1", time.Hour, true},
+		{"other holder cannot take an unexpired lock", "server-2", time.Hour, false},
+		{"holder renews its own lock", "server-1", -time.Minute, true},
+		{"other holder takes an expired lock", "server-2", time.Hour, true},
+		{"previous holder cannot take the lock back", "server-1", time.Hour, false},
+	}
+
+	for _, test := range tests {
+		acquired, err := repo.AcquireJobLock("preview-reaper", test.holder, test.ttl)
+
+		if err != nil {
+			t.Fatalf("%s: %v\n", test.name, err)
+		}
+
+		if acquired != test.expected {
+			t.Errorf("%s: expected acquired to be %t, got %t", test.name, test.expected, acquired)
+		}
+	}
+
+	// locks of different jobs are independent
+	acquired, err := repo.AcquireJobLock("image-retention", "server-1", time.Hour)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !acquired {
+		t.Errorf("expected lock of another job to be acquired")
+	}
+}
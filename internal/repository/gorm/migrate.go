@@ -51,6 +51,9 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.APIToken{},
 		&models.Policy{},
 		&models.Tag{},
+		&models.AuditEvent{},
+		&models.EnvGroupSource{},
+		&models.JobLock{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -67,5 +70,9 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&ints.GithubAppInstallation{},
 		&ints.GithubAppOAuthIntegration{},
 		&ints.SlackIntegration{},
+		&ints.WebhookIntegration{},
+		&ints.VaultIntegration{},
+		&ints.TeamsIntegration{},
+		&ints.DiscordIntegration{},
 	)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProjectRepository uses gorm.DB for querying the database
@@ -68,6 +69,16 @@ func (repo *ProjectRepository) ReadProject(id uint) (*models.Project, error) {
 	return project, nil
 }
 
+// UpdateProject modifies the settings of an existing project. Associations, such as
+// roles, are not updated.
+func (repo *ProjectRepository) UpdateProject(project *models.Project) (*models.Project, error) {
+	if err := repo.db.Omit(clause.Associations).Save(project).Error; err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 // ReadProject gets a projects specified by a unique id
 func (repo *ProjectRepository) ReadProjectRole(projID, userID uint) (*models.Role, error) {
 	// find the role
@@ -93,6 +104,18 @@ func (repo *ProjectRepository) ListProjectsByUserID(userID uint) ([]*models.Proj
 	return projects, nil
 }
 
+// ListProjectsWithEnvGroupRetention lists the projects that limit the number of env group
+// versions kept in their clusters
+func (repo *ProjectRepository) ListProjectsWithEnvGroupRetention() ([]*models.Project, error) {
+	projects := make([]*models.Project, 0)
+
+	if err := repo.db.Where("env_group_version_retention > 0").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
 // ReadProject gets a projects specified by a unique id
 func (repo *ProjectRepository) ListProjectRoles(projID uint) ([]models.Role, error) {
 	project := &models.Project{}
@@ -107,6 +107,24 @@ func (repo *RegistryRepository) ListRegistriesByProjectID(
 	return regs, nil
 }
 
+// ListRegistriesWithImageRetention lists the registries that delete old images
+func (repo *RegistryRepository) ListRegistriesWithImageRetention() ([]*models.Registry, error) {
+	regs := []*models.Registry{}
+
+	query := repo.db.Preload("TokenCache").
+		Where("image_retention_keep_last > 0 OR image_retention_max_age_days > 0")
+
+	if err := query.Find(&regs).Error; err != nil {
+		return nil, err
+	}
+
+	for _, reg := range regs {
+		repo.DecryptRegistryData(reg, repo.key)
+	}
+
+	return regs, nil
+}
+
 // UpdateRegistry modifies an existing Registry in the database
 func (repo *RegistryRepository) UpdateRegistry(
 	reg *models.Registry,
@@ -125,6 +125,48 @@ func TestUpdateRegistry(t *testing.T) {
 	}
 }
 
+func TestListRegistriesWithImageRetention(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_registries_image_retention.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	initRegistry(tester, t)
+	defer cleanup(tester, t)
+
+	regs, err := tester.repo.Registry().ListRegistriesWithImageRetention()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(regs) != 0 {
+		t.Fatalf("length of registries incorrect: expected %d, got %d\n", 0, len(regs))
+	}
+
+	reg := tester.initRegs[0]
+	reg.ImageRetentionMaxAgeDays = 30
+
+	if _, err := tester.repo.Registry().UpdateRegistry(reg); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	regs, err = tester.repo.Registry().ListRegistriesWithImageRetention()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(regs) != 1 {
+		t.Fatalf("length of registries incorrect: expected %d, got %d\n", 1, len(regs))
+	}
+
+	if regs[0].ImageRetentionMaxAgeDays != 30 {
+		t.Errorf("incorrect max age: expected %d, got %d\n", 30, regs[0].ImageRetentionMaxAgeDays)
+	}
+}
+
 func TestUpdateRegistryToken(t *testing.T) {
 	tester := &tester{
 		dbFileName: "./porter_test_update_registry_token.db",
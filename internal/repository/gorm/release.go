@@ -67,6 +67,27 @@ func (repo *ReleaseRepository) UpdateRelease(release *models.Release) (*models.R
 	return release, nil
 }
 
+// RecordWebhookSignature stores the last accepted webhook signature of a release in a single
+// conditional update, and returns false if a request signed at the same time or later was
+// already accepted. Concurrent deliveries of the same request therefore succeed only once.
+func (repo *ReleaseRepository) RecordWebhookSignature(releaseID uint, signedAt int64, signature string) (bool, error) {
+	res := repo.db.Model(&models.Release{}).
+		Where(
+			"id = ? AND COALESCE(webhook_last_signed_at, 0) <= ? AND COALESCE(webhook_last_signature, '') <> ?",
+			releaseID, signedAt, signature,
+		).
+		Updates(map[string]interface{}{
+			"webhook_last_signed_at": signedAt,
+			"webhook_last_signature": signature,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
 // DeleteRelease deletes a single user using their unique name and namespace pair
 func (repo *ReleaseRepository) DeleteRelease(release *models.Release) (*models.Release, error) {
 	if err := repo.db.Delete(&release).Error; err != nil {
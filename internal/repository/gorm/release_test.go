@@ -154,3 +154,61 @@ func TestDeleteRelease(t *testing.T) {
 		t.Fatalf("incorrect error: expected %v, got %v\n", orm.ErrRecordNotFound, err)
 	}
 }
+
+func TestRecordWebhookSignature(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_record_webhook_signature.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	release, err := tester.repo.Release().CreateRelease(&models.Release{
+		Name:         "denver-meister-dakota",
+		Namespace:    "default",
+		ProjectID:    1,
+		ClusterID:    1,
+		WebhookToken: "abcdefgh",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tests := []struct {
+		signedAt  int64
+		signature string
+		expected  bool
+	}{
+		{100, "sig-1", true},
+		// the same request replayed
+		{100, "sig-1", false},
+		// a different request signed in the same second
+		{100, "sig-2", true},
+		// a request signed before the last accepted one
+		{99, "sig-3", false},
+		{101, "sig-4", true},
+	}
+
+	for i, test := range tests {
+		accepted, err := tester.repo.Release().RecordWebhookSignature(release.ID, test.signedAt, test.signature)
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if accepted != test.expected {
+			t.Errorf("request %d: expected accepted to be %t, got %t\n", i, test.expected, accepted)
+		}
+	}
+
+	release, err = tester.repo.Release().ReadRelease(1, release.Name, release.Namespace)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if release.WebhookLastSignedAt != 101 || release.WebhookLastSignature != "sig-4" {
+		t.Errorf("incorrect last signature: expected %d %s, got %d %s\n", 101, "sig-4", release.WebhookLastSignedAt, release.WebhookLastSignature)
+	}
+}
@@ -33,6 +33,10 @@ type GormRepository struct {
 	githubAppInstallation     repository.GithubAppInstallationRepository
 	githubAppOAuthIntegration repository.GithubAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	webhookIntegration        repository.WebhookIntegrationRepository
+	vaultIntegration          repository.VaultIntegrationRepository
+	teamsIntegration          repository.TeamsIntegrationRepository
+	discordIntegration        repository.DiscordIntegrationRepository
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
@@ -47,6 +51,9 @@ type GormRepository struct {
 	apiToken                  repository.APITokenRepository
 	policy                    repository.PolicyRepository
 	tag                       repository.TagRepository
+	auditEvent                repository.AuditEventRepository
+	envGroupSource            repository.EnvGroupSourceRepository
+	jobLock                   repository.JobLockRepository
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -153,6 +160,22 @@ func (t *GormRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *GormRepository) WebhookIntegration() repository.WebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
+func (t *GormRepository) VaultIntegration() repository.VaultIntegrationRepository {
+	return t.vaultIntegration
+}
+
+func (t *GormRepository) TeamsIntegration() repository.TeamsIntegrationRepository {
+	return t.teamsIntegration
+}
+
+func (t *GormRepository) DiscordIntegration() repository.DiscordIntegrationRepository {
+	return t.discordIntegration
+}
+
 func (t *GormRepository) GitlabIntegration() repository.GitlabIntegrationRepository {
 	return t.gitlabIntegration
 }
@@ -209,11 +232,23 @@ func (t *GormRepository) Tag() repository.TagRepository {
 	return t.tag
 }
 
+func (t *GormRepository) AuditEvent() repository.AuditEventRepository {
+	return t.auditEvent
+}
+
+func (t *GormRepository) EnvGroupSource() repository.EnvGroupSourceRepository {
+	return t.envGroupSource
+}
+
+func (t *GormRepository) JobLock() repository.JobLockRepository {
+	return t.jobLock
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
 	return &GormRepository{
-		user:                      NewUserRepository(db),
+		user:                      NewUserRepository(db, key),
 		session:                   NewSessionRepository(db),
 		project:                   NewProjectRepository(db),
 		cluster:                   NewClusterRepository(db, key),
@@ -239,6 +274,10 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		githubAppInstallation:     NewGithubAppInstallationRepository(db),
 		githubAppOAuthIntegration: NewGithubAppOAuthIntegrationRepository(db),
 		slackIntegration:          NewSlackIntegrationRepository(db, key),
+		webhookIntegration:        NewWebhookIntegrationRepository(db, key),
+		vaultIntegration:          NewVaultIntegrationRepository(db, key),
+		teamsIntegration:          NewTeamsIntegrationRepository(db, key),
+		discordIntegration:        NewDiscordIntegrationRepository(db, key),
 		gitlabIntegration:         NewGitlabIntegrationRepository(db, key, storageBackend),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(db, key, storageBackend),
 		notificationConfig:        NewNotificationConfigRepository(db),
@@ -253,5 +292,8 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		apiToken:                  NewAPITokenRepository(db),
 		policy:                    NewPolicyRepository(db),
 		tag:                       NewTagRepository(db),
+		auditEvent:                NewAuditEventRepository(db),
+		envGroupSource:            NewEnvGroupSourceRepository(db),
+		jobLock:                   NewJobLockRepository(db),
 	}
 }
@@ -104,3 +104,48 @@ func TestReadUserByGoogleUserID(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestUserTOTPSecretEncrypted(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_user_totp_secret.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	user, err := tester.repo.User().CreateUser(&models.User{
+		Email:       "test@test.it",
+		Password:    "fake",
+		TOTPSecret:  []byte("JBSWY3DPEHPK3PXP"),
+		TOTPEnabled: true,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(user.TOTPSecret) != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("expected decrypted secret after create, got %s", user.TOTPSecret)
+	}
+
+	// the secret should not be stored in plaintext
+	stored := &models.User{}
+
+	if err := tester.db.Where("id = ?", user.ID).First(stored).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.TOTPSecret) == "JBSWY3DPEHPK3PXP" {
+		t.Errorf("expected secret to be encrypted in the database")
+	}
+
+	user, err = tester.repo.User().ReadUser(user.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(user.TOTPSecret) != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("expected decrypted secret after read, got %s", user.TOTPSecret)
+	}
+}
@@ -0,0 +1,138 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// VaultIntegrationRepository uses gorm.DB for querying the database
+type VaultIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewVaultIntegrationRepository returns a VaultIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewVaultIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.VaultIntegrationRepository {
+	return &VaultIntegrationRepository{db, key}
+}
+
+// CreateVaultIntegration creates a new Vault integration
+func (repo *VaultIntegrationRepository) CreateVaultIntegration(
+	vaultInt *ints.VaultIntegration,
+) (*ints.VaultIntegration, error) {
+	err := repo.EncryptVaultIntegrationData(vaultInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(vaultInt).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptVaultIntegrationData(vaultInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vaultInt, nil
+}
+
+// ReadVaultIntegration finds a Vault integration by project id and id
+func (repo *VaultIntegrationRepository) ReadVaultIntegration(
+	projectID, id uint,
+) (*ints.VaultIntegration, error) {
+	vaultInt := &ints.VaultIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&vaultInt).Error; err != nil {
+		return nil, err
+	}
+
+	err := repo.DecryptVaultIntegrationData(vaultInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vaultInt, nil
+}
+
+// ListVaultIntegrationsByProjectID finds all Vault integrations
+// for a given project id
+func (repo *VaultIntegrationRepository) ListVaultIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.VaultIntegration, error) {
+	vaultInts := []*ints.VaultIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&vaultInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, vaultInt := range vaultInts {
+		err := repo.DecryptVaultIntegrationData(vaultInt, repo.key)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vaultInts, nil
+}
+
+// DeleteVaultIntegration deletes a Vault integration by ID
+func (repo *VaultIntegrationRepository) DeleteVaultIntegration(
+	integrationID uint,
+) error {
+	if err := repo.db.Where("id = ?", integrationID).Delete(&ints.VaultIntegration{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EncryptVaultIntegrationData will encrypt the Vault integration data before
+// writing to the DB
+func (repo *VaultIntegrationRepository) EncryptVaultIntegrationData(
+	vaultInt *ints.VaultIntegration,
+	key *[32]byte,
+) error {
+	if len(vaultInt.Token) > 0 {
+		cipherData, err := encryption.Encrypt(vaultInt.Token, key)
+
+		if err != nil {
+			return err
+		}
+
+		vaultInt.Token = cipherData
+	}
+
+	return nil
+}
+
+// DecryptVaultIntegrationData will decrypt the Vault integration data before
+// returning it from the DB
+func (repo *VaultIntegrationRepository) DecryptVaultIntegrationData(
+	vaultInt *ints.VaultIntegration,
+	key *[32]byte,
+) error {
+	if len(vaultInt.Token) > 0 {
+		plaintext, err := encryption.Decrypt(vaultInt.Token, key)
+
+		if err != nil {
+			return err
+		}
+
+		vaultInt.Token = plaintext
+	}
+
+	return nil
+}
@@ -87,6 +87,40 @@ type GithubAppInstallationRepository interface {
 	DeleteGithubAppInstallationByAccountID(accountID int64) error
 }
 
+// WebhookIntegrationRepository represents the set of queries on a webhook
+// notification integration
+type WebhookIntegrationRepository interface {
+	CreateWebhookIntegration(webhookInt *ints.WebhookIntegration) (*ints.WebhookIntegration, error)
+	ReadWebhookIntegration(projectID, id uint) (*ints.WebhookIntegration, error)
+	ListWebhookIntegrationsByProjectID(projectID uint) ([]*ints.WebhookIntegration, error)
+	DeleteWebhookIntegration(integrationID uint) error
+}
+
+// VaultIntegrationRepository represents the set of queries on a Vault
+// integration
+type VaultIntegrationRepository interface {
+	CreateVaultIntegration(vaultInt *ints.VaultIntegration) (*ints.VaultIntegration, error)
+	ReadVaultIntegration(projectID, id uint) (*ints.VaultIntegration, error)
+	ListVaultIntegrationsByProjectID(projectID uint) ([]*ints.VaultIntegration, error)
+	DeleteVaultIntegration(integrationID uint) error
+}
+
+// TeamsIntegrationRepository represents the set of queries on a Microsoft Teams integration
+type TeamsIntegrationRepository interface {
+	CreateTeamsIntegration(teamsInt *ints.TeamsIntegration) (*ints.TeamsIntegration, error)
+	ReadTeamsIntegration(projectID, id uint) (*ints.TeamsIntegration, error)
+	ListTeamsIntegrationsByProjectID(projectID uint) ([]*ints.TeamsIntegration, error)
+	DeleteTeamsIntegration(integrationID uint) error
+}
+
+// DiscordIntegrationRepository represents the set of queries on a Discord integration
+type DiscordIntegrationRepository interface {
+	CreateDiscordIntegration(discordInt *ints.DiscordIntegration) (*ints.DiscordIntegration, error)
+	ReadDiscordIntegration(projectID, id uint) (*ints.DiscordIntegration, error)
+	ListDiscordIntegrationsByProjectID(projectID uint) ([]*ints.DiscordIntegration, error)
+	DeleteDiscordIntegration(integrationID uint) error
+}
+
 // GitlabIntegrationRepository represents the set of queries on the GitlabIntegration model
 type GitlabIntegrationRepository interface {
 	CreateGitlabIntegration(gi *ints.GitlabIntegration) (*ints.GitlabIntegration, error)
@@ -0,0 +1,10 @@
+package repository
+
+import "time"
+
+// JobLockRepository represents the set of queries on the JobLock model
+type JobLockRepository interface {
+	// AcquireJobLock acquires or renews the lease on a job for ttl, and returns false if
+	// another holder has an unexpired lease
+	AcquireJobLock(name, holder string, ttl time.Duration) (bool, error)
+}
@@ -13,9 +13,11 @@ type ProjectRepository interface {
 	CreateProjectRole(project *models.Project, role *models.Role) (*models.Role, error)
 	UpdateProjectRole(projID uint, role *models.Role) (*models.Role, error)
 	ReadProject(id uint) (*models.Project, error)
+	UpdateProject(project *models.Project) (*models.Project, error)
 	ReadProjectRole(projID, userID uint) (*models.Role, error)
 	ListProjectRoles(projID uint) ([]models.Role, error)
 	ListProjectsByUserID(userID uint) ([]*models.Project, error)
+	ListProjectsWithEnvGroupRetention() ([]*models.Project, error)
 	DeleteProject(project *models.Project) (*models.Project, error)
 	DeleteProjectRole(projID, userID uint) (*models.Role, error)
 }
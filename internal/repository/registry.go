@@ -11,6 +11,7 @@ type RegistryRepository interface {
 	ReadRegistry(projectID, regID uint) (*models.Registry, error)
 	ReadRegistryByInfraID(projectID, infraID uint) (*models.Registry, error)
 	ListRegistriesByProjectID(projectID uint) ([]*models.Registry, error)
+	ListRegistriesWithImageRetention() ([]*models.Registry, error)
 	UpdateRegistry(reg *models.Registry) (*models.Registry, error)
 	UpdateRegistryTokenCache(tokenCache *ints.RegTokenCache) (*models.Registry, error)
 	DeleteRegistry(reg *models.Registry) error
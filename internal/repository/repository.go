@@ -27,6 +27,10 @@ type Repository interface {
 	GithubAppInstallation() GithubAppInstallationRepository
 	GithubAppOAuthIntegration() GithubAppOAuthIntegrationRepository
 	SlackIntegration() SlackIntegrationRepository
+	WebhookIntegration() WebhookIntegrationRepository
+	VaultIntegration() VaultIntegrationRepository
+	TeamsIntegration() TeamsIntegrationRepository
+	DiscordIntegration() DiscordIntegrationRepository
 	GitlabIntegration() GitlabIntegrationRepository
 	GitlabAppOAuthIntegration() GitlabAppOAuthIntegrationRepository
 	NotificationConfig() NotificationConfigRepository
@@ -41,4 +45,7 @@ type Repository interface {
 	APIToken() APITokenRepository
 	Policy() PolicyRepository
 	Tag() TagRepository
+	AuditEvent() AuditEventRepository
+	EnvGroupSource() EnvGroupSourceRepository
+	JobLock() JobLockRepository
 }
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// AuditEventRepository will return errors on queries if canQuery is false
+// and stores audit events in-memory
+type AuditEventRepository struct {
+	canQuery bool
+	events   []*models.AuditEvent
+}
+
+// NewAuditEventRepository returns an AuditEventRepository which stores audit
+// events in-memory
+func NewAuditEventRepository(canQuery bool) repository.AuditEventRepository {
+	return &AuditEventRepository{canQuery, []*models.AuditEvent{}}
+}
+
+func (repo *AuditEventRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.events = append(repo.events, event)
+	event.ID = uint(len(repo.events))
+
+	return event, nil
+}
+
+func (repo *AuditEventRepository) ListAuditEventsByProjectID(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+) ([]*models.AuditEvent, int64, error) {
+	if !repo.canQuery {
+		return nil, 0, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.AuditEvent, 0)
+
+	for _, event := range repo.events {
+		if event.ProjectID != projectID ||
+			(opts.UserID != 0 && event.UserID != opts.UserID) ||
+			(opts.APITokenUID != "" && event.APITokenUID != opts.APITokenUID) ||
+			(opts.ClusterID != 0 && event.ClusterID != opts.ClusterID) ||
+			(opts.Namespace != "" && event.Namespace != opts.Namespace) ||
+			(opts.ReleaseName != "" && event.ReleaseName != opts.ReleaseName) ||
+			(opts.Verb != "" && event.Verb != opts.Verb) ||
+			(opts.Outcome != "" && event.Outcome != opts.Outcome) ||
+			(opts.Since != 0 && event.CreatedAt.Unix() < opts.Since) ||
+			(opts.Until != 0 && event.CreatedAt.Unix() > opts.Until) {
+			continue
+		}
+
+		res = append(res, event)
+	}
+
+	// most recent events first
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].ID > res[j].ID
+	})
+
+	count := int64(len(res))
+
+	limit := opts.Limit
+
+	if limit == 0 {
+		limit = 50
+	}
+
+	if opts.Skip >= len(res) {
+		return []*models.AuditEvent{}, count, nil
+	}
+
+	res = res[opts.Skip:]
+
+	if limit < len(res) {
+		res = res[:limit]
+	}
+
+	return res, count, nil
+}
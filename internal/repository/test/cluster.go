@@ -150,6 +150,24 @@ func (repo *ClusterRepository) ListClustersByProjectID(
 	return res, nil
 }
 
+// ListClustersWithEnvGroupRetention lists the clusters that override the number of env group
+// versions kept by their project
+func (repo *ClusterRepository) ListClustersWithEnvGroupRetention() ([]*models.Cluster, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Cluster, 0)
+
+	for _, cluster := range repo.clusters {
+		if cluster != nil && cluster.EnvGroupVersionRetention > 0 {
+			res = append(res, cluster)
+		}
+	}
+
+	return res, nil
+}
+
 // UpdateCluster modifies an existing Cluster in the database
 func (repo *ClusterRepository) UpdateCluster(
 	cluster *models.Cluster,
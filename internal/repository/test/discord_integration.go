@@ -0,0 +1,82 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DiscordIntegrationRepository will return errors on queries if canQuery is false
+// and stores Discord integrations in-memory
+type DiscordIntegrationRepository struct {
+	canQuery    bool
+	discordInts []*ints.DiscordIntegration
+}
+
+// NewDiscordIntegrationRepository returns a DiscordIntegrationRepository which
+// stores Discord integrations in-memory
+func NewDiscordIntegrationRepository(canQuery bool) repository.DiscordIntegrationRepository {
+	return &DiscordIntegrationRepository{canQuery, []*ints.DiscordIntegration{}}
+}
+
+func (repo *DiscordIntegrationRepository) CreateDiscordIntegration(
+	discordInt *ints.DiscordIntegration,
+) (*ints.DiscordIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.discordInts = append(repo.discordInts, discordInt)
+	discordInt.ID = uint(len(repo.discordInts))
+
+	return discordInt, nil
+}
+
+func (repo *DiscordIntegrationRepository) ReadDiscordIntegration(
+	projectID, id uint,
+) (*ints.DiscordIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.discordInts) || repo.discordInts[id-1] == nil ||
+		repo.discordInts[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.discordInts[id-1], nil
+}
+
+func (repo *DiscordIntegrationRepository) ListDiscordIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.DiscordIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.DiscordIntegration, 0)
+
+	for _, discordInt := range repo.discordInts {
+		if discordInt != nil && discordInt.ProjectID == projectID {
+			res = append(res, discordInt)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *DiscordIntegrationRepository) DeleteDiscordIntegration(integrationID uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(integrationID-1) >= len(repo.discordInts) || repo.discordInts[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.discordInts[integrationID-1] = nil
+
+	return nil
+}
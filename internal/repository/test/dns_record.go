@@ -34,3 +34,23 @@ func (repo *DNSRecordRepository) CreateDNSRecord(
 
 	return record, nil
 }
+
+// ListDNSRecordsByNamespace finds all DNS records created for releases in a namespace
+func (repo *DNSRecordRepository) ListDNSRecordsByNamespace(
+	clusterID uint,
+	namespace string,
+) ([]*models.DNSRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.DNSRecord, 0)
+
+	for _, record := range repo.dnsRecords {
+		if record.ClusterID == clusterID && record.Namespace == namespace {
+			res = append(res, record)
+		}
+	}
+
+	return res, nil
+}
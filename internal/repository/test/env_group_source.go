@@ -0,0 +1,102 @@
+package test
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSourceRepository will return errors on queries if canQuery is false
+// and stores env group sources in-memory
+type EnvGroupSourceRepository struct {
+	canQuery bool
+	sources  []*models.EnvGroupSource
+}
+
+// NewEnvGroupSourceRepository returns an EnvGroupSourceRepository which stores env
+// group sources in-memory
+func NewEnvGroupSourceRepository(canQuery bool) repository.EnvGroupSourceRepository {
+	return &EnvGroupSourceRepository{canQuery, []*models.EnvGroupSource{}}
+}
+
+func (repo *EnvGroupSourceRepository) CreateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.sources = append(repo.sources, source)
+	source.ID = uint(len(repo.sources))
+
+	return source, nil
+}
+
+func (repo *EnvGroupSourceRepository) ListEnvGroupSources(
+	projectID, clusterID uint,
+	namespace, name string,
+) ([]*models.EnvGroupSource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.EnvGroupSource, 0)
+
+	for _, source := range repo.sources {
+		if source != nil && source.ProjectID == projectID && source.ClusterID == clusterID &&
+			source.Namespace == namespace && source.EnvGroupName == name {
+			res = append(res, source)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+
+	return res, nil
+}
+
+func (repo *EnvGroupSourceRepository) ListAllEnvGroupSources() ([]*models.EnvGroupSource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.EnvGroupSource, 0)
+
+	for _, source := range repo.sources {
+		if source != nil {
+			res = append(res, source)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *EnvGroupSourceRepository) UpdateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(source.ID-1) >= len(repo.sources) || repo.sources[source.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.sources[int(source.ID-1)] = source
+
+	return source, nil
+}
+
+func (repo *EnvGroupSourceRepository) DeleteEnvGroupSource(source *models.EnvGroupSource) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(source.ID-1) >= len(repo.sources) || repo.sources[source.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.sources[int(source.ID-1)] = nil
+
+	return nil
+}
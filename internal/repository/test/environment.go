@@ -42,6 +42,14 @@ func (repo *EnvironmentRepository) ListEnvironments(projectID, clusterID uint) (
 	panic("unimplemented")
 }
 
+func (repo *EnvironmentRepository) ListEnvironmentsWithExpiry() ([]*models.Environment, error) {
+	panic("unimplemented")
+}
+
+func (repo *EnvironmentRepository) UpdateEnvironment(env *models.Environment) (*models.Environment, error) {
+	panic("unimplemented")
+}
+
 func (repo *EnvironmentRepository) DeleteEnvironment(env *models.Environment) (*models.Environment, error) {
 	panic("unimplemented")
 }
@@ -1,39 +1,94 @@
 package test
 
 import (
+	"errors"
+
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
-type BuildEventRepository struct{}
+// BuildEventRepository implements repository.BuildEventRepository
+type BuildEventRepository struct {
+	canQuery   bool
+	containers []*models.EventContainer
+	subEvents  []*models.SubEvent
+}
 
+// NewBuildEventRepository will return errors if canQuery is false
 func NewBuildEventRepository(canQuery bool) repository.BuildEventRepository {
-	return &BuildEventRepository{}
+	return &BuildEventRepository{canQuery: canQuery}
 }
 
 func (n *BuildEventRepository) CreateEventContainer(am *models.EventContainer) (*models.EventContainer, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	n.containers = append(n.containers, am)
+	am.ID = uint(len(n.containers))
+
+	return am, nil
 }
 
 func (n *BuildEventRepository) CreateSubEvent(am *models.SubEvent) (*models.SubEvent, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	n.subEvents = append(n.subEvents, am)
+	am.ID = uint(len(n.subEvents))
+
+	return am, nil
 }
 
 func (n *BuildEventRepository) ReadEventsByContainerID(id uint) ([]*models.SubEvent, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.SubEvent, 0)
+
+	for _, event := range n.subEvents {
+		if event.EventContainerID == id {
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
 }
 
 func (n *BuildEventRepository) ReadEventContainer(id uint) (*models.EventContainer, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(n.containers) || n.containers[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return n.containers[id-1], nil
 }
 
 func (n *BuildEventRepository) ReadSubEvent(id uint) (*models.SubEvent, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(n.subEvents) || n.subEvents[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return n.subEvents[id-1], nil
 }
 
 func (n *BuildEventRepository) AppendEvent(container *models.EventContainer, event *models.SubEvent) error {
-	panic("not implemented") // TODO: Implement
+	event.EventContainerID = container.ID
+
+	_, err := n.CreateSubEvent(event)
+
+	return err
 }
 
 type KubeEventRepository struct{}
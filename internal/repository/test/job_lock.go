@@ -0,0 +1,42 @@
+package test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// JobLockRepository will return errors on queries if canQuery is false
+// and stores job locks in-memory
+type JobLockRepository struct {
+	canQuery bool
+	mu       sync.Mutex
+	locks    map[string]*models.JobLock
+}
+
+// NewJobLockRepository returns a JobLockRepository which stores job locks in-memory
+func NewJobLockRepository(canQuery bool) repository.JobLockRepository {
+	return &JobLockRepository{canQuery: canQuery, locks: make(map[string]*models.JobLock)}
+}
+
+func (repo *JobLockRepository) AcquireJobLock(name, holder string, ttl time.Duration) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now()
+
+	if lock, ok := repo.locks[name]; ok && lock.Holder != holder && !lock.ExpiresAt.Before(now) {
+		return false, nil
+	}
+
+	repo.locks[name] = &models.JobLock{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)}
+
+	return true, nil
+}
@@ -13,6 +13,7 @@ const (
 	CreateProjectMethod        string = "create_project_0"
 	CreateProjectRoleMethod    string = "create_project_role_0"
 	ReadProjectMethod          string = "read_project_0"
+	UpdateProjectMethod        string = "update_project_0"
 	ListProjectsByUserIDMethod string = "list_projects_by_user_id_0"
 )
 
@@ -131,6 +132,21 @@ func (repo *ProjectRepository) ReadProject(id uint) (*models.Project, error) {
 	return repo.projects[index], nil
 }
 
+// UpdateProject modifies an existing project in the in-memory projects array
+func (repo *ProjectRepository) UpdateProject(project *models.Project) (*models.Project, error) {
+	if !repo.canQuery || strings.Contains(repo.failingMethods, UpdateProjectMethod) {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(project.ID-1) >= len(repo.projects) || repo.projects[project.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.projects[project.ID-1] = project
+
+	return project, nil
+}
+
 // ListProjectsByUserID lists projects where a user has an associated role
 func (repo *ProjectRepository) ListProjectsByUserID(userID uint) ([]*models.Project, error) {
 	if !repo.canQuery || strings.Contains(repo.failingMethods, ListProjectsByUserIDMethod) {
@@ -151,6 +167,24 @@ func (repo *ProjectRepository) ListProjectsByUserID(userID uint) ([]*models.Proj
 	return resp, nil
 }
 
+// ListProjectsWithEnvGroupRetention lists the projects that limit the number of env group
+// versions kept in their clusters
+func (repo *ProjectRepository) ListProjectsWithEnvGroupRetention() ([]*models.Project, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	resp := make([]*models.Project, 0)
+
+	for _, project := range repo.projects {
+		if project != nil && project.EnvGroupVersionRetention > 0 {
+			resp = append(resp, project)
+		}
+	}
+
+	return resp, nil
+}
+
 // ListProjectRoles returns a list of roles for the project
 func (repo *ProjectRepository) ListProjectRoles(projID uint) ([]models.Role, error) {
 	if !repo.canQuery {
@@ -79,6 +79,23 @@ func (repo *RegistryRepository) ListRegistriesByProjectID(
 	return res, nil
 }
 
+// ListRegistriesWithImageRetention lists the registries that delete old images
+func (repo *RegistryRepository) ListRegistriesWithImageRetention() ([]*models.Registry, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Registry, 0)
+
+	for _, reg := range repo.registries {
+		if reg != nil && reg.ImageRetentionEnabled() {
+			res = append(res, reg)
+		}
+	}
+
+	return res, nil
+}
+
 // UpdateRegistry modifies an existing Registry in the database
 func (repo *RegistryRepository) UpdateRegistry(
 	reg *models.Registry,
@@ -111,6 +111,28 @@ func (repo *ReleaseRepository) UpdateRelease(
 	return release, nil
 }
 
+// RecordWebhookSignature stores the last accepted webhook signature of a release
+func (repo *ReleaseRepository) RecordWebhookSignature(releaseID uint, signedAt int64, signature string) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	if int(releaseID-1) >= len(repo.releases) || repo.releases[releaseID-1] == nil {
+		return false, gorm.ErrRecordNotFound
+	}
+
+	release := repo.releases[releaseID-1]
+
+	if signedAt < release.WebhookLastSignedAt || signature == release.WebhookLastSignature {
+		return false, nil
+	}
+
+	release.WebhookLastSignedAt = signedAt
+	release.WebhookLastSignature = signature
+
+	return true, nil
+}
+
 // DeleteRelease removes a release from the array by setting it to nil
 func (repo *ReleaseRepository) DeleteRelease(
 	release *models.Release,
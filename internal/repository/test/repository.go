@@ -32,6 +32,10 @@ type TestRepository struct {
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	webhookIntegration        repository.WebhookIntegrationRepository
+	vaultIntegration          repository.VaultIntegrationRepository
+	teamsIntegration          repository.TeamsIntegrationRepository
+	discordIntegration        repository.DiscordIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
 	jobNotificationConfig     repository.JobNotificationConfigRepository
 	buildEvent                repository.BuildEventRepository
@@ -45,6 +49,9 @@ type TestRepository struct {
 	apiToken                  repository.APITokenRepository
 	policy                    repository.PolicyRepository
 	tag                       repository.TagRepository
+	auditEvent                repository.AuditEventRepository
+	envGroupSource            repository.EnvGroupSourceRepository
+	jobLock                   repository.JobLockRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -155,6 +162,22 @@ func (t *TestRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *TestRepository) WebhookIntegration() repository.WebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
+func (t *TestRepository) VaultIntegration() repository.VaultIntegrationRepository {
+	return t.vaultIntegration
+}
+
+func (t *TestRepository) TeamsIntegration() repository.TeamsIntegrationRepository {
+	return t.teamsIntegration
+}
+
+func (t *TestRepository) DiscordIntegration() repository.DiscordIntegrationRepository {
+	return t.discordIntegration
+}
+
 func (t *TestRepository) NotificationConfig() repository.NotificationConfigRepository {
 	return t.notificationConfig
 }
@@ -207,6 +230,18 @@ func (t *TestRepository) Tag() repository.TagRepository {
 	return t.tag
 }
 
+func (t *TestRepository) AuditEvent() repository.AuditEventRepository {
+	return t.auditEvent
+}
+
+func (t *TestRepository) EnvGroupSource() repository.EnvGroupSourceRepository {
+	return t.envGroupSource
+}
+
+func (t *TestRepository) JobLock() repository.JobLockRepository {
+	return t.jobLock
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -238,6 +273,10 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		gitlabIntegration:         NewGitlabIntegrationRepository(canQuery),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(canQuery),
 		slackIntegration:          NewSlackIntegrationRepository(canQuery),
+		webhookIntegration:        NewWebhookIntegrationRepository(canQuery),
+		vaultIntegration:          NewVaultIntegrationRepository(canQuery),
+		teamsIntegration:          NewTeamsIntegrationRepository(canQuery),
+		discordIntegration:        NewDiscordIntegrationRepository(canQuery),
 		notificationConfig:        NewNotificationConfigRepository(canQuery),
 		jobNotificationConfig:     NewJobNotificationConfigRepository(canQuery),
 		buildEvent:                NewBuildEventRepository(canQuery),
@@ -251,5 +290,8 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		apiToken:                  NewAPITokenRepository(canQuery),
 		policy:                    NewPolicyRepository(canQuery),
 		tag:                       NewTagRepository(),
+		auditEvent:                NewAuditEventRepository(canQuery),
+		envGroupSource:            NewEnvGroupSourceRepository(canQuery),
+		jobLock:                   NewJobLockRepository(canQuery),
 	}
 }
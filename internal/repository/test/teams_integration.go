@@ -0,0 +1,82 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TeamsIntegrationRepository will return errors on queries if canQuery is false
+// and stores Teams integrations in-memory
+type TeamsIntegrationRepository struct {
+	canQuery  bool
+	teamsInts []*ints.TeamsIntegration
+}
+
+// NewTeamsIntegrationRepository returns a TeamsIntegrationRepository which
+// stores Teams integrations in-memory
+func NewTeamsIntegrationRepository(canQuery bool) repository.TeamsIntegrationRepository {
+	return &TeamsIntegrationRepository{canQuery, []*ints.TeamsIntegration{}}
+}
+
+func (repo *TeamsIntegrationRepository) CreateTeamsIntegration(
+	teamsInt *ints.TeamsIntegration,
+) (*ints.TeamsIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.teamsInts = append(repo.teamsInts, teamsInt)
+	teamsInt.ID = uint(len(repo.teamsInts))
+
+	return teamsInt, nil
+}
+
+func (repo *TeamsIntegrationRepository) ReadTeamsIntegration(
+	projectID, id uint,
+) (*ints.TeamsIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.teamsInts) || repo.teamsInts[id-1] == nil ||
+		repo.teamsInts[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.teamsInts[id-1], nil
+}
+
+func (repo *TeamsIntegrationRepository) ListTeamsIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.TeamsIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.TeamsIntegration, 0)
+
+	for _, teamsInt := range repo.teamsInts {
+		if teamsInt != nil && teamsInt.ProjectID == projectID {
+			res = append(res, teamsInt)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *TeamsIntegrationRepository) DeleteTeamsIntegration(integrationID uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(integrationID-1) >= len(repo.teamsInts) || repo.teamsInts[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.teamsInts[integrationID-1] = nil
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// VaultIntegrationRepository will return errors on queries if canQuery is false
+// and stores Vault integrations in-memory
+type VaultIntegrationRepository struct {
+	canQuery  bool
+	vaultInts []*ints.VaultIntegration
+}
+
+// NewVaultIntegrationRepository returns a VaultIntegrationRepository which
+// stores Vault integrations in-memory
+func NewVaultIntegrationRepository(canQuery bool) repository.VaultIntegrationRepository {
+	return &VaultIntegrationRepository{canQuery, []*ints.VaultIntegration{}}
+}
+
+func (repo *VaultIntegrationRepository) CreateVaultIntegration(
+	vaultInt *ints.VaultIntegration,
+) (*ints.VaultIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.vaultInts = append(repo.vaultInts, vaultInt)
+	vaultInt.ID = uint(len(repo.vaultInts))
+
+	return vaultInt, nil
+}
+
+func (repo *VaultIntegrationRepository) ReadVaultIntegration(
+	projectID, id uint,
+) (*ints.VaultIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.vaultInts) || repo.vaultInts[id-1] == nil ||
+		repo.vaultInts[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.vaultInts[id-1], nil
+}
+
+func (repo *VaultIntegrationRepository) ListVaultIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.VaultIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.VaultIntegration, 0)
+
+	for _, vaultInt := range repo.vaultInts {
+		if vaultInt != nil && vaultInt.ProjectID == projectID {
+			res = append(res, vaultInt)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *VaultIntegrationRepository) DeleteVaultIntegration(integrationID uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(integrationID-1) >= len(repo.vaultInts) || repo.vaultInts[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.vaultInts[integrationID-1] = nil
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// WebhookIntegrationRepository will return errors on queries if canQuery is false
+// and stores webhook integrations in-memory
+type WebhookIntegrationRepository struct {
+	canQuery    bool
+	webhookInts []*ints.WebhookIntegration
+}
+
+// NewWebhookIntegrationRepository returns a WebhookIntegrationRepository which
+// stores webhook integrations in-memory
+func NewWebhookIntegrationRepository(canQuery bool) repository.WebhookIntegrationRepository {
+	return &WebhookIntegrationRepository{canQuery, []*ints.WebhookIntegration{}}
+}
+
+func (repo *WebhookIntegrationRepository) CreateWebhookIntegration(
+	webhookInt *ints.WebhookIntegration,
+) (*ints.WebhookIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.webhookInts = append(repo.webhookInts, webhookInt)
+	webhookInt.ID = uint(len(repo.webhookInts))
+
+	return webhookInt, nil
+}
+
+func (repo *WebhookIntegrationRepository) ReadWebhookIntegration(
+	projectID, id uint,
+) (*ints.WebhookIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.webhookInts) || repo.webhookInts[id-1] == nil ||
+		repo.webhookInts[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.webhookInts[id-1], nil
+}
+
+func (repo *WebhookIntegrationRepository) ListWebhookIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.WebhookIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.WebhookIntegration, 0)
+
+	for _, webhookInt := range repo.webhookInts {
+		if webhookInt != nil && webhookInt.ProjectID == projectID {
+			res = append(res, webhookInt)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *WebhookIntegrationRepository) DeleteWebhookIntegration(integrationID uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(integrationID-1) >= len(repo.webhookInts) || repo.webhookInts[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.webhookInts[integrationID-1] = nil
+
+	return nil
+}
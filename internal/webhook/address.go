@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrDisallowedAddress is returned for webhook URLs which point to a loopback, link-local
+// or private address, so that webhooks cannot be used to reach the internal network
+var ErrDisallowedAddress = fmt.Errorf("webhook URL must not point to a loopback, link-local or private address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not covered by IsPrivate
+var _, sharedAddressSpace, _ = net.ParseCIDR("100.64.0.0/10")
+
+// IsDisallowedIP returns true if webhook requests must not be sent to the address
+func IsDisallowedIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// ValidateURL checks that a webhook URL is an http or https URL whose host only resolves
+// to public addresses
+func ValidateURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("webhook URL must be an http or https URL")
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+
+	if err != nil {
+		return fmt.Errorf("could not resolve the host of the webhook URL: %w", err)
+	}
+
+	for _, addr := range addrs {
+		if IsDisallowedIP(addr.IP) {
+			return ErrDisallowedAddress
+		}
+	}
+
+	return nil
+}
+
+// NewClient returns an http client for sending webhook requests which refuses to connect
+// to disallowed addresses. The address is checked when connecting rather than when the
+// webhook is created, so that a host which resolves to a different address later on, or
+// redirects to an internal URL, is rejected as well. Proxies from the environment are not
+// used, since the check would apply to the proxy rather than to the webhook host.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || IsDisallowedIP(ip) {
+				return ErrDisallowedAddress
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
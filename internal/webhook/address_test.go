@@ -0,0 +1,77 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/webhook"
+)
+
+func TestIsDisallowedIP(t *testing.T) {
+	tests := []struct {
+		ip         string
+		disallowed bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.0.0.1", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"fd00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"2001:4860:4860::8888", false},
+	}
+
+	for _, tc := range tests {
+		if got := webhook.IsDisallowedIP(net.ParseIP(tc.ip)); got != tc.disallowed {
+			t.Errorf("%s: expected disallowed to be %t, got %t", tc.ip, tc.disallowed, got)
+		}
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url    string
+		expErr bool
+	}{
+		{"https://8.8.8.8/hook", false},
+		{"ftp://8.8.8.8/hook", true},
+		{"https:///hook", true},
+		{"http://127.0.0.1:8080/hook", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"http://[::1]/hook", true},
+	}
+
+	for _, tc := range tests {
+		err := webhook.ValidateURL(context.Background(), tc.url)
+
+		if tc.expErr && err == nil {
+			t.Errorf("%s: expected error, got nil", tc.url)
+		} else if !tc.expErr && err != nil {
+			t.Errorf("%s: expected no error, got %v", tc.url, err)
+		}
+	}
+}
+
+func TestNewClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := webhook.NewClient(time.Second).Post(server.URL, "application/json", nil)
+
+	if !errors.Is(err, webhook.ErrDisallowedAddress) {
+		t.Fatalf("expected %v, got %v", webhook.ErrDisallowedAddress, err)
+	}
+}
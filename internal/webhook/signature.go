@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the header which carries the signature of a webhook request
+const SignatureHeader = "X-Porter-Signature"
+
+// DefaultSignatureTolerance is the maximum age of a signed webhook request
+const DefaultSignatureTolerance = 5 * time.Minute
+
+var (
+	ErrMissingSignature = fmt.Errorf("missing %s header", SignatureHeader)
+	ErrInvalidSignature = fmt.Errorf("invalid %s header", SignatureHeader)
+	ErrStaleSignature   = fmt.Errorf("%s timestamp is outside of the tolerance window", SignatureHeader)
+)
+
+// Signature is a parsed signature header, of the form t=<unix timestamp>,v1=<hex signature>
+type Signature struct {
+	Timestamp time.Time
+	Value     string
+}
+
+// Sign computes the signature header for a request body sent at the given time. The
+// signature is the hex-encoded HMAC-SHA256 of "<unix timestamp>.<body>", keyed by the
+// signing secret.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp.Unix(), computeSignature(secret, timestamp.Unix(), body))
+}
+
+// ParseSignature parses a signature header
+func ParseSignature(header string) (*Signature, error) {
+	if header == "" {
+		return nil, ErrMissingSignature
+	}
+
+	res := &Signature{}
+	var hasTimestamp bool
+
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+
+		if len(kv) != 2 {
+			return nil, ErrInvalidSignature
+		}
+
+		switch kv[0] {
+		case "t":
+			ts, err := strconv.ParseInt(kv[1], 10, 64)
+
+			if err != nil {
+				return nil, ErrInvalidSignature
+			}
+
+			res.Timestamp = time.Unix(ts, 0)
+			hasTimestamp = true
+		case "v1":
+			res.Value = kv[1]
+		}
+	}
+
+	if !hasTimestamp || res.Value == "" {
+		return nil, ErrInvalidSignature
+	}
+
+	return res, nil
+}
+
+// Verify checks that the signature header is valid for the body and the secret, and
+// that its timestamp is within the tolerance of the current time. The parsed signature
+// is returned so that callers can reject replays of previously accepted signatures.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) (*Signature, error) {
+	sig, err := ParseSignature(header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expected := computeSignature(secret, sig.Timestamp.Unix(), body)
+
+	if !hmac.Equal([]byte(expected), []byte(sig.Value)) {
+		return nil, ErrInvalidSignature
+	}
+
+	if diff := now.Sub(sig.Timestamp); diff > tolerance || diff < -tolerance {
+		return nil, ErrStaleSignature
+	}
+
+	return sig, nil
+}
+
+func computeSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,72 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/webhook"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"commit":"abc123"}`)
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		expErr error
+	}{
+		{
+			name:   "valid signature",
+			header: webhook.Sign("secret", now.Add(-time.Minute), body),
+			body:   body,
+		},
+		{
+			name:   "missing signature",
+			header: "",
+			body:   body,
+			expErr: webhook.ErrMissingSignature,
+		},
+		{
+			name:   "malformed signature",
+			header: "v1=abcdef",
+			body:   body,
+			expErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:   "wrong secret",
+			header: webhook.Sign("other", now, body),
+			body:   body,
+			expErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:   "modified body",
+			header: webhook.Sign("secret", now, body),
+			body:   []byte(`{"commit":"def456"}`),
+			expErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:   "stale timestamp",
+			header: webhook.Sign("secret", now.Add(-10*time.Minute), body),
+			body:   body,
+			expErr: webhook.ErrStaleSignature,
+		},
+		{
+			name:   "timestamp in the future",
+			header: webhook.Sign("secret", now.Add(10*time.Minute), body),
+			body:   body,
+			expErr: webhook.ErrStaleSignature,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := webhook.Verify("secret", tc.header, tc.body, now, webhook.DefaultSignatureTolerance)
+
+			if err != tc.expErr {
+				t.Errorf("expected error %v, got %v", tc.expErr, err)
+			}
+		})
+	}
+}